@@ -3,8 +3,12 @@ package addon
 import (
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/wksctl/cmd/wksctl/addon/build"
+	"github.com/weaveworks/wksctl/cmd/wksctl/addon/diff"
 	"github.com/weaveworks/wksctl/cmd/wksctl/addon/list"
+	"github.com/weaveworks/wksctl/cmd/wksctl/addon/remove"
+	"github.com/weaveworks/wksctl/cmd/wksctl/addon/schema"
 	"github.com/weaveworks/wksctl/cmd/wksctl/addon/show"
+	"github.com/weaveworks/wksctl/cmd/wksctl/addon/test"
 )
 
 var Cmd = &cobra.Command{
@@ -15,6 +19,10 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(build.Cmd)
+	Cmd.AddCommand(diff.Cmd)
 	Cmd.AddCommand(list.Cmd)
+	Cmd.AddCommand(remove.Cmd)
+	Cmd.AddCommand(schema.Cmd)
 	Cmd.AddCommand(show.Cmd)
+	Cmd.AddCommand(test.Cmd)
 }
@@ -3,12 +3,15 @@ package build
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/specs"
 )
 
 var Cmd = &cobra.Command{
@@ -19,14 +22,32 @@ var Cmd = &cobra.Command{
 }
 
 var addonBuildOptions struct {
-	outputDirectory string
-	params          []string
-	imageRepository string
+	outputDirectory       string
+	params                []string
+	imageRepository       string
+	pinDigests            bool
+	transforms            string
+	libraryPaths          []string
+	extVars               []string
+	validate              bool
+	kubeVersion           string
+	sealedSecretCertPath  string
+	imagePullSecretPath   string
+	allowPlaintextSecrets bool
 }
 
 func init() {
 	Cmd.Flags().StringVarP(&addonBuildOptions.outputDirectory, "output-directory", "o", "", "manifest output directory")
 	Cmd.Flags().StringVarP(&addonBuildOptions.imageRepository, "image-repository", "r", "", "use this container repository for addon images")
+	Cmd.Flags().BoolVar(&addonBuildOptions.pinDigests, "pin-digests", false, "pin container images to the digest of their manifest")
+	Cmd.Flags().StringVar(&addonBuildOptions.transforms, "transforms", "", "YAML file with the list of transforms to apply to the addon objects")
+	Cmd.Flags().StringArrayVarP(&addonBuildOptions.libraryPaths, "jpath", "J", nil, "additional jsonnet library search directory")
+	Cmd.Flags().StringArrayVar(&addonBuildOptions.extVars, "ext-str", nil, "jsonnet external variable e.g. --ext-str clusterName=example")
+	Cmd.Flags().BoolVar(&addonBuildOptions.validate, "validate", false, "validate the built objects against the Kubernetes API and CRD schemas")
+	Cmd.Flags().StringVar(&addonBuildOptions.kubeVersion, "kubernetes-version", "", "Kubernetes version to validate objects against, defaults to the most recent supported one")
+	Cmd.Flags().StringVar(&addonBuildOptions.sealedSecretCertPath, "sealed-secret-cert", "", "Path to a certificate used to encrypt sealed secrets")
+	Cmd.Flags().StringVar(&addonBuildOptions.imagePullSecretPath, "image-pull-secret", "", "Path to a SealedSecret of type kubernetes.io/dockerconfigjson the addon pods pull images with")
+	Cmd.Flags().BoolVar(&addonBuildOptions.allowPlaintextSecrets, "allow-plaintext-secrets", false, "write secret parameters in plaintext Secrets when no sealed secrets certificate is given")
 	Cmd.Flags().StringArrayVarP(&addonBuildOptions.params, "params", "p", nil, "addon input parameters e.g. --params foo=bar --params baz=qux")
 }
 
@@ -46,7 +67,8 @@ func parseParam(input string) (name, value string, err error) {
 	return parts[0], parts[1], nil
 }
 
-func makeParams(input []string) (map[string]string, error) {
+// MakeParams parses a list of key=value addon parameters.
+func MakeParams(input []string) (map[string]string, error) {
 	output := make(map[string]string)
 
 	for _, desc := range input {
@@ -68,7 +90,7 @@ func addonBuildRun(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
-	params, err := makeParams(opts.params)
+	params, err := MakeParams(opts.params)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -79,11 +101,57 @@ func addonBuildRun(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	extVars, err := MakeParams(opts.extVars)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var transforms []addons.Transform
+	if opts.transforms != "" {
+		data, err := ioutil.ReadFile(opts.transforms)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if transforms, err = addons.ParseTransforms(data); err != nil {
+			log.Fatalf("invalid transforms %s: %v", opts.transforms, err)
+		}
+		// Transform files are relative to the transforms file.
+		for i := range transforms {
+			if t := transforms[i].Jsonnet; t != "" && !filepath.IsAbs(t) {
+				transforms[i].Jsonnet = filepath.Join(filepath.Dir(opts.transforms), t)
+			}
+		}
+	}
+
+	var cert []byte
+	if opts.sealedSecretCertPath != "" {
+		if cert, err = ioutil.ReadFile(opts.sealedSecretCertPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var pullSecret []byte
+	if opts.imagePullSecretPath != "" {
+		if pullSecret, err = ioutil.ReadFile(opts.imagePullSecretPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	addonOptions := addons.BuildOptions{
-		OutputDirectory: opts.outputDirectory,
-		Params:          params,
-		ImageRepository: opts.imageRepository,
-		YAML:            true,
+		OutputDirectory:       opts.outputDirectory,
+		Params:                params,
+		ImageRepository:       opts.imageRepository,
+		YAML:                  true,
+		PinDigests:            opts.pinDigests,
+		Transforms:            transforms,
+		LibraryPaths:          opts.libraryPaths,
+		ExtVars:               extVars,
+		SealedSecretCert:      cert,
+		AllowPlaintextSecrets: opts.allowPlaintextSecrets,
+		ImagePullSecret:       pullSecret,
+		ImageRewritten: func(r addons.ImageRewrite) {
+			fmt.Printf("rewrote image %s to %s in %s\n", r.From, r.To, r.Object)
+		},
 	}
 
 	if err := addon.ValidateOptions(&addonOptions); err != nil {
@@ -98,4 +166,21 @@ func addonBuildRun(cmd *cobra.Command, args []string) {
 	for _, filename := range manifests {
 		fmt.Printf("wrote %s\n", filename)
 	}
+
+	if !opts.validate {
+		return
+	}
+	validator, err := specs.NewAddonValidator(opts.kubeVersion)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := addons.Validate(validator, manifests); err != nil {
+		if errs, ok := err.(addons.ValidationErrors); ok {
+			for _, e := range errs {
+				log.Error(e)
+			}
+			log.Fatalf("%d invalid fields, validated against Kubernetes %s", len(errs), validator.KubernetesVersion)
+		}
+		log.Fatal(err)
+	}
 }
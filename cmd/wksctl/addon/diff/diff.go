@@ -0,0 +1,294 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	existinginfra1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
+	"github.com/weaveworks/wksctl/cmd/wksctl/addon/build"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/client"
+	objdiff "github.com/weaveworks/wksctl/pkg/kubernetes/diff"
+	"github.com/weaveworks/wksctl/pkg/specs"
+	"github.com/weaveworks/wksctl/pkg/utilities/manifest"
+	"github.com/weaveworks/wksctl/pkg/utilities/path"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the changes applying an addon would make to the cluster",
+	Long: `Build an addon with the parameters found in the cluster manifest and
+compare the result with the objects running in the cluster.
+
+Use "wksctl kubeconfig" to generate the kubeconfig file used to access the
+cluster.`,
+	Args: addonDiffArgs,
+	Run:  addonDiffRun,
+}
+
+var addonDiffOptions struct {
+	clusterManifestPath   string
+	machinesManifestPath  string
+	artifactDirectory     string
+	namespace             string
+	kubeconfig            string
+	params                []string
+	libraryPaths          []string
+	sealedSecretCertPath  string
+	allowPlaintextSecrets bool
+	configDirectory       string
+	summary               bool
+}
+
+func init() {
+	opts := &addonDiffOptions
+	Cmd.Flags().StringVar(&opts.clusterManifestPath, "cluster", "cluster.yaml", "Location of cluster manifest")
+	Cmd.Flags().StringVar(&opts.machinesManifestPath, "machines", "machines.yaml", "Location of machines manifest")
+	Cmd.Flags().StringVar(&opts.artifactDirectory, "artifact-directory", "", "Location of WKS artifacts")
+	Cmd.Flags().StringVar(&opts.namespace, "namespace", manifest.DefaultNamespace, "namespace portion of kubeconfig path")
+	Cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "kubeconfig file to use instead of the one generated by wksctl kubeconfig")
+	Cmd.Flags().StringArrayVarP(&opts.params, "params", "p", nil, "override addon parameters from the cluster manifest e.g. --params foo=bar")
+	Cmd.Flags().StringArrayVarP(&opts.libraryPaths, "jpath", "J", nil, "additional jsonnet library search directory, relative to the cluster manifest")
+	Cmd.Flags().StringVar(&opts.sealedSecretCertPath, "sealed-secret-cert", "", "Path to a certificate used to encrypt sealed secrets")
+	Cmd.Flags().BoolVar(&opts.allowPlaintextSecrets, "allow-plaintext-secrets", false, "build secret parameters in plaintext Secrets when no sealed secrets certificate is given")
+	Cmd.Flags().StringVar(&opts.configDirectory, "config-directory", ".", "Directory containing configuration information for the cluster")
+	Cmd.Flags().BoolVar(&opts.summary, "summary", false, "print a summary of the objects that would be created, changed or orphaned")
+}
+
+func addonDiffArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("diff requires an addon name")
+	}
+	return nil
+}
+
+// result holds the outcome of comparing built objects with the cluster.
+type result struct {
+	created   []string
+	changed   []string
+	unchanged []string
+	orphaned  []string
+}
+
+func addonDiffRun(cmd *cobra.Command, args []string) {
+	opts := &addonDiffOptions
+	name := args[0]
+
+	addon, err := addons.Get(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sp, err := specs.NewFromPaths(opts.clusterManifestPath, opts.machinesManifestPath)
+	if err != nil {
+		log.Fatal("Error parsing manifest: ", err)
+	}
+
+	// Start from the addon in cluster.yaml and apply the overrides.
+	desc := existinginfra1.Addon{Name: name, Params: make(map[string]string)}
+	for _, d := range sp.ClusterSpec.Addons {
+		if d.Name == name {
+			desc.Deps = d.Deps
+			for k, v := range d.Params {
+				desc.Params[k] = v
+			}
+		}
+	}
+	overrides, err := build.MakeParams(opts.params)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for k, v := range overrides {
+		desc.Params[k] = v
+	}
+
+	transforms, err := specs.AddonTransforms(sp.Cluster)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	kubeconfig := opts.kubeconfig
+	if kubeconfig == "" {
+		kubeconfig = path.Kubeconfig(opts.artifactDirectory, opts.namespace, sp.GetClusterName())
+	}
+	c, err := client.NewFromKubeconfig(kubeconfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "wksctl-addon-diff")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var cert []byte
+	if opts.sealedSecretCertPath != "" {
+		if cert, err = ioutil.ReadFile(opts.sealedSecretCertPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	pullSecret, err := specs.ImagePullSecret(sp.Cluster, opts.configDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Build the addon the way apply-addons does.
+	base, err := specs.AddonBuildOptions(sp.Cluster, sp.ClusterSpec, opts.namespace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	base.BasePath = filepath.Dir(opts.clusterManifestPath)
+	base.LibraryPaths = opts.libraryPaths
+	base.SealedSecretCert = cert
+	base.AllowPlaintextSecrets = opts.allowPlaintextSecrets
+	base.ImagePullSecret = pullSecret
+	validateOptions := base
+	validateOptions.Params = desc.Params
+	if err := addon.ValidateOptions(&validateOptions); err != nil {
+		log.Fatalf("invalid options: %v\n", err)
+	}
+	spec := *sp.ClusterSpec
+	spec.Addons = []existinginfra1.Addon{desc}
+	_, built, err := specs.BuildAddons(&spec, base, transforms, tmpDir, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	manifests := built[0]
+
+	var objects []unstructured.Unstructured
+	for _, manifest := range manifests {
+		o, err := client.ReadObjectsFromFile(manifest)
+		if err != nil {
+			log.Fatal(err)
+		}
+		objects = append(objects, o...)
+	}
+
+	res, err := diffObjects(context.Background(), c, name, objects)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if opts.summary {
+		printSummary(res)
+	}
+}
+
+// diffObjects prints the differences between the built objects and the
+// cluster.
+func diffObjects(ctx context.Context, c *client.Client, name string, objects []unstructured.Unstructured) (*result, error) {
+	res := &result{}
+	built := make(map[string]bool)
+
+	for i := range objects {
+		desired := &objects[i]
+		id := client.ObjectName(desired)
+		built[id] = true
+
+		live, err := c.Get(ctx, desired)
+		if apierrors.IsNotFound(err) {
+			fmt.Printf("+ %s\n", id)
+			res.created = append(res.created, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		changes := objdiff.Objects(withoutBuildFields(desired), withoutBuildFields(live))
+		if len(changes) == 0 {
+			res.unchanged = append(res.unchanged, id)
+			continue
+		}
+		fmt.Printf("~ %s\n", id)
+		fmt.Print(objdiff.String(changes, "    "))
+		res.changed = append(res.changed, id)
+	}
+
+	// Objects of the installed addon which aren't part of the build anymore
+	// would be left behind, the inventory of the installed addon lists them.
+	installed, err := addons.GetInventory(ctx, c, name)
+	if err != nil {
+		return nil, err
+	}
+	if installed != nil {
+		for _, ref := range installed.Objects {
+			o := ref.Object()
+			id := client.ObjectName(o)
+			if built[id] {
+				continue
+			}
+			if _, err := c.Get(ctx, o); apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			fmt.Printf("- %s\n", id)
+			res.orphaned = append(res.orphaned, id)
+			built[id] = true
+		}
+	}
+	sort.Strings(res.orphaned)
+
+	return res, nil
+}
+
+// withoutBuildFields returns a copy of o without the fields changing every time
+// an addon is built: the build label and the encrypted values of SealedSecrets,
+// sealed with a new session key each time. Sealed keys are kept so that added
+// and removed keys still show.
+func withoutBuildFields(o *unstructured.Unstructured) *unstructured.Unstructured {
+	o = o.DeepCopy()
+	labels := o.GetLabels()
+	delete(labels, addons.BuildLabel)
+	if len(labels) == 0 {
+		unstructured.RemoveNestedField(o.Object, "metadata", "labels")
+	} else {
+		o.SetLabels(labels)
+	}
+
+	if o.GetKind() != "SealedSecret" {
+		return o
+	}
+	encrypted, found, err := unstructured.NestedMap(o.Object, "spec", "encryptedData")
+	if !found || err != nil {
+		return o
+	}
+	for key := range encrypted {
+		encrypted[key] = "<sealed>"
+	}
+	_ = unstructured.SetNestedMap(o.Object, encrypted, "spec", "encryptedData")
+	return o
+}
+
+func printSummary(res *result) {
+	fmt.Printf("==> %d to create, %d to change, %d unchanged, %d orphaned\n",
+		len(res.created), len(res.changed), len(res.unchanged), len(res.orphaned))
+	for _, section := range []struct {
+		title string
+		ids   []string
+	}{
+		{"created", res.created},
+		{"changed", res.changed},
+		{"orphaned", res.orphaned},
+	} {
+		if len(section.ids) == 0 {
+			continue
+		}
+		fmt.Printf("    %s:\n", section.title)
+		for _, id := range section.ids {
+			fmt.Printf("      %s\n", id)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	objdiff "github.com/weaveworks/wksctl/pkg/kubernetes/diff"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func sealedSecret(build string, encryptedData map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "bitnami.com/v1alpha1",
+		"kind":       "SealedSecret",
+		"metadata": map[string]interface{}{
+			"name":      "flux-git-deploy",
+			"namespace": "flux",
+			"labels": map[string]interface{}{
+				addons.NameLabel:  "flux",
+				addons.BuildLabel: build,
+			},
+		},
+		"spec": map[string]interface{}{
+			"encryptedData": encryptedData,
+		},
+	}}
+}
+
+func TestWithoutBuildFields(t *testing.T) {
+	live := sealedSecret("1111", map[string]interface{}{"identity": "AgBy3i4OJSWK+PiTySYZZA=="})
+
+	// Building again changes the build label and seals the value again.
+	desired := sealedSecret("2222", map[string]interface{}{"identity": "AgCtr8OJSWK+PtySYZZA4i=="})
+	assert.Empty(t, objdiff.Objects(withoutBuildFields(desired), withoutBuildFields(live)))
+	assert.NotEmpty(t, objdiff.Objects(desired, live))
+
+	// Added keys still show.
+	desired = sealedSecret("2222", map[string]interface{}{
+		"identity":    "AgCtr8OJSWK+PtySYZZA4i==",
+		"known_hosts": "AgA4iOJSWK+PtySYZZACtr==",
+	})
+	changes := objdiff.Objects(withoutBuildFields(desired), withoutBuildFields(live))
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, objdiff.Added, changes[0].Op)
+	assert.Equal(t, "spec.encryptedData.known_hosts", changes[0].Path)
+
+	// The addon name label is compared.
+	stripped := withoutBuildFields(desired)
+	assert.Equal(t, map[string]string{addons.NameLabel: "flux"}, stripped.GetLabels())
+	assert.Equal(t, "2222", desired.GetLabels()[addons.BuildLabel])
+}
@@ -3,10 +3,13 @@ package list
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/utilities"
 )
 
 var Cmd = &cobra.Command{
@@ -15,13 +18,42 @@ var Cmd = &cobra.Command{
 	Run:   addonListRun,
 }
 
+var addonListOptions struct {
+	output   string
+	category string
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&addonListOptions.output, "output", "o", "text", "Output format (text|json|yaml)")
+	Cmd.Flags().StringVar(&addonListOptions.category, "category", "", "Only list addons of this category, eg. CNI")
+}
+
 func addonListRun(cmd *cobra.Command, args []string) {
+	opts := &addonListOptions
+
+	var list []addons.Addon
+	for _, addon := range addons.List() {
+		if opts.category != "" && !strings.EqualFold(addon.Category, opts.category) {
+			continue
+		}
+		list = append(list, addon)
+	}
+
+	if opts.output != "text" {
+		if list == nil {
+			list = []addons.Addon{}
+		}
+		if err := utilities.PrintObject(os.Stdout, opts.output, list); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	const tabWidth = 4
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, tabWidth, ' ', 0)
 
-	addons := addons.List()
-	for _, addon := range addons {
-		fmt.Fprintf(w, "%s\t%s\n", addon.ShortName, addon.Name)
+	for _, addon := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", addon.ShortName, addon.Name, addon.Version, addon.KubernetesVersions)
 	}
 
 	w.Flush()
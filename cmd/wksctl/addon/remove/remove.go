@@ -0,0 +1,101 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/client"
+	"github.com/weaveworks/wksctl/pkg/specs"
+	"github.com/weaveworks/wksctl/pkg/utilities/manifest"
+	"github.com/weaveworks/wksctl/pkg/utilities/path"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Delete the objects of an addon from the cluster",
+	Long: `Delete the objects recorded in the inventory of an addon from the
+cluster, in the reverse order they were applied in.
+
+Use "wksctl kubeconfig" to generate the kubeconfig file used to access the
+cluster.`,
+	Args: addonRemoveArgs,
+	Run:  addonRemoveRun,
+}
+
+var addonRemoveOptions struct {
+	clusterManifestPath  string
+	machinesManifestPath string
+	artifactDirectory    string
+	namespace            string
+	kubeconfig           string
+	force                bool
+}
+
+func init() {
+	opts := &addonRemoveOptions
+	Cmd.Flags().StringVar(&opts.clusterManifestPath, "cluster", "cluster.yaml", "Location of cluster manifest")
+	Cmd.Flags().StringVar(&opts.machinesManifestPath, "machines", "machines.yaml", "Location of machines manifest")
+	Cmd.Flags().StringVar(&opts.artifactDirectory, "artifact-directory", "", "Location of WKS artifacts")
+	Cmd.Flags().StringVar(&opts.namespace, "namespace", manifest.DefaultNamespace, "namespace portion of kubeconfig path")
+	Cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "kubeconfig file to use instead of the one generated by wksctl kubeconfig")
+	Cmd.Flags().BoolVar(&opts.force, "force", false, "remove the addon even if other installed addons depend on it")
+}
+
+func addonRemoveArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("remove requires an addon name")
+	}
+	return nil
+}
+
+func addonRemoveRun(cmd *cobra.Command, args []string) {
+	opts := &addonRemoveOptions
+	name := args[0]
+	ctx := context.Background()
+
+	sp, err := specs.NewFromPaths(opts.clusterManifestPath, opts.machinesManifestPath)
+	if err != nil {
+		log.Fatal("Error parsing manifest: ", err)
+	}
+	for _, desc := range sp.ClusterSpec.Addons {
+		if desc.Name == name {
+			log.Warnf("addon %s is still listed in %s and will be installed again by the next apply", name, opts.clusterManifestPath)
+		}
+	}
+
+	kubeconfig := opts.kubeconfig
+	if kubeconfig == "" {
+		kubeconfig = path.Kubeconfig(opts.artifactDirectory, opts.namespace, sp.GetClusterName())
+	}
+	c, err := client.NewFromKubeconfig(kubeconfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	inventories, err := addons.ListInventories(ctx, c)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var inv *addons.Inventory
+	for i := range inventories {
+		if inventories[i].Addon == name {
+			inv = &inventories[i]
+		}
+	}
+	if inv == nil {
+		log.Fatalf("addon %s isn't installed", name)
+	}
+	if dependents := addons.Dependents(inventories, name); len(dependents) > 0 && !opts.force {
+		log.Fatalf("addon %s is required by: %s", name, strings.Join(dependents, ", "))
+	}
+
+	if err := addons.Remove(ctx, c, inv); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("==> Removed addon %s\n", name)
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"errors"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/utilities"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the addon parameters",
+	Long: `Print the JSON Schema of the addon parameters.
+
+The schema validates the params of the addon in the addons section of the
+cluster manifest.`,
+	Args: addonSchemaArgs,
+	Run:  addonSchemaRun,
+}
+
+var addonSchemaOptions struct {
+	output string
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&addonSchemaOptions.output, "output", "o", "json", "Output format (json|yaml)")
+}
+
+func addonSchemaArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("schema requires an addon name")
+	}
+	return nil
+}
+
+func addonSchemaRun(cmd *cobra.Command, args []string) {
+	addon, err := addons.Get(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := utilities.PrintObject(os.Stdout, addonSchemaOptions.output, addon.ParamsSchema()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/utilities"
 )
 
 var Cmd = &cobra.Command{
@@ -18,6 +19,14 @@ var Cmd = &cobra.Command{
 	Run:   addonShowRun,
 }
 
+var addonShowOptions struct {
+	output string
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&addonShowOptions.output, "output", "o", "text", "Output format (text|json|yaml)")
+}
+
 func addonShowArgs(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return errors.New("show requires an addon name")
@@ -31,12 +40,21 @@ func addonShowRun(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
+	if addonShowOptions.output != "text" {
+		if err := utilities.PrintObject(os.Stdout, addonShowOptions.output, addon); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	const tabWidth = 4
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, tabWidth, ' ', 0)
 
 	fmt.Fprintf(w, "Name\t%s\n", addon.Name)
 	fmt.Fprintf(w, "Category\t%s\n", addon.Category)
 	fmt.Fprintf(w, "Description\t%s\n", addon.Description)
+	fmt.Fprintf(w, "Version\t%s\n", addon.Version)
+	fmt.Fprintf(w, "Kubernetes versions\t%s\n", addon.KubernetesVersions)
 	fmt.Fprintf(w, "Params\n")
 	for _, param := range addon.Params {
 		var required string
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/specs"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "test <name|directory>",
+	Short: "Run the golden tests of an addon",
+	Long: `Run the golden tests of an addon.
+
+Test cases are read from the tests directory next to addon.json: each
+tests/<case>.yaml file holds the params (and optionally extVars and
+imageRepository) to build the addon with. The built objects are validated
+against the Kubernetes API and CRD schemas, then compared with
+tests/<case>.golden.yaml. Use --update to write the golden files.
+
+The addon is either the name of an embedded addon or the directory of an
+addon being developed. Listing the addon images is tested as well.`,
+	Args: addonTestArgs,
+	Run:  addonTestRun,
+}
+
+var addonTestOptions struct {
+	update      bool
+	kubeVersion string
+}
+
+func init() {
+	Cmd.Flags().BoolVar(&addonTestOptions.update, "update", false, "write the golden files with the built objects")
+	Cmd.Flags().StringVar(&addonTestOptions.kubeVersion, "kubernetes-version", "", "Kubernetes version to validate objects against, defaults to the most recent supported one")
+}
+
+func addonTestArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("test requires an addon name or directory")
+	}
+	return nil
+}
+
+// getAddon returns the embedded addon called name or the addon in the name
+// directory.
+func getAddon(name string) (addons.Addon, error) {
+	if strings.ContainsRune(name, filepath.Separator) {
+		return addons.Load(name)
+	}
+	if _, err := os.Stat(filepath.Join(name, "addon.json")); err == nil {
+		return addons.Load(name)
+	}
+	return addons.Get(name)
+}
+
+func addonTestRun(cmd *cobra.Command, args []string) {
+	opts := &addonTestOptions
+
+	addon, err := getAddon(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	validator, err := specs.NewAddonValidator(opts.kubeVersion)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, err := addon.RunTests(addons.TestOptions{
+		Update:    opts.update,
+		Validator: validator,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Failed():
+			failed++
+			fmt.Printf("--- FAIL: %s/%s\n", addon.ShortName, r.Name)
+		case r.Updated:
+			fmt.Printf("--- UPDATED: %s/%s\n", addon.ShortName, r.Name)
+		default:
+			fmt.Printf("--- PASS: %s/%s\n", addon.ShortName, r.Name)
+		}
+		if errs, ok := r.Err.(addons.ValidationErrors); ok {
+			for _, e := range errs {
+				fmt.Printf("    %v\n", e)
+			}
+		} else if r.Err != nil {
+			fmt.Printf("    %v\n", r.Err)
+		}
+		if r.Diff != "" {
+			for _, line := range strings.Split(strings.TrimSuffix(r.Diff, "\n"), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+	if failed > 0 {
+		log.Fatalf("%d of %d tests failed", failed, len(results))
+	}
+}
@@ -65,7 +65,7 @@ func init() {
 	Cmd.Flags().StringVar(&globalParams.configDirectory, "config-directory", ".", "Directory containing configuration information for the cluster")
 	Cmd.Flags().StringVar(&globalParams.namespace, "namespace", manifest.DefaultNamespace, "namespace override for WKS components")
 	Cmd.Flags().BoolVar(&globalParams.useManifestNamespace, "use-manifest-namespace", false, "use namespaces from supplied manifests (overriding any --namespace argument)")
-	Cmd.Flags().StringSliceVar(&globalParams.addonNamespaces, "addon-namespace", []string{"weave-net=kube-system"}, "override namespace for specific addons")
+	Cmd.Flags().StringSliceVar(&globalParams.addonNamespaces, "addon-namespace", nil, "override namespace for specific addons, eg. weave-net=kube-system, CNI addons default to the namespace declared by their addon")
 
 	// Hide controller-image flag as it is a helper/debug flag.
 	Cmd.Flags().StringVar(&globalParams.controllerImage, "controller-image", "", "Controller image override")
@@ -109,7 +109,10 @@ func parseCluster(clusterManifest []byte) (c *clusterv1.Cluster, eic *existingin
 }
 
 func (a *Applier) initiateCluster(ctx context.Context, clusterManifestPath, machinesManifestPath string) error {
-	sp := specs.NewFromPaths(clusterManifestPath, machinesManifestPath)
+	sp, err := specs.NewFromPathsWithControllerImage(clusterManifestPath, machinesManifestPath, a.Params.controllerImage)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse manifests")
+	}
 	sshClient, err := ssh.NewClientForMachine(sp.MasterSpec, sp.ClusterSpec.User, a.Params.sshKeyPath, log.GetLevel() > log.InfoLevel)
 
 	if err != nil {
@@ -141,7 +144,8 @@ func (a *Applier) initiateCluster(ctx context.Context, clusterManifestPath, mach
 		ns = a.Params.namespace
 	}
 
-	addonNamespaces := map[string]string{}
+	// CNI addons default to the namespace declared in their addon.json.
+	addonNamespaces := addons.CNINamespaces()
 	if len(a.Params.addonNamespaces) > 0 {
 		for _, entry := range a.Params.addonNamespaces {
 			parts := strings.SplitN(entry, "=", 2)
@@ -167,7 +171,6 @@ func (a *Applier) initiateCluster(ctx context.Context, clusterManifestPath, mach
 			return errors.Wrap(err, "failed to apply the cluster's image repository to the WKS controller's image")
 		}
 	}
-
 	clusterManifest, err := ioutil.ReadFile(clusterManifestPath)
 	if err != nil {
 		return errors.Wrap(err, "failed to read cluster manifest: ")
@@ -194,12 +197,6 @@ func (a *Applier) initiateCluster(ctx context.Context, clusterManifestPath, mach
 		eic.Spec.KubernetesVersion = *machines[0].Spec.Version
 	}
 
-	eic.Spec.DeprecatedSSHKeyPath = a.Params.sshKeyPath
-	clusterManifest, err = wksos.UnparseCluster(cluster, eic)
-	if err != nil {
-		return errors.Wrap(err, "failed to annotate cluster manifest: ")
-	}
-
 	// Read sealed secret cert and key
 	var cert []byte
 	var key []byte
@@ -215,12 +212,54 @@ func (a *Applier) initiateCluster(ctx context.Context, clusterManifestPath, mach
 		}
 	}
 
+	// Every machine writes the registries configuration of its container
+	// runtime from the os.files of the cluster spec. The registry credentials
+	// stay sealed in the cluster, they are unsealed here and written on the
+	// machines over SSH. Machines joined later get them from the
+	// registry-credentials command.
+	registries, err := specs.ParseRegistries(cluster)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse cluster manifest: ")
+	}
+	if registries != nil && registries.CredentialsSecretFile != "" {
+		credentials, err := wksos.ReadRegistryCredentials(string(key), configDir, registries.CredentialsSecretFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the registry credentials: ")
+		}
+		if err := wksos.WriteMachinesRegistryCredentials(ctx, sp.ClusterSpec, machinesManifest, a.Params.sshKeyPath, credentials); err != nil {
+			return err
+		}
+	}
+	eic.Spec.OS.Files, err = specs.RegistryFiles(&eic.Spec, registries)
+	if err != nil {
+		return errors.Wrap(err, "failed to configure the registries: ")
+	}
+
+	eic.Spec.DeprecatedSSHKeyPath = a.Params.sshKeyPath
+	clusterManifest, err = wksos.UnparseCluster(cluster, eic)
+	if err != nil {
+		return errors.Wrap(err, "failed to annotate cluster manifest: ")
+	}
+
+	// The seed node installs the CNI addon selected for the cluster with the
+	// cni script of its spec, the cluster manifest keeps the annotation.
+	seedCluster := *eic
+	seedCluster.Spec.CNI, err = specs.CNIInstallScript(cluster, &eic.Spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to build the CNI addon: ")
+	}
+
+	cniReadiness, err := specs.CNIReadiness(cluster)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the CNI addon readiness checks: ")
+	}
+
 	if err := wksos.SetupSeedNode(installer, capeios.SeedNodeParams{
 		PublicIP:             sp.GetMasterPublicAddress(),
 		PrivateIP:            sp.GetMasterPrivateAddress(),
 		ServicesCIDRBlocks:   sp.Cluster.Spec.ClusterNetwork.Services.CIDRBlocks,
 		PodsCIDRBlocks:       sp.Cluster.Spec.ClusterNetwork.Pods.CIDRBlocks,
-		ExistingInfraCluster: *eic,
+		ExistingInfraCluster: seedCluster,
 		ClusterManifest:      string(clusterManifest),
 		MachinesManifest:     string(machinesManifest),
 		BootstrapToken:       token,
@@ -247,7 +286,7 @@ func (a *Applier) initiateCluster(ctx context.Context, clusterManifestPath, mach
 		Namespace:            ns,
 		AddonNamespaces:      addonNamespaces,
 		Flavor:               sp.ClusterSpec.Flavor,
-	}); err != nil {
+	}, cniReadiness); err != nil {
 		return errors.Wrapf(err, "failed to set up seed node (%s)", sp.GetMasterPublicAddress())
 	}
 
@@ -1,17 +1,20 @@
 package applyaddons
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	capeispecs "github.com/weaveworks/cluster-api-provider-existinginfra/pkg/specs"
 	"github.com/weaveworks/launcher/pkg/kubectl"
 	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/client"
 	"github.com/weaveworks/wksctl/pkg/specs"
 	"github.com/weaveworks/wksctl/pkg/utilities/manifest"
 	"github.com/weaveworks/wksctl/pkg/utilities/path"
@@ -25,10 +28,17 @@ var Cmd = &cobra.Command{
 }
 
 var applyAddonsOptions struct {
-	clusterManifestPath  string
-	machinesManifestPath string
-	artifactDirectory    string
-	namespace            string
+	clusterManifestPath   string
+	machinesManifestPath  string
+	artifactDirectory     string
+	namespace             string
+	prune                 bool
+	libraryPaths          []string
+	sealedSecretCertPath  string
+	allowPlaintextSecrets bool
+	configDirectory       string
+	jobs                  int
+	cacheDirectory        string
 }
 
 func init() {
@@ -39,31 +49,102 @@ func init() {
 		&opts.artifactDirectory, "artifact-directory", "", "Location of WKS artifacts ")
 	Cmd.Flags().StringVar(
 		&applyAddonsOptions.namespace, "namespace", manifest.DefaultNamespace, "namespace portion of kubeconfig path")
+	Cmd.Flags().BoolVar(
+		&opts.prune, "prune", false, "delete the objects of addons which are no longer part of the build or of the cluster manifest")
+	Cmd.Flags().StringArrayVarP(
+		&opts.libraryPaths, "jpath", "J", nil, "additional jsonnet library search directory, relative to the cluster manifest")
+	Cmd.Flags().StringVar(
+		&opts.sealedSecretCertPath, "sealed-secret-cert", "", "Path to a certificate used to encrypt sealed secrets")
+	Cmd.Flags().BoolVar(
+		&opts.allowPlaintextSecrets, "allow-plaintext-secrets", false, "apply secret parameters in plaintext Secrets when no sealed secrets certificate is given")
+	Cmd.Flags().StringVar(
+		&opts.configDirectory, "config-directory", ".", "Directory containing configuration information for the cluster")
+	Cmd.Flags().IntVar(
+		&opts.jobs, "jobs", 0, "number of addons built concurrently, defaults to the number of CPUs")
+	Cmd.Flags().StringVar(
+		&opts.cacheDirectory, "cache-directory", addons.DefaultCacheDirectory(), "directory caching addon builds, empty to disable the cache")
+}
+
+// waitForAddon blocks until the readiness checks of the addon pass.
+func waitForAddon(ctx context.Context, c *client.Client, addon *addons.Addon, params map[string]string) error {
+	for _, check := range addon.ReadinessChecks(params) {
+		var timeout time.Duration
+		if check.Timeout != "" {
+			var err error
+			if timeout, err = time.ParseDuration(check.Timeout); err != nil {
+				return fmt.Errorf("invalid readiness timeout %q: %v", check.Timeout, err)
+			}
+		}
+		if err := c.Wait(ctx, client.WaitArgs{
+			Kind:      check.Kind,
+			Namespace: check.Namespace,
+			Selector:  check.Selector,
+			Condition: check.Condition,
+			Timeout:   timeout,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func applyAddonsUsingConfig(sp *capeispecs.Specs, basePath, kubeconfig string) error {
+// applyAddonsUsingConfig builds and applies the addons of the cluster. base
+// holds the build options common to all addons. Addons are built concurrently,
+// jobs at a time, and applied in order.
+func applyAddonsUsingConfig(sp *capeispecs.Specs, base addons.BuildOptions, transforms map[string][]addons.Transform, kubeconfig string, prune bool, jobs int) error {
 	fmt.Println("==> Applying addons (2)")
 
-	for _, addonDesc := range sp.ClusterSpec.Addons {
-		log.Debugf("applying addon '%s'", addonDesc.Name)
+	ctx := context.Background()
+	var kc *client.Client
+	getClient := func() (*client.Client, error) {
+		if kc != nil {
+			return kc, nil
+		}
+		var err error
+		kc, err = client.NewFromKubeconfig(kubeconfig)
+		return kc, err
+	}
 
-		// Generate the addon manifest.
-		addon, err := addons.Get(addonDesc.Name)
+	// Record the installed addons before applying anything to know what needs
+	// pruning.
+	installed := make(map[string]*addons.Inventory)
+	if prune {
+		if _, err := getClient(); err != nil {
+			return err
+		}
+		inventories, err := addons.ListInventories(ctx, kc)
 		if err != nil {
 			return err
 		}
+		for i := range inventories {
+			installed[inventories[i].Addon] = &inventories[i]
+		}
+	}
 
-		tmpDir, err := ioutil.TempDir("", "wksctl-apply-addons")
+	// Generate the addon manifests.
+	tmpDir, err := ioutil.TempDir("", "wksctl-apply-addons")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	built, builtManifests, err := specs.BuildAddons(sp.ClusterSpec, base, transforms, tmpDir, jobs)
+	if err != nil {
+		return err
+	}
+
+	for i, addonDesc := range sp.ClusterSpec.Addons {
+		log.Debugf("applying addon '%s'", addonDesc.Name)
+		addon, manifests := built[i], builtManifests[i]
+		current, err := addons.ReadInventory(manifests)
 		if err != nil {
 			return err
 		}
-
-		manifests, err := addon.Build(addons.BuildOptions{
-			OutputDirectory: tmpDir,
-			BasePath:        basePath,
-			ImageRepository: sp.ClusterSpec.ImageRepository,
-			Params:          addonDesc.Params,
-		})
+		// The addon owns the namespaces it creates, only those are deleted
+		// when pruning or removing it.
+		if _, err := getClient(); err != nil {
+			return err
+		}
+		namespaces, err := addons.NewNamespaces(ctx, kc, current)
 		if err != nil {
 			return err
 		}
@@ -79,9 +160,43 @@ func applyAddonsUsingConfig(sp *capeispecs.Specs, basePath, kubeconfig string) e
 				return err
 			}
 		}
+		if err := addons.ClaimNamespaces(ctx, kc, addonDesc.Name, namespaces); err != nil {
+			return err
+		}
+
+		if previous, ok := installed[addonDesc.Name]; ok {
+			if err := addons.Prune(ctx, kc, previous, current); err != nil {
+				return fmt.Errorf("failed to prune addon %s: %v", addonDesc.Name, err)
+			}
+			delete(installed, addonDesc.Name)
+		}
 
-		// Remove the generated manifest files.
-		os.RemoveAll(tmpDir)
+		if len(addon.Readiness) == 0 {
+			continue
+		}
+		fmt.Printf("==> Waiting for addon %s to be ready\n", addonDesc.Name)
+		if err := waitForAddon(ctx, kc, &addon, addonDesc.Params); err != nil {
+			return fmt.Errorf("addon %s is not ready: %v", addonDesc.Name, err)
+		}
+		fmt.Printf("==> Addon %s is ready\n", addonDesc.Name)
+	}
+
+	// Addons left are installed but not part of the cluster spec anymore.
+	if len(installed) > 0 {
+		var removed []addons.Inventory
+		for _, inv := range installed {
+			removed = append(removed, *inv)
+		}
+		removed, err := addons.RemovalOrder(removed)
+		if err != nil {
+			return err
+		}
+		for i := range removed {
+			fmt.Printf("==> Removing addon %s\n", removed[i].Addon)
+			if err := addons.Remove(ctx, kc, &removed[i]); err != nil {
+				return fmt.Errorf("failed to remove addon %s: %v", removed[i].Addon, err)
+			}
+		}
 	}
 
 	return nil
@@ -89,7 +204,10 @@ func applyAddonsUsingConfig(sp *capeispecs.Specs, basePath, kubeconfig string) e
 
 func applyAddonsRun(cmd *cobra.Command, args []string) {
 	opts := &applyAddonsOptions
-	sp := specs.NewFromPaths(opts.clusterManifestPath, opts.machinesManifestPath)
+	sp, err := specs.NewFromPaths(opts.clusterManifestPath, opts.machinesManifestPath)
+	if err != nil {
+		log.Fatal("Error parsing manifest: ", err)
+	}
 	configPath := path.Kubeconfig(opts.artifactDirectory, applyAddonsOptions.namespace, sp.GetClusterName())
 
 	if !configExists(configPath) {
@@ -100,7 +218,36 @@ func applyAddonsRun(cmd *cobra.Command, args []string) {
 
 	}
 
-	if err := applyAddonsUsingConfig(sp, filepath.Dir(opts.clusterManifestPath), configPath); err != nil {
+	transforms, err := specs.AddonTransforms(sp.Cluster)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var cert []byte
+	if opts.sealedSecretCertPath != "" {
+		if cert, err = ioutil.ReadFile(opts.sealedSecretCertPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+	// Addons pull images from private registries with the sealed registry
+	// credentials, unsealed in each of their namespaces.
+	pullSecret, err := specs.ImagePullSecret(sp.Cluster, opts.configDirectory)
+	if err != nil {
+		log.Fatal(err)
+	}
+	base, err := specs.AddonBuildOptions(sp.Cluster, sp.ClusterSpec, opts.namespace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	base.BasePath = filepath.Dir(opts.clusterManifestPath)
+	base.LibraryPaths = opts.libraryPaths
+	base.SealedSecretCert = cert
+	base.AllowPlaintextSecrets = opts.allowPlaintextSecrets
+	base.ImagePullSecret = pullSecret
+	base.CacheDirectory = opts.cacheDirectory
+	base.ImageRewritten = func(r addons.ImageRewrite) {
+		log.Debugf("rewrote image %s to %s in %s", r.From, r.To, r.Object)
+	}
+	if err := applyAddonsUsingConfig(sp, base, transforms, configPath, opts.prune, opts.jobs); err != nil {
 		log.Fatal("Error applying addons: ", err)
 	}
 }
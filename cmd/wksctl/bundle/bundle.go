@@ -0,0 +1,20 @@
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/wksctl/cmd/wksctl/bundle/create"
+	"github.com/weaveworks/wksctl/cmd/wksctl/bundle/load"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Create and load bundles for air-gapped installs",
+	Long: `Bundles hold the container images, the rendered addon manifests and, optionally, the packages needed to install a cluster without Internet access.
+
+Create a bundle on a machine with Internet access, move it to the air-gapped network and load it there into the internal registry.`,
+}
+
+func init() {
+	Cmd.AddCommand(create.Cmd)
+	Cmd.AddCommand(load.Cmd)
+}
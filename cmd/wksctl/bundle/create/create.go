@@ -0,0 +1,168 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/kubernetes"
+	"github.com/weaveworks/wksctl/pkg/bundle"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/mirror"
+	"github.com/weaveworks/wksctl/pkg/specs"
+	"github.com/weaveworks/wksctl/pkg/utilities/manifest"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a bundle for air-gapped installs",
+	Long: `Pull the WKS container images, with all their platforms, and write them to a bundle.
+
+When a cluster manifest is given, the bundle also holds the manifests of its addons and of its CNI, rendered with the image repository of the cluster spec.`,
+	Args: cobra.NoArgs,
+	Run:  createRun,
+}
+
+var createOptions struct {
+	output                string
+	clusterManifestPath   string
+	machinesManifestPath  string
+	versionsRange         string
+	packagesDirectory     string
+	sealedSecretCertPath  string
+	allowPlaintextSecrets bool
+	configDirectory       string
+	insecure              []string
+	jobs                  int
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&createOptions.output, "output", "o", "bundle.tar.gz", "Path of the bundle to write")
+	Cmd.Flags().StringVar(&createOptions.clusterManifestPath, "cluster", "", "Location of cluster manifest, to include the images of its flavor and the manifests of its addons")
+	Cmd.Flags().StringVar(&createOptions.machinesManifestPath, "machines", "", "Location of machines manifest, to include the control plane images of its Kubernetes version")
+	Cmd.Flags().StringVar(&createOptions.versionsRange, "versions", "", "Range of Kubernetes semantic versions, e.g.: \""+kubernetes.DefaultVersionsRange+"\"")
+	Cmd.Flags().StringVar(&createOptions.packagesDirectory, "packages", "", "Directory of RPM or DEB packages to include")
+	Cmd.Flags().StringVar(&createOptions.sealedSecretCertPath, "sealed-secret-cert", "", "Path to a certificate used to encrypt sealed secrets")
+	Cmd.Flags().BoolVar(&createOptions.allowPlaintextSecrets, "allow-plaintext-secrets", false, "write secret parameters in plaintext Secrets when no sealed secrets certificate is given")
+	Cmd.Flags().StringVar(&createOptions.configDirectory, "config-directory", ".", "Directory containing configuration information for the cluster")
+	Cmd.Flags().StringSliceVar(&createOptions.insecure, "insecure-registry", nil, "Registries accessed over plain HTTP")
+	Cmd.Flags().IntVar(&createOptions.jobs, "jobs", 0, "Number of images pulled, and of addons built, concurrently, defaults to the number of CPUs")
+}
+
+// buildManifests renders the addons and the CNI of the cluster to dir, one
+// subdirectory per addon.
+func buildManifests(dir string) error {
+	opts := &createOptions
+	if opts.machinesManifestPath == "" {
+		return fmt.Errorf("--machines is required to render the addons of %s", opts.clusterManifestPath)
+	}
+	sp, err := specs.NewFromPaths(opts.clusterManifestPath, opts.machinesManifestPath)
+	if err != nil {
+		return err
+	}
+	transforms, err := specs.AddonTransforms(sp.Cluster)
+	if err != nil {
+		return err
+	}
+	var cert []byte
+	if opts.sealedSecretCertPath != "" {
+		if cert, err = ioutil.ReadFile(opts.sealedSecretCertPath); err != nil {
+			return err
+		}
+	}
+	pullSecret, err := specs.ImagePullSecret(sp.Cluster, opts.configDirectory)
+	if err != nil {
+		return err
+	}
+	base, err := specs.AddonBuildOptions(sp.Cluster, sp.ClusterSpec, manifest.DefaultNamespace)
+	if err != nil {
+		return err
+	}
+	base.BasePath = filepath.Dir(opts.clusterManifestPath)
+	base.SealedSecretCert = cert
+	base.AllowPlaintextSecrets = opts.allowPlaintextSecrets
+	base.ImagePullSecret = pullSecret
+
+	buildDir, err := ioutil.TempDir("", "wksctl-bundle-addons")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+	built, builtManifests, err := specs.BuildAddons(sp.ClusterSpec, base, transforms, buildDir, opts.jobs)
+	if err != nil {
+		return err
+	}
+	for i, addon := range built {
+		addonDir := filepath.Join(dir, sp.ClusterSpec.Addons[i].Name)
+		if err := os.MkdirAll(addonDir, 0755); err != nil {
+			return err
+		}
+		for _, m := range builtManifests[i] {
+			data, err := ioutil.ReadFile(m)
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(addonDir, filepath.Base(m)), data, 0644); err != nil {
+				return fmt.Errorf("failed to write the manifests of addon %s: %v", addon.ShortName, err)
+			}
+		}
+	}
+
+	if _, ok := specs.CNIAddon(sp.Cluster); !ok {
+		return nil
+	}
+	cni, err := specs.BuildCNI(sp.Cluster, sp.ClusterSpec)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "cni.yaml"), cni, 0644)
+}
+
+func createRun(cmd *cobra.Command, args []string) {
+	opts := &createOptions
+	client, err := registry.NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client.Insecure = opts.insecure
+
+	options, err := mirror.ControlPlaneOptions(context.Background(), client, opts.clusterManifestPath, opts.machinesManifestPath, opts.versionsRange)
+	if err != nil {
+		log.Fatal(err)
+	}
+	options.Jobs = opts.jobs
+	images, err := mirror.Images(options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	contents := bundle.Contents{PackagesDirectory: opts.packagesDirectory}
+	for _, image := range images {
+		contents.Images = append(contents.Images, image.String())
+	}
+
+	if opts.clusterManifestPath != "" {
+		dir, err := ioutil.TempDir("", "wksctl-bundle-manifests")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		if err := buildManifests(dir); err != nil {
+			log.Fatal("Error rendering manifests: ", err)
+		}
+		contents.ManifestsDirectory = dir
+	}
+
+	index, err := bundle.Create(context.Background(), client, opts.output, contents, opts.jobs)
+	if err != nil {
+		log.Fatal("Error creating bundle: ", err)
+	}
+	fmt.Printf("Wrote %s: %d images, %d manifests", opts.output, len(index.Images), len(index.Manifests))
+	if index.Packages != "" {
+		fmt.Printf(", %s packages", index.Packages)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,79 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/wksctl/pkg/bundle"
+	"github.com/weaveworks/wksctl/pkg/registry"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "load <bundle>",
+	Short: "Load a bundle into an internal registry",
+	Long: `Push the container images of a bundle to an internal registry and write its manifests and packages to the output directory.
+
+Packages are written along with the configuration of their repository, a yum or apt source file and a ConfigMap to reference from the os.files of the cluster spec. Serve the packages directory at the URL given with --packages-url.`,
+	Args: loadArgs,
+	Run:  loadRun,
+}
+
+var loadOptions struct {
+	repository      string
+	outputDirectory string
+	packagesURL     string
+	insecure        []string
+	jobs            int
+}
+
+func init() {
+	Cmd.Flags().StringVar(&loadOptions.repository, "repository", "", "Repository to push images to, \"host:port\" or \"host:port/org\", as the imageRepository of the cluster spec")
+	Cmd.Flags().StringVar(&loadOptions.outputDirectory, "output-directory", ".", "Directory to write the manifests and packages to")
+	Cmd.Flags().StringVar(&loadOptions.packagesURL, "packages-url", "http://localhost:8080", "URL the packages directory is served from")
+	Cmd.Flags().StringSliceVar(&loadOptions.insecure, "insecure-registry", nil, "Registries accessed over plain HTTP")
+	Cmd.Flags().IntVar(&loadOptions.jobs, "jobs", 0, "Number of images pushed concurrently, defaults to the number of CPUs")
+	_ = Cmd.MarkFlagRequired("repository")
+}
+
+func loadArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("load requires the path of a bundle")
+	}
+	return nil
+}
+
+func loadRun(cmd *cobra.Command, args []string) {
+	client, err := registry.NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client.Insecure = loadOptions.insecure
+
+	result, err := bundle.Load(context.Background(), client, args[0], bundle.LoadOptions{
+		Repository:      loadOptions.repository,
+		OutputDirectory: loadOptions.outputDirectory,
+		PackagesURL:     loadOptions.packagesURL,
+		Workers:         loadOptions.jobs,
+	})
+	if result != nil {
+		for _, r := range result.Images {
+			switch {
+			case r.Err != nil:
+				log.WithField("error", r.Err).Errorf("Failed to push %s.", r.Source)
+			case r.UpToDate:
+				fmt.Printf("%s: up to date\n", r.Destination)
+			default:
+				fmt.Printf("%s: pushed %s (%d blobs copied, %d skipped)\n", r.Destination, r.Source, r.BlobsCopied, r.BlobsSkipped)
+			}
+		}
+		for _, f := range result.Files {
+			fmt.Printf("Wrote %s\n", f)
+		}
+	}
+	if err != nil {
+		log.Fatal("Error loading bundle: ", err)
+	}
+}
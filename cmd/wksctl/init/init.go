@@ -13,7 +13,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	capeios "github.com/weaveworks/cluster-api-provider-existinginfra/pkg/apis/wksprovider/machine/os"
+	"github.com/weaveworks/wksctl/pkg/addons"
 	"github.com/weaveworks/wksctl/pkg/specs"
 	"github.com/weaveworks/wksctl/pkg/utilities/manifest"
 	"github.com/weaveworks/wksctl/pkg/version"
@@ -44,7 +44,7 @@ var (
 	Cmd = &cobra.Command{
 		Use:          "init",
 		Short:        "Update stored kubernetes manifests to match the local cluster environment",
-		Long:         "'wksctl init' configures existing kubernetes 'flux.yaml', and 'weave-net.yaml' manifests in a repository with information about the local GitOps repository, the preferred weave system namespace, and current container image tags. The files can be anywhere in the repository. If either file is absent, 'wksctl init' will return an error.",
+		Long:         "'wksctl init' configures existing kubernetes 'flux.yaml' and CNI addon manifests (eg. 'weave-net.yaml', 'calico.yaml') in a repository with information about the local GitOps repository, the cluster pod network, the preferred weave system namespace, and current container image tags. The files can be anywhere in the repository. If 'flux.yaml' is absent, 'wksctl init' will return an error.",
 		Example:      "wksctl init --namespace=wksctl --git-url=git@github.com:haskellcurry/lambda.git --git-branch=development --git-path=src",
 		RunE:         initRun,
 		SilenceUsage: true,
@@ -59,9 +59,8 @@ var (
 	gitBranchPattern       = multiLineRegexp(`(--git-branch)=\S+`)
 	gitPathPattern         = multiLineRegexp(`(--git-path)=\S+`)
 
-	updates = []manifestUpdate{
-		{name: "weave-net", selector: equal("weave-net.yaml"), updater: updateWeaveNetManifests},
-		{name: "flux", selector: and(prefix("flux"), extension("yaml")), updater: updateFluxManifests}}
+	updates = append(cniUpdates(),
+		manifestUpdate{name: "flux", selector: and(prefix("flux"), extension("yaml")), updater: updateFluxManifests})
 )
 
 func multiLineRegexp(pattern string) *regexp.Regexp {
@@ -118,24 +117,49 @@ func updatedArg(item string) []byte {
 	return []byte(fmt.Sprintf("$1=%s", item))
 }
 
-func updateWeaveNetManifests(contents []byte, options initOptionType) ([]byte, error) {
+// cniUpdates returns the updates of the manifests of the CNI addons, matched
+// by the name of their entry point.
+func cniUpdates() []manifestUpdate {
+	var cni []manifestUpdate
+	for _, addon := range addons.List() {
+		if !addon.IsCNI() {
+			continue
+		}
+		addon := addon
+		cni = append(cni, manifestUpdate{
+			name:     addon.ShortName,
+			selector: equal(addon.EntryPoint),
+			updater: func(contents []byte, options initOptionType) ([]byte, error) {
+				return updateCNIManifests(&addon, contents, options)
+			},
+		})
+	}
+	return cni
+}
+
+func updateCNIManifests(addon *addons.Addon, contents []byte, options initOptionType) ([]byte, error) {
 	clusterManifestPath := (path.Join(options.localRepoDirectory, options.clusterManifestPath))
 	machinesManifestPath := (path.Join(options.localRepoDirectory, options.machinesManifestPath))
-	sp := specs.NewFromPaths(clusterManifestPath, machinesManifestPath)
-
-	podsCIDRBlocks := sp.Cluster.Spec.ClusterNetwork.Pods.CIDRBlocks
-	if len(podsCIDRBlocks) > 0 && podsCIDRBlocks[0] != "" {
-		// setting the pod CIDR block is currently only supported for the weave-net CNI
-		log.Debug("Updating weave-net manifest.")
-		manifests, err := capeios.SetWeaveNetPodCIDRBlock([][]byte{contents}, podsCIDRBlocks[0])
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to inject ipalloc_range")
-		}
-		return manifests[0], nil
+	sp, err := specs.NewFromPaths(clusterManifestPath, machinesManifestPath)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Debug("No change to weave-net manifest")
-	return contents, nil
+	network, err := specs.CNINetwork(sp.Cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the cluster network settings")
+	}
+	if network.PodCIDR == "" && network.MTU == 0 {
+		log.Debugf("No change to %s manifest", addon.ShortName)
+		return contents, nil
+	}
+
+	log.Debugf("Updating %s manifest.", addon.ShortName)
+	updated, err := addon.ConfigureNetwork(contents, network)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to configure the %s network", addon.ShortName)
+	}
+	return updated, nil
 }
 
 func updateFluxManifests(contents []byte, options initOptionType) ([]byte, error) {
@@ -104,7 +104,10 @@ func writeKubeconfig(ctx context.Context, cpath, mpath string) error {
 	var err error
 	var configPath string
 
-	sp := specs.NewFromPaths(cpath, mpath)
+	sp, err := specs.NewFromPaths(cpath, mpath)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse manifests")
+	}
 
 	if kubeconfigOptions.artifactDirectory != "" {
 		wksHome, err = path.CreateDirectory(capeipath.ExpandHome(kubeconfigOptions.artifactDirectory))
@@ -11,13 +11,18 @@ import (
 	"github.com/weaveworks/wksctl/cmd/wksctl/apply"
 	"github.com/weaveworks/wksctl/cmd/wksctl/applyaddons"
 	"github.com/weaveworks/wksctl/cmd/wksctl/bashcompletions"
+	"github.com/weaveworks/wksctl/cmd/wksctl/bundle"
 	initpkg "github.com/weaveworks/wksctl/cmd/wksctl/init"
 	"github.com/weaveworks/wksctl/cmd/wksctl/kubeconfig"
 	"github.com/weaveworks/wksctl/cmd/wksctl/plan"
 	"github.com/weaveworks/wksctl/cmd/wksctl/profile"
+	"github.com/weaveworks/wksctl/cmd/wksctl/registrycredentials"
+	"github.com/weaveworks/wksctl/cmd/wksctl/registrysync"
 	"github.com/weaveworks/wksctl/cmd/wksctl/registrysynccommands"
+	"github.com/weaveworks/wksctl/cmd/wksctl/validate"
 	"github.com/weaveworks/wksctl/cmd/wksctl/version"
 	"github.com/weaveworks/wksctl/cmd/wksctl/zshcompletions"
+	"github.com/weaveworks/wksctl/pkg/specs"
 	v "github.com/weaveworks/wksctl/pkg/version"
 )
 
@@ -43,15 +48,20 @@ func configureLogger(cmd *cobra.Command, args []string) {
 
 func main() {
 	rootCmd.PersistentFlags().BoolVarP(&options.verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVar(&specs.SkipValidation, "skip-validation", false, "Print the validation errors of the cluster and machines manifests as warnings instead of failing")
 
 	rootCmd.AddCommand(addon.Cmd)
 	rootCmd.AddCommand(apply.Cmd)
 	rootCmd.AddCommand(applyaddons.Cmd)
+	rootCmd.AddCommand(bundle.Cmd)
 	rootCmd.AddCommand(initpkg.Cmd)
 	rootCmd.AddCommand(kubeconfig.Cmd)
 	rootCmd.AddCommand(plan.Cmd)
 	rootCmd.AddCommand(profile.Cmd)
+	rootCmd.AddCommand(registrycredentials.Cmd)
+	rootCmd.AddCommand(registrysync.Cmd)
 	rootCmd.AddCommand(registrysynccommands.Cmd)
+	rootCmd.AddCommand(validate.Cmd)
 	rootCmd.AddCommand(version.Cmd)
 
 	rootCmd.AddCommand(bashcompletions.Cmd)
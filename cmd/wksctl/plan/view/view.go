@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/apis/wksprovider/machine/config"
 	capeios "github.com/weaveworks/cluster-api-provider-existinginfra/pkg/apis/wksprovider/machine/os"
+	"github.com/weaveworks/wksctl/pkg/addons"
 	"github.com/weaveworks/wksctl/pkg/manifests"
 	"github.com/weaveworks/wksctl/pkg/plan/runners/ssh"
 	"github.com/weaveworks/wksctl/pkg/specs"
@@ -88,7 +89,10 @@ func planRun(cmd *cobra.Command, args []string) error {
 
 func displayPlan(ctx context.Context, clusterManifestPath, machinesManifestPath string) error {
 	// TODO: reuse the actual plan created by `wksctl apply`, rather than trying to construct a similar plan and printing it.
-	sp := specs.NewFromPaths(clusterManifestPath, machinesManifestPath)
+	sp, err := specs.NewFromPathsWithControllerImage(clusterManifestPath, machinesManifestPath, viewOptions.controllerImage)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse manifests")
+	}
 	sshClient, err := ssh.NewClientForMachine(sp.MasterSpec, sp.ClusterSpec.User, viewOptions.sshKeyPath, viewOptions.verbose)
 	if err != nil {
 		return errors.Wrap(err, "failed to create SSH client: ")
@@ -158,7 +162,7 @@ func displayPlan(ctx context.Context, clusterManifestPath, machinesManifestPath
 		SealedSecretCert: string(cert),
 		SealedSecretKey:  string(key),
 		Namespace:        manifest.DefaultNamespace,
-		AddonNamespaces:  manifest.DefaultAddonNamespaces,
+		AddonNamespaces:  addons.CNINamespaces(),
 		ConfigDirectory:  configDir,
 	}
 	plan, err := capeios.CreateSeedNodeSetupPlan(ctx, installer, params)
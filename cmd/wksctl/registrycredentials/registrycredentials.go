@@ -0,0 +1,67 @@
+package registrycredentials
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	wksos "github.com/weaveworks/wksctl/pkg/apis/wksprovider/machine/os"
+	"github.com/weaveworks/wksctl/pkg/specs"
+)
+
+// Cmd represents the registry-credentials command
+var Cmd = &cobra.Command{
+	Use:   "registry-credentials",
+	Short: "Write the registry credentials of the cluster on its machines",
+	Long: `Unseal the registry credentials of the cluster, see the credentialsSecretFile
+of its registries, and write them on every machine of the machines manifest
+over SSH. wksctl apply writes them on the machines the cluster is created
+with: run this command once machines are added to the machines manifest.`,
+	RunE:         registryCredentialsRun,
+	SilenceUsage: true,
+}
+
+var registryCredentialsOptions struct {
+	clusterManifestPath  string
+	machinesManifestPath string
+	sshKeyPath           string
+	sealedSecretKeyPath  string
+	configDirectory      string
+}
+
+func init() {
+	Cmd.Flags().StringVar(&registryCredentialsOptions.clusterManifestPath, "cluster", "cluster.yaml", "Location of cluster manifest")
+	Cmd.Flags().StringVar(&registryCredentialsOptions.machinesManifestPath, "machines", "machines.yaml", "Location of machines manifest")
+	Cmd.Flags().StringVar(&registryCredentialsOptions.sshKeyPath, "ssh-key", "./cluster-key", "Path to a key authorized to log in to machines by SSH")
+	Cmd.Flags().StringVar(&registryCredentialsOptions.sealedSecretKeyPath, "sealed-secret-key", "", "Path to a key used to decrypt sealed secrets")
+	Cmd.Flags().StringVar(&registryCredentialsOptions.configDirectory, "config-directory", ".", "Directory containing configuration information for the cluster")
+	_ = Cmd.MarkFlagRequired("sealed-secret-key")
+}
+
+func registryCredentialsRun(cmd *cobra.Command, args []string) error {
+	cluster, eic, err := specs.ParseClusterManifest(registryCredentialsOptions.clusterManifestPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse cluster manifest: ")
+	}
+	registries, err := specs.ParseRegistries(cluster)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse cluster manifest: ")
+	}
+	if registries == nil || registries.CredentialsSecretFile == "" {
+		return errors.New("the cluster has no registry credentials")
+	}
+
+	key, err := ioutil.ReadFile(registryCredentialsOptions.sealedSecretKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read sealed secret key: ")
+	}
+	credentials, err := wksos.ReadRegistryCredentials(string(key), registryCredentialsOptions.configDirectory, registries.CredentialsSecretFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the registry credentials: ")
+	}
+	machinesManifest, err := ioutil.ReadFile(registryCredentialsOptions.machinesManifestPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read machines manifest: ")
+	}
+	return wksos.WriteMachinesRegistryCredentials(cmd.Context(), &eic.Spec, machinesManifest, registryCredentialsOptions.sshKeyPath, credentials)
+}
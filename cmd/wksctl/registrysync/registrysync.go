@@ -0,0 +1,96 @@
+package registrysync
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/kubernetes"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/mirror"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "registry-sync",
+	Short: "Synchronize container images to an internal registry",
+	Long: `Copy the WKS container images to the provided destination organization and registry.
+
+Images are copied directly between registries, without a container runtime, using the credentials of the docker client configuration. Multi-architecture images are copied with all their platforms and layers already present in the destination registry aren't copied again.`,
+	Args: cobra.NoArgs,
+	Run:  registrySyncRun,
+}
+
+var registrySyncOptions struct {
+	destRegistry         string
+	destOrganization     string
+	clusterManifestPath  string
+	machinesManifestPath string
+	versionsRange        string
+	insecure             []string
+	jobs                 int
+	dryRun               bool
+}
+
+func init() {
+	Cmd.Flags().StringVar(&registrySyncOptions.destRegistry, "dest-registry", "localhost:1337", "Destination registry that will be used to push images to")
+	Cmd.Flags().StringVar(&registrySyncOptions.destOrganization, "dest-organization", "wks", "Destination organization that will be used to push images to")
+	Cmd.Flags().StringVar(&registrySyncOptions.clusterManifestPath, "cluster", "", "Location of cluster manifest, to include the images of its flavor")
+	Cmd.Flags().StringVar(&registrySyncOptions.machinesManifestPath, "machines", "", "Location of machines manifest, to include the control plane images of its Kubernetes version")
+	Cmd.Flags().StringVar(&registrySyncOptions.versionsRange, "versions", "", "Range of Kubernetes semantic versions, e.g.: \""+kubernetes.DefaultVersionsRange+"\"")
+	Cmd.Flags().StringSliceVar(&registrySyncOptions.insecure, "insecure-registry", nil, "Registries accessed over plain HTTP")
+	Cmd.Flags().IntVar(&registrySyncOptions.jobs, "jobs", 0, "Number of images copied, and of addons built to list their images, concurrently, defaults to the number of CPUs")
+	Cmd.Flags().BoolVar(&registrySyncOptions.dryRun, "dry-run", false, "Print the images that would be copied without copying them")
+}
+
+func registrySyncRun(cmd *cobra.Command, args []string) {
+	client, err := registry.NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client.Insecure = registrySyncOptions.insecure
+
+	options, err := mirror.ControlPlaneOptions(context.Background(), client, registrySyncOptions.clusterManifestPath, registrySyncOptions.machinesManifestPath, registrySyncOptions.versionsRange)
+	if err != nil {
+		log.Fatal(err)
+	}
+	options.Jobs = registrySyncOptions.jobs
+	images, err := mirror.Images(options)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	destinations, err := mirror.Destinations(images, registrySyncOptions.destRegistry, registrySyncOptions.destOrganization)
+	if err != nil {
+		log.Fatal(err)
+	}
+	requests := make([]registry.CopyRequest, 0, len(images))
+	for i, image := range images {
+		requests = append(requests, registry.CopyRequest{
+			Source:      image.String(),
+			Destination: destinations[i].String(),
+		})
+	}
+	results := client.CopyAll(context.Background(), requests, registry.CopyOptions{
+		DryRun:  registrySyncOptions.dryRun,
+		Workers: registrySyncOptions.jobs,
+	})
+
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			log.WithField("error", result.Err).Errorf("Failed to copy %s.", result.Source)
+			failed++
+		case result.UpToDate:
+			fmt.Printf("%s: up to date\n", result.Destination)
+		case registrySyncOptions.dryRun:
+			fmt.Printf("%s: would copy %s\n", result.Destination, result.Source)
+		default:
+			fmt.Printf("%s: copied %s (%d blobs copied, %d skipped)\n", result.Destination, result.Source, result.BlobsCopied, result.BlobsSkipped)
+		}
+	}
+	if failed > 0 {
+		log.Fatalf("Failed to copy %d of %d images.", failed, len(results))
+	}
+}
@@ -1,67 +1,115 @@
 package registrysynccommands
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"sort"
+	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/kubernetes"
-	"github.com/weaveworks/wksctl/pkg/addons"
 	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/mirror"
 )
 
 var Cmd = &cobra.Command{
 	Use:   "registry-sync-commands",
 	Short: "Synchronize container images to an internal registry",
-	Long:  "Generate docker commands to STDOUT to pull, tag, and push the WKS container images to the provided destination organization and registry.",
-	Run:   registrySyncRun,
+	Long: `Generate commands to STDOUT copying the WKS container images to the provided destination organization and registry.
+
+The commands are docker, podman, skopeo or crane commands depending on --format. --format json prints a JSON object mapping the source images to their destination instead.`,
+	Args: cobra.NoArgs,
+	Run:  registrySyncRun,
 }
 
 var registrySyncOptions struct {
 	destRegistry         string
 	destOrganization     string
+	clusterManifestPath  string
 	machinesManifestPath string
 	versionsRange        string
+	jobs                 int
+	format               string
+	insecure             bool
 }
 
+const formatJSON = "json"
+
 func init() {
 	Cmd.Flags().StringVar(&registrySyncOptions.destRegistry, "dest-registry", "localhost:1337", "Destination registry that will be used to push images to")
 	Cmd.Flags().StringVar(&registrySyncOptions.destOrganization, "dest-organization", "wks", "Destination organization that will be used to push images to")
-	Cmd.Flags().StringVar(&registrySyncOptions.machinesManifestPath, "machines", "", "Location of machines manifest")
+	Cmd.Flags().StringVar(&registrySyncOptions.clusterManifestPath, "cluster", "", "Location of cluster manifest, to include the images of its flavor")
+	Cmd.Flags().StringVar(&registrySyncOptions.machinesManifestPath, "machines", "", "Location of machines manifest, to include the control plane images of its Kubernetes version")
 	Cmd.Flags().StringVar(&registrySyncOptions.versionsRange, "versions", "", "Range of Kubernetes semantic versions, e.g.: \""+kubernetes.DefaultVersionsRange+"\"")
+	Cmd.Flags().IntVar(&registrySyncOptions.jobs, "jobs", 0, "Number of addons built concurrently to list their images, defaults to the number of CPUs")
+	Cmd.Flags().StringVar(&registrySyncOptions.format, "format", string(registry.FormatDocker), "Output format, one of "+formats())
+	Cmd.Flags().BoolVar(&registrySyncOptions.insecure, "insecure", false, "Push to the destination registry without TLS verification")
 }
 
-func registrySyncRun(cmd *cobra.Command, args []string) {
-	imagesSet := make(map[registry.Image]struct{}) // to deduplicate images.
+func formats() string {
+	var names []string
+	for _, format := range registry.CommandFormats {
+		names = append(names, string(format))
+	}
+	return strings.Join(append(names, formatJSON), "|")
+}
 
-	// Get addons' images:
-	for _, addon := range addons.List() {
-		addonImages, err := addon.ListImages()
-		if err != nil {
-			log.WithField("error", err).WithField("addon", addon.Name).Fatal("Failed to get addon's images.")
-		}
-		for _, image := range addonImages {
-			imagesSet[image] = struct{}{}
+func validFormat(format string) bool {
+	for _, f := range registry.CommandFormats {
+		if string(f) == format {
+			return true
 		}
 	}
+	return format == formatJSON
+}
+
+func registrySyncRun(cmd *cobra.Command, args []string) {
+	format := registry.CommandFormat(registrySyncOptions.format)
+	if !validFormat(registrySyncOptions.format) {
+		log.Fatalf("Invalid --format %q, expected one of %s.", registrySyncOptions.format, formats())
+	}
+
+	client, err := registry.NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+	options, err := mirror.ControlPlaneOptions(context.Background(), client, registrySyncOptions.clusterManifestPath, registrySyncOptions.machinesManifestPath, registrySyncOptions.versionsRange)
+	if err != nil {
+		log.Fatal(err)
+	}
+	options.Jobs = registrySyncOptions.jobs
+	images, err := mirror.Images(options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	destImages, err := mirror.Destinations(images, registrySyncOptions.destRegistry, registrySyncOptions.destOrganization)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	// Convert set back into a slice:
-	images := make([]registry.Image, 0, len(imagesSet))
-	for image := range imagesSet {
-		images = append(images, image)
+	if registrySyncOptions.format == formatJSON {
+		mapping := make(map[string]string, len(images))
+		for i, sourceImage := range images {
+			mapping[sourceImage.String()] = destImages[i].String()
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(mapping); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
-	sort.Sort(registry.ByCoordinate(images))
 
 	// Generate all commands:
 	commands := make([]string, 0, 3*len(images))
-	for _, sourceImage := range images {
-		// Make a copy of the source image and overrides the registry:
-		destImage := sourceImage
-		destImage.Registry = registrySyncOptions.destRegistry
-		// set the organization for the image
-		destImage.User = registrySyncOptions.destOrganization
-		commands = append(commands, sourceImage.CommandsToRetagAs(destImage)...)
+	for i, sourceImage := range images {
+		imageCommands, err := sourceImage.CommandsToCopyAs(destImages[i], format, registrySyncOptions.insecure)
+		if err != nil {
+			log.Fatal(err)
+		}
+		commands = append(commands, imageCommands...)
 	}
 
 	// Print all commands:
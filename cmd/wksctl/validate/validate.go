@@ -0,0 +1,206 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/wksctl/pkg/specs"
+	"github.com/weaveworks/wksctl/pkg/version"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the cluster and machines manifests",
+	Long: `Validate the cluster and machines manifests and the addons of the cluster, without connecting to the machines.
+
+Errors are printed with the path of the invalid field and its location in the manifests, as text, as JSON or as a SARIF log to annotate pull requests. The command exits with a non-zero status when the manifests are invalid, unless --skip-validation downgrades the errors to warnings.`,
+	Args: cobra.NoArgs,
+	Run:  validateRun,
+}
+
+var validateOptions struct {
+	clusterManifestPath  string
+	machinesManifestPath string
+	output               string
+}
+
+func init() {
+	Cmd.Flags().StringVar(&validateOptions.clusterManifestPath, "cluster", "cluster.yaml", "Location of cluster manifest")
+	Cmd.Flags().StringVar(&validateOptions.machinesManifestPath, "machines", "machines.yaml", "Location of machines manifest")
+	Cmd.Flags().StringVarP(&validateOptions.output, "output", "o", "text", "Output format (text|json|sarif)")
+}
+
+func validateRun(cmd *cobra.Command, args []string) {
+	opts := &validateOptions
+	var print func(io.Writer, []specs.ValidationError) error
+	switch opts.output {
+	case "text":
+		print = printText
+	case "json":
+		print = printJSON
+	case "sarif":
+		print = printSARIF
+	default:
+		log.Fatalf("Invalid --output %q, expected one of text|json|sarif.", opts.output)
+	}
+
+	errors, err := specs.Validate(opts.clusterManifestPath, opts.machinesManifestPath)
+	if err != nil {
+		log.Fatal("Error parsing manifest: ", err)
+	}
+	if err := print(os.Stdout, errors); err != nil {
+		log.Fatal(err)
+	}
+	if len(errors) > 0 && !specs.SkipValidation {
+		os.Exit(1)
+	}
+}
+
+// printText prints errors in the "file:line:column: message" format of
+// compilers.
+func printText(w io.Writer, errors []specs.ValidationError) error {
+	for _, e := range errors {
+		var err error
+		if e.Line > 0 {
+			_, err = fmt.Fprintf(w, "%s:%d:%d: %s\n", e.Manifest, e.Line, e.Column, e.Message)
+		} else {
+			_, err = fmt.Fprintf(w, "%s: %s\n", e.Manifest, e.Message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printJSON(w io.Writer, errors []specs.ValidationError) error {
+	if errors == nil {
+		errors = []specs.ValidationError{}
+	}
+	data, err := json.MarshalIndent(errors, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// SARIF 2.1.0 log, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html. Only the
+// properties used by wksctl are defined.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func newSARIFLog(errors []specs.ValidationError, level string) *sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "wksctl",
+			InformationURI: "https://github.com/weaveworks/wksctl",
+			Version:        version.Version,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, e := range errors {
+		ruleID := string(e.Type)
+		if !rules[ruleID] {
+			rules[ruleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: ruleID})
+		}
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: e.Manifest},
+			},
+			LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: e.Field}},
+		}
+		if e.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: e.Line, StartColumn: e.Column}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    ruleID,
+			Level:     level,
+			Message:   sarifMessage{Text: e.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+	return &sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
+
+func printSARIF(w io.Writer, errors []specs.ValidationError) error {
+	level := "error"
+	if specs.SkipValidation {
+		level = "warning"
+	}
+	data, err := json.MarshalIndent(newSARIFLog(errors, level), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/specs"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var validationErrors = []specs.ValidationError{
+	{
+		Manifest: "cluster.yaml",
+		Line:     21,
+		Column:   3,
+		Field:    "cluster.spec.providerSpec.value.sshKeyPath",
+		Type:     field.ErrorTypeInvalid,
+		Message:  "cluster.spec.providerSpec.value.sshKeyPath: Invalid value: \"/etc/hosts\": not empty",
+	},
+	{
+		Manifest: "machines.yaml",
+		Field:    "spec",
+		Type:     field.ErrorTypeInvalid,
+		Message:  "spec: Invalid value: \"[...]\": no machines",
+	},
+}
+
+func TestPrintText(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, printText(&buf, validationErrors))
+	assert.Equal(t, `cluster.yaml:21:3: cluster.spec.providerSpec.value.sshKeyPath: Invalid value: "/etc/hosts": not empty
+machines.yaml: spec: Invalid value: "[...]": no machines
+`, buf.String())
+}
+
+func TestSARIFLog(t *testing.T) {
+	log := newSARIFLog(validationErrors, "error")
+	assert.Equal(t, "2.1.0", log.Version)
+	run := log.Runs[0]
+	assert.Equal(t, []sarifRule{{ID: "FieldValueInvalid"}}, run.Tool.Driver.Rules)
+	assert.Len(t, run.Results, 2)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: "cluster.yaml"},
+			Region:           &sarifRegion{StartLine: 21, StartColumn: 3},
+		},
+		LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "cluster.spec.providerSpec.value.sshKeyPath"}},
+	}, run.Results[0].Locations[0])
+	// Errors which can't be located point at the manifest.
+	assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region)
+
+	var buf bytes.Buffer
+	assert.NoError(t, printJSON(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
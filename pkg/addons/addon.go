@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/google/go-jsonnet"
@@ -16,12 +19,17 @@ import (
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/utilities/manifest"
 	"github.com/weaveworks/libgitops/pkg/serializer"
 	"github.com/weaveworks/wksctl/pkg/addons/assets"
-	"github.com/weaveworks/wksctl/pkg/qjson"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/client"
 	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/utilities/version"
 )
 
 const (
 	descriptor = "addon.json"
+
+	// NameLabel is the label identifying the addon a Kubernetes object belongs
+	// to.
+	NameLabel = "wksctl.weave.works/addon"
 )
 
 // addonKind specifies what type of addon has been defined.
@@ -42,6 +50,11 @@ const (
 	ParamKindString ParamKind = ""
 	// ParamKindFile is base64-encoded file content.
 	ParamKindFile ParamKind = "file"
+	// ParamKindSecret is secret file content, eg. a private key. It's never
+	// written in plaintext: the Secrets holding it are sealed with the cluster
+	// sealed secrets certificate. Secret parameters can also reference an
+	// existing Secret with "secretRef:<name>".
+	ParamKindSecret ParamKind = "secret"
 )
 
 // ParamError describes an error on the input parameters.
@@ -71,13 +84,17 @@ func newParamErrorf(param, format string, args ...interface{}) error {
 // Param is a input parameter for addon configuration.
 type Param struct {
 	// Name of the parameter
-	Name string
+	Name string `json:"name"`
 	// Target is the TLA name in the jsonnet file. Defaults to Name.
-	Target       string
-	Kind         ParamKind
-	Required     bool
-	DefaultValue string
-	Description  string
+	Target       string    `json:"target,omitempty"`
+	Kind         ParamKind `json:"kind,omitempty"`
+	Required     bool      `json:"required,omitempty"`
+	DefaultValue string    `json:"defaultValue"`
+	Description  string    `json:"description"`
+	// SecretRefTarget is the TLA name receiving the name of the existing
+	// Secret a secret parameter references. Secret parameters can only
+	// reference Secrets when it's set.
+	SecretRefTarget string `json:"secretRefTarget,omitempty"`
 }
 
 func resolvePath(base, s string) string {
@@ -97,6 +114,8 @@ func (p *Param) value(config *BuildOptions, input string) (string, error) {
 			return "", err
 		}
 		return base64.StdEncoding.EncodeToString(content), nil
+	case ParamKindSecret:
+		return p.secretValue(config, input)
 	case ParamKindString:
 		fallthrough
 	default:
@@ -104,6 +123,41 @@ func (p *Param) value(config *BuildOptions, input string) (string, error) {
 	}
 }
 
+// ReadinessCheck describes a condition the objects deployed by an addon need to
+// meet before the addon is considered ready.
+type ReadinessCheck struct {
+	// Kind is the type of object to wait for, eg. "pods" or "deployment".
+	Kind string `json:"kind"`
+	// Namespace the objects live in. Defaults to the value of the addon
+	// "namespace" parameter, if the addon has one.
+	Namespace string `json:"namespace,omitempty"`
+	// Selector is a label selector restricting which objects to wait for, eg.
+	// "name=weave-net".
+	Selector string `json:"selector,omitempty"`
+	// Condition to wait for, using the "kubectl wait --for" syntax, eg.
+	// "condition=Available".
+	Condition string `json:"condition"`
+	// Timeout is how long to wait for the condition to be met, eg. "5m".
+	// Defaults to 30s.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Validate checks the readiness check can be waited on.
+func (c *ReadinessCheck) Validate() error {
+	if c.Kind == "" {
+		return fmt.Errorf("missing readiness kind")
+	}
+	if err := client.ValidateCondition(c.Condition); err != nil {
+		return err
+	}
+	if c.Timeout != "" {
+		if _, err := time.ParseDuration(c.Timeout); err != nil {
+			return fmt.Errorf("invalid readiness timeout %q: %v", c.Timeout, err)
+		}
+	}
+	return nil
+}
+
 // output is the jsonnet evaluation mode
 type output string
 
@@ -114,17 +168,22 @@ const (
 
 // Addon is a piece of software that can be installed on a Kubernetes cluster.
 type Addon struct {
-	Kind        addonKind
-	Category    string
-	Name        string
-	Description string
-	Params      []Param
-
-	ShortName string // The directory name in addons/
+	Kind        addonKind `json:"kind,omitempty"`
+	Category    string    `json:"category"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	// Version is the version of the software the addon installs.
+	Version string `json:"version,omitempty"`
+	// KubernetesVersions is the range of Kubernetes versions the addon
+	// supports, eg. ">=1.16.1 <=1.20.x". Any version is supported when empty.
+	KubernetesVersions string  `json:"kubernetesVersions,omitempty"`
+	Params             []Param `json:"params,omitempty"`
+
+	ShortName string `json:"shortName"` // The directory name in addons/
 	// Entrypoint is either:
 	//  - the jsonnet file to execute to build manifests for jsonnet addons.
 	//  - a multi-document YAML file for YAML addons.
-	EntryPoint string
+	EntryPoint string `json:"entryPoint"`
 	// Jsonnet file to execute to list images. The result is an array of image
 	// strings. eg.
 	// [
@@ -133,8 +192,19 @@ type Addon struct {
 	//   "quay.io/coreos/configmap-reload:v0.0.1",
 	//   "grafana/grafana:5.2.4"
 	// ]
-	ListImagesEntryPoint string
-	OutputMode           output // How to evaluate the jsonnet script. Default to Single.
+	ListImagesEntryPoint string `json:"listImagesEntryPoint,omitempty"`
+	OutputMode           output `json:"outputMode,omitempty"` // How to evaluate the jsonnet script. Default to Single.
+	// Readiness lists the checks to perform once the addon manifests have been
+	// applied to consider the addon ready.
+	Readiness []ReadinessCheck `json:"readiness,omitempty"`
+	// CNI is set for addons installing a pod network.
+	CNI *CNI `json:"cni,omitempty"`
+
+	// assets holds the addon files under /<ShortName>, next to the jsonnet
+	// libraries.
+	assets http.FileSystem
+	// dir is the directory of addons loaded from the filesystem.
+	dir string
 }
 
 func addonDescriptor(shortName string) string {
@@ -143,13 +213,47 @@ func addonDescriptor(shortName string) string {
 
 // Get returns the Addon with the corresponding shortName.
 func Get(shortName string) (Addon, error) {
-	desc, err := assets.Assets.Open(addonDescriptor(shortName))
+	return load(assets.Assets, shortName)
+}
+
+// addonDir serves an addon directory on the filesystem under /<shortName> and
+// everything else from the embedded assets, eg. the jsonnet libraries.
+type addonDir struct {
+	shortName string
+	dir       string
+}
+
+func (d *addonDir) Open(name string) (http.File, error) {
+	prefix := "/" + d.shortName
+	if name == prefix || strings.HasPrefix(name, prefix+"/") {
+		return http.Dir(d.dir).Open(strings.TrimPrefix(name, prefix))
+	}
+	return assets.Assets.Open(name)
+}
+
+// Load returns the addon defined in dir, eg. an addon developed outside of
+// wksctl. Its short name is the name of the directory.
+func Load(dir string) (Addon, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Addon{}, err
+	}
+	shortName := filepath.Base(dir)
+	addon, err := load(&addonDir{shortName: shortName, dir: dir}, shortName)
+	addon.dir = dir
+	return addon, err
+}
+
+func load(fs http.FileSystem, shortName string) (Addon, error) {
+	desc, err := fs.Open(addonDescriptor(shortName))
 	if err != nil {
 		return Addon{}, fmt.Errorf("addon: couldn't find %s", shortName)
 	}
+	defer desc.Close()
 
 	addon := Addon{
 		ShortName: shortName,
+		assets:    fs,
 	}
 	if err := json.NewDecoder(desc).Decode(&addon); err != nil {
 		return addon, fmt.Errorf("addon: couldn't parse descriptor for %s", shortName)
@@ -202,11 +306,51 @@ func (a *Addon) Param(name string) *Param {
 	return nil
 }
 
+// SupportsKubernetesVersion returns true if the addon supports the given
+// Kubernetes version.
+func (a *Addon) SupportsKubernetesVersion(kubernetesVersion string) (bool, error) {
+	return version.MatchesRange(kubernetesVersion, a.KubernetesVersions)
+}
+
 // HasParam returns true if the addon has name as input parameter.
 func (a *Addon) HasParam(name string) bool {
 	return a.Param(name) != nil
 }
 
+// ReadinessChecks returns the readiness checks of the addon when built with the
+// provided parameters.
+func (a *Addon) ReadinessChecks(params map[string]string) []ReadinessCheck {
+	checks := make([]ReadinessCheck, len(a.Readiness))
+	for i, check := range a.Readiness {
+		if check.Namespace == "" {
+			check.Namespace = a.paramValueOrDefault("namespace", params)
+		}
+		checks[i] = check
+	}
+	return checks
+}
+
+// ValidateReadiness checks every readiness check of the addon.
+func (a *Addon) ValidateReadiness() error {
+	for i, check := range a.Readiness {
+		if err := check.Validate(); err != nil {
+			return fmt.Errorf("readiness check %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func (a *Addon) paramValueOrDefault(name string, params map[string]string) string {
+	param := a.Param(name)
+	if param == nil {
+		return ""
+	}
+	if v, ok := params[name]; ok {
+		return v
+	}
+	return param.DefaultValue
+}
+
 // BuildOptions holds some options for Build.
 type BuildOptions struct {
 	// Base path against which relative paths should be resolved (used for File
@@ -220,6 +364,45 @@ type BuildOptions struct {
 	// registry instead of their default one(s).
 	ImageRepository string
 	YAML            bool
+	// ImageRewritten, if not nil, is called for every container image
+	// rewritten because of ImageRepository.
+	ImageRewritten func(ImageRewrite)
+	// Transforms modify the built objects, after images have been rewritten.
+	Transforms []Transform
+	// LibraryPaths are directories searched for jsonnet imports not found in
+	// the addon, the right-most one first. Relative paths are resolved against
+	// BasePath.
+	LibraryPaths []string
+	// ExtVars are jsonnet external variables, see ExtVar* for the ones set
+	// from the cluster manifest. Known external variables default to "".
+	ExtVars map[string]string
+	// BuildID is recorded as a label on every built object. It defaults to a
+	// hash of the built objects.
+	BuildID string
+	// Deps are the addons this addon depends on, recorded in its inventory.
+	Deps []string
+	// PinDigests rewrites container image references to include the digest of
+	// the image manifest, eg. "name:tag@sha256:…".
+	PinDigests bool
+	// Resolver resolves image digests when pinning. It defaults to querying
+	// registries with the credentials of the docker client configuration.
+	Resolver DigestResolver
+	// SealedSecretCert is the PEM encoded certificate of the cluster sealed
+	// secrets controller, used to seal the Secrets holding secret parameters.
+	SealedSecretCert []byte
+	// AllowPlaintextSecrets lets the build write the value of secret
+	// parameters in plaintext, eg. when no SealedSecretCert is given.
+	AllowPlaintextSecrets bool
+	// ImagePullSecret is a SealedSecret of type kubernetes.io/dockerconfigjson,
+	// sealed cluster-wide. Pods pull images with it, and a copy of it is built
+	// in each of their namespaces.
+	ImagePullSecret []byte
+	// Network holds the cluster network settings injected into CNI addons.
+	Network Network
+	// CacheDirectory, if not empty, caches builds: a build with the same
+	// addon, options and wksctl version copies the cached manifests instead
+	// of building them again. ImageRewritten isn't called for cached builds.
+	CacheDirectory string
 }
 
 func extension(config *BuildOptions) string {
@@ -268,6 +451,36 @@ func (a *Addon) absEntryPoint(entry string) string {
 	return "/" + a.ShortName + "/" + entry
 }
 
+func (a *Addon) fs() http.FileSystem {
+	if a.assets == nil {
+		return assets.Assets
+	}
+	return a.assets
+}
+
+// importCache returns the cache of the files imported by the addon.
+func (a *Addon) importCache() *importCache {
+	if a.dir == "" {
+		return embeddedImportCache
+	}
+	return newImportCache()
+}
+
+// readFile reads a file of the addon, path being relative to the addon
+// directory.
+func (a *Addon) readFile(path string) (string, error) {
+	f, err := a.fs().Open(a.absEntryPoint(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 func (a *Addon) evaluate(vm *jsonnet.VM, config *BuildOptions, script string) (map[string]string, error) {
 	output := make(map[string]string)
 
@@ -293,28 +506,29 @@ func (a *Addon) evaluate(vm *jsonnet.VM, config *BuildOptions, script string) (m
 	return output, nil
 }
 
-func makeVM() *jsonnet.VM {
-	vm := jsonnet.MakeVM()
-
-	importer := newVFSImporter()
-	importer.searchPaths = []string{"/", "/vendor"}
-	importer.assets = assets.Assets
-	vm.Importer(importer)
-
-	return vm
-}
-
-func (a *Addon) buildJsonnet(config BuildOptions) ([]string, error) {
-	vm := makeVM()
+func (a *Addon) buildJsonnet(config BuildOptions) ([]*manifestFile, error) {
+	vm := makeVM(&config, a.fs(), a.importCache())
 
-	contents, err := assets.ReadAll(a.absEntryPoint(a.EntryPoint))
+	contents, err := a.readFile(a.EntryPoint)
 	if err != nil {
 		return nil, err
 	}
 
 	// If the addon exposes it, we can override the repository of container images.
 	if config.ImageRepository != "" && a.HasParam("imageRepository") {
-		config.Params["imageRepository"] = config.ImageRepository
+		params := map[string]string{}
+		for k, v := range config.Params {
+			params[k] = v
+		}
+		params["imageRepository"] = config.ImageRepository
+		config.Params = params
+	}
+
+	if params := a.networkParams(&config); len(params) > 0 {
+		for k, v := range config.Params {
+			params[k] = v
+		}
+		config.Params = params
 	}
 
 	for k, v := range config.Params {
@@ -326,7 +540,11 @@ func (a *Addon) buildJsonnet(config BuildOptions) ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
-		vm.TLAVar(param.Target, value)
+		target := param.Target
+		if _, ok := secretRef(v); ok && param.Kind == ParamKindSecret {
+			target = param.SecretRefTarget
+		}
+		vm.TLAVar(target, value)
 	}
 
 	output, err := a.evaluate(vm, &config, string(contents))
@@ -334,8 +552,19 @@ func (a *Addon) buildJsonnet(config BuildOptions) ([]string, error) {
 		return nil, err
 	}
 
-	for filename, strValue := range output {
-		content := []byte(strValue)
+	// Sorting is important as it's used to order creation of Kubernetes objects
+	// by, eg. the prometheus operator with 00namespace.yaml.
+	filenames := make([]string, 0, len(output))
+	for filename := range output {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	// Manifests are kept in memory until post-processed: they can hold secret
+	// parameters in plaintext.
+	var files []*manifestFile
+	for _, filename := range filenames {
+		content := []byte(output[filename])
 		if config.YAML {
 			data, err := yaml.JSONToYAML(content)
 			if err != nil {
@@ -347,83 +576,65 @@ func (a *Addon) buildJsonnet(config BuildOptions) ([]string, error) {
 				return nil, err
 			}
 		}
-		if err := ioutil.WriteFile(filename, content, 0660); err != nil {
+		m, err := parseManifest(filename, content)
+		if err != nil {
 			return nil, err
 		}
+		files = append(files, m)
 	}
-
-	// Build the list of written manifests.
-	manifests := make([]string, len(output))
-	i := 0
-	for k := range output {
-		manifests[i] = k
-		i++
-	}
-
-	// Sorting is important as it's used to order creation of Kubernetes objects
-	// by, eg. the prometheus operator with 00namespace.yaml.
-	sort.Strings(manifests)
-
-	return manifests, nil
+	return files, nil
 }
 
-func (a *Addon) buildYAML(config BuildOptions) ([]string, error) {
-	manifests, err := assets.ReadAll(a.absEntryPoint(a.EntryPoint))
+func (a *Addon) buildYAML(config BuildOptions) ([]*manifestFile, error) {
+	manifests, err := a.readFile(a.EntryPoint)
 	if err != nil {
 		return nil, err
 	}
 
-	// Rewrite the images references
-	var objects object
-	if config.ImageRepository != "" {
-		var err error
-
-		objects, err = newObjectFromYAML([]byte(manifests))
-		if err != nil {
-			return nil, err
-		}
-		objects = transformList(objects, withImageRepository(config.ImageRepository))
-	}
-
-	var output []byte
-	if !objects.IsEmpty() {
-		if config.YAML {
-			output, err = objects.toYAML()
-		} else {
-			output, err = objects.toJSON()
-		}
-	} else {
-		if config.YAML {
-			output = []byte(manifests)
-		} else {
-			output, err = yaml.YAMLToJSON([]byte(manifests))
-		}
-	}
-
+	// Images are rewritten and the output format applied to every document
+	// of the manifest when post-processing the build.
+	m, err := parseManifest(filepath.Join(config.OutputDirectory, a.EntryPoint), []byte(manifests))
 	if err != nil {
 		return nil, err
 	}
-
-	filename := filepath.Join(config.OutputDirectory, a.EntryPoint)
-	if err := ioutil.WriteFile(filename, output, 0660); err != nil {
-		return nil, err
-	}
-	return []string{
-		filename,
-	}, nil
+	return []*manifestFile{m}, nil
 }
 
 // Build builds the addon manifests and write them to disk. It returns the list
-// of written files.
+// of written files, the last one being the addon inventory.
 func (a *Addon) Build(config BuildOptions) ([]string, error) {
+	key, cacheable := a.cacheKey(&config)
+	if cacheable {
+		if manifests, ok := restoreBuild(&config, key); ok {
+			log.Debugf("addon: using cached build of %s", a.ShortName)
+			return manifests, nil
+		}
+	}
+
+	var files []*manifestFile
+	var err error
 	switch a.Kind {
 	case addonKindJsonnet:
-		return a.buildJsonnet(config)
+		files, err = a.buildJsonnet(config)
 	case addonKindYAML:
-		return a.buildYAML(config)
+		files, err = a.buildYAML(config)
 	default:
 		return nil, fmt.Errorf("unknown addon kind '%s'", a.Kind)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	built, err := a.postBuild(&config, files)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		if err := storeBuild(&config, key, built); err != nil {
+			log.Debugf("addon: failed to cache the build of %s: %v", a.ShortName, err)
+		}
+	}
+	return built, nil
 }
 
 func (a *Addon) listImagesFromManifest() ([]registry.Image, error) {
@@ -441,24 +652,15 @@ func (a *Addon) listImagesFromManifest() ([]registry.Image, error) {
 	var images []registry.Image
 	for _, manifest := range manifests {
 		// Extract the container images from this Kubernetes manifest:
-		jsonBytes, err := ioutil.ReadFile(manifest)
+		data, err := ioutil.ReadFile(manifest)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read addon manifest: %v", err)
 		}
-
-		imageStrings, err := qjson.CollectStrings("spec.containers.#.image", jsonBytes)
+		manifestImages, err := ListImagesFromManifest(data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to extract images from addon %s: %v", a.Name, err)
 		}
-
-		// Parse and collect all the extracted container images:
-		for _, imageString := range imageStrings {
-			image, err := registry.NewImage(imageString)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse image %s from addon %s: %v", imageString, a.Name, err)
-			}
-			images = append(images, *image)
-		}
+		images = append(images, manifestImages...)
 	}
 
 	// Cleanup the generated manifests.
@@ -468,9 +670,9 @@ func (a *Addon) listImagesFromManifest() ([]registry.Image, error) {
 }
 
 func (a *Addon) listImagesFromScript() ([]registry.Image, error) {
-	vm := makeVM()
+	vm := makeVM(&BuildOptions{}, a.fs(), a.importCache())
 
-	script, err := assets.ReadAll(a.absEntryPoint(a.ListImagesEntryPoint))
+	script, err := a.readFile(a.ListImagesEntryPoint)
 	if err != nil {
 		return nil, err
 	}
@@ -497,6 +699,36 @@ func (a *Addon) listImagesFromScript() ([]registry.Image, error) {
 	return images, nil
 }
 
+// ListImagesFromManifest lists the container images used by the Kubernetes
+// objects of a JSON or (multi-document) YAML manifest. Images are listed once,
+// in the order they are found.
+func ListImagesFromManifest(data []byte) ([]registry.Image, error) {
+	m, err := parseManifest("", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []registry.Image
+	seen := make(map[string]bool)
+	var firstErr error
+	m.forEachObject(func(o object) {
+		forEachContainer(o, func(container object) {
+			imageString, err := container.GetString("image")
+			if err != nil || seen[imageString] || firstErr != nil {
+				return
+			}
+			seen[imageString] = true
+			image, err := registry.NewImage(imageString)
+			if err != nil {
+				firstErr = fmt.Errorf("failed to parse image %s: %v", imageString, err)
+				return
+			}
+			images = append(images, *image)
+		})
+	})
+	return images, firstErr
+}
+
 // ListImages lists all container images required for this addon to run.
 func (a *Addon) ListImages() ([]registry.Image, error) {
 	if a.ListImagesEntryPoint == "" {
@@ -525,7 +757,7 @@ func (a *Addon) autoBuild(config BuildOptions) ([]string, error) {
 		// Try to automatically provide required parameters:
 		if param.Required {
 			switch param.Kind {
-			case ParamKindFile:
+			case ParamKindFile, ParamKindSecret:
 				params[param.Name] = tmpFile.Name()
 			case ParamKindString:
 				params[param.Name] = "string_autovalue"
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema"
 	"github.com/weaveworks/wksctl/pkg/registry"
 )
 
@@ -63,3 +64,135 @@ func TestListImagesAllAddons(t *testing.T) {
 		})
 	}
 }
+
+func TestAddonVersions(t *testing.T) {
+	for _, addon := range List() {
+		t.Run(addon.ShortName, func(t *testing.T) {
+			assert.NotEmpty(t, addon.Version)
+			supported, err := addon.SupportsKubernetesVersion("1.20.2")
+			assert.NoError(t, err)
+			assert.True(t, supported)
+			supported, err = addon.SupportsKubernetesVersion("1.14.1")
+			assert.NoError(t, err)
+			assert.False(t, supported)
+		})
+	}
+}
+
+func TestReadinessChecks(t *testing.T) {
+	addon, err := Get("flux")
+	assert.NoError(t, err)
+
+	checks := addon.ReadinessChecks(nil)
+	assert.Equal(t, 2, len(checks))
+	for _, check := range checks {
+		assert.Equal(t, "flux", check.Namespace)
+		assert.Equal(t, "condition=Available", check.Condition)
+	}
+
+	checks = addon.ReadinessChecks(map[string]string{"namespace": "weave"})
+	for _, check := range checks {
+		assert.Equal(t, "weave", check.Namespace)
+	}
+}
+
+func TestValidateReadiness(t *testing.T) {
+	tests := []struct {
+		check ReadinessCheck
+		valid bool
+	}{
+		{ReadinessCheck{Kind: "deployment", Condition: "condition=Available", Timeout: "5m"}, true},
+		{ReadinessCheck{Kind: "pods", Condition: "delete"}, true},
+		{ReadinessCheck{Condition: "condition=Available"}, false},
+		{ReadinessCheck{Kind: "deployment", Condition: "Available"}, false},
+		{ReadinessCheck{Kind: "deployment", Condition: "condition=Available", Timeout: "5"}, false},
+	}
+	for _, test := range tests {
+		addon := Addon{Readiness: []ReadinessCheck{test.check}}
+		err := addon.ValidateReadiness()
+		if test.valid {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+
+	for _, name := range []string{"flux", "flux-helm-op", "weave-net", "calico", "cilium"} {
+		addon, err := Get(name)
+		assert.NoError(t, err)
+		assert.NoError(t, addon.ValidateReadiness(), name)
+	}
+}
+
+func TestListImagesFromManifest(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: apps/v1
+  kind: Deployment
+  spec:
+    template:
+      spec:
+        initContainers:
+        - image: busybox:1.32
+        containers:
+        - image: quay.io/org/app:v1
+        - image: busybox:1.32
+---
+apiVersion: batch/v1beta1
+kind: CronJob
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - image: org/job:v2
+---
+apiVersion: v1
+kind: Pod
+spec:
+  containers:
+  - image: pod
+`
+	images, err := ListImagesFromManifest([]byte(manifest))
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.Image{
+		{Name: "busybox", Tag: "1.32"},
+		{Registry: "quay.io", User: "org", Name: "app", Tag: "v1"},
+		{User: "org", Name: "job", Tag: "v2"},
+		{Name: "pod"},
+	}, images)
+}
+
+func TestParamsSchema(t *testing.T) {
+	addon, err := Get("flux")
+	assert.NoError(t, err)
+
+	schema := addon.ParamsSchema()
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"gitURL"}, schema.Required)
+	assert.Len(t, schema.Properties, len(addon.Params))
+	assert.Equal(t, "master", schema.Properties["gitBranch"].Default)
+	assert.False(t, *schema.AdditionalProperties)
+}
+
+func TestValidateAllAddons(t *testing.T) {
+	v, err := schema.New("")
+	assert.NoError(t, err)
+
+	for _, addon := range List() {
+		t.Run(addon.ShortName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "validate-"+addon.ShortName)
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			manifests, err := addon.autoBuild(BuildOptions{
+				OutputDirectory: dir,
+			})
+			assert.NoError(t, err)
+			assert.NoError(t, Validate(v, manifests))
+		})
+	}
+}
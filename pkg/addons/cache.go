@@ -0,0 +1,273 @@
+package addons
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/weaveworks/wksctl/pkg/addons/assets"
+	"github.com/weaveworks/wksctl/pkg/version"
+)
+
+// cacheIndex lists the manifests of a cached build, relative to the build
+// output directory, in the order returned by Build.
+const cacheIndex = "manifests.json"
+
+// DefaultCacheDirectory returns the directory caching addon builds in the user
+// cache directory, or "" if there's no such directory.
+func DefaultCacheDirectory() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wksctl", "addons")
+}
+
+var embeddedAssetsDigest struct {
+	once   sync.Once
+	digest string
+	err    error
+}
+
+// hashAssets hashes the names and contents of the files of fs under dir.
+func hashAssets(h io.Writer, fs http.FileSystem, dir string) error {
+	f, err := fs.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	files, err := f.Readdir(-1)
+	if err != nil {
+		return err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name() < files[j].Name()
+	})
+	for _, fi := range files {
+		name := path.Join(dir, fi.Name())
+		if fi.IsDir() {
+			if err := hashAssets(h, fs, name); err != nil {
+				return err
+			}
+			continue
+		}
+		file, err := fs.Open(name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s %d\n", name, fi.Size())
+		_, err = io.Copy(h, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assetsDigest returns a digest of the embedded addons, so that cached builds
+// of wksctl development versions aren't reused once the addons change.
+func assetsDigest() (string, error) {
+	d := &embeddedAssetsDigest
+	d.once.Do(func() {
+		h := sha256.New()
+		d.err = hashAssets(h, assets.Assets, "/")
+		d.digest = hex.EncodeToString(h.Sum(nil))
+	})
+	return d.digest, d.err
+}
+
+func hashFile(filename string) (string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cacheKey returns the key of the build in the cache, derived from everything
+// the built objects depend on. Builds depending on something the key can't
+// capture aren't cached: it then returns false. So are builds failing to hash
+// their inputs, the build reports the error.
+func (a *Addon) cacheKey(config *BuildOptions) (string, bool) {
+	switch {
+	case config.CacheDirectory == "",
+		// Addons loaded from a directory are being worked on.
+		a.dir != "",
+		// Digests change with the registries.
+		config.PinDigests,
+		// Imported library files aren't tracked.
+		len(config.LibraryPaths) > 0:
+		return "", false
+	}
+	for i := range config.Transforms {
+		if config.Transforms[i].Jsonnet != "" {
+			return "", false
+		}
+	}
+
+	files := make(map[string]string)
+	for name, value := range config.Params {
+		param := a.Param(name)
+		if param == nil || param.Kind == ParamKindString {
+			continue
+		}
+		if _, ok := secretRef(value); ok && param.Kind == ParamKindSecret {
+			continue
+		}
+		if param.Kind == ParamKindSecret && (len(config.SealedSecretCert) == 0 || config.AllowPlaintextSecrets) {
+			// Don't write plaintext secrets to the cache.
+			return "", false
+		}
+		sum, err := hashFile(resolvePath(config.BasePath, value))
+		if err != nil {
+			return "", false
+		}
+		files[name] = sum
+	}
+
+	digest, err := assetsDigest()
+	if err != nil {
+		return "", false
+	}
+	// encoding/json sorts map keys: the key doesn't depend on iteration order.
+	data, err := json.Marshal(struct {
+		Addon                 string
+		AddonVersion          string
+		WksctlVersion         string
+		Assets                string
+		Params                map[string]string
+		Files                 map[string]string
+		ImageRepository       string
+		YAML                  bool
+		Transforms            []Transform
+		ExtVars               map[string]string
+		BuildID               string
+		Deps                  []string
+		SealedSecretCert      []byte
+		AllowPlaintextSecrets bool
+		ImagePullSecret       []byte
+		Network               Network
+	}{
+		Addon:                 a.ShortName,
+		AddonVersion:          a.Version,
+		WksctlVersion:         version.Version,
+		Assets:                digest,
+		Params:                config.Params,
+		Files:                 files,
+		ImageRepository:       config.ImageRepository,
+		YAML:                  config.YAML,
+		Transforms:            config.Transforms,
+		ExtVars:               config.ExtVars,
+		BuildID:               config.BuildID,
+		Deps:                  config.Deps,
+		SealedSecretCert:      config.SealedSecretCert,
+		AllowPlaintextSecrets: config.AllowPlaintextSecrets,
+		ImagePullSecret:       config.ImagePullSecret,
+		Network:               config.Network,
+	})
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), true
+}
+
+func outputDirectory(config *BuildOptions) string {
+	if config.OutputDirectory == "" {
+		return "."
+	}
+	return config.OutputDirectory
+}
+
+func copyFile(dst, src string) error {
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0770); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dst, data, 0660)
+}
+
+// restoreBuild copies the manifests of a cached build to the output directory.
+// It returns false if the build isn't cached.
+func restoreBuild(config *BuildOptions, key string) ([]string, bool) {
+	entry := filepath.Join(config.CacheDirectory, key)
+	data, err := ioutil.ReadFile(filepath.Join(entry, cacheIndex))
+	if err != nil {
+		return nil, false
+	}
+	var files []string
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, false
+	}
+
+	var manifests []string
+	for _, f := range files {
+		filename := filepath.Join(config.OutputDirectory, f)
+		if err := copyFile(filename, filepath.Join(entry, f)); err != nil {
+			log.Debugf("addon: failed to restore cached build %s: %v", key, err)
+			return nil, false
+		}
+		manifests = append(manifests, filename)
+	}
+	return manifests, true
+}
+
+// storeBuild copies the built manifests to the cache. Entries are written
+// under a temporary name and renamed, so that concurrent builds never see
+// partial entries.
+func storeBuild(config *BuildOptions, key string, manifests []string) error {
+	if err := os.MkdirAll(config.CacheDirectory, 0770); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempDir(config.CacheDirectory, key+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	var files []string
+	for _, filename := range manifests {
+		f, err := filepath.Rel(outputDirectory(config), filename)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(filepath.Join(tmp, f), filename); err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+	data, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmp, cacheIndex), data, 0660); err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp, filepath.Join(config.CacheDirectory, key))
+	if err != nil && isCached(config, key) {
+		// Stored by a concurrent build.
+		return nil
+	}
+	return err
+}
+
+func isCached(config *BuildOptions, key string) bool {
+	_, err := os.Stat(filepath.Join(config.CacheDirectory, key, cacheIndex))
+	return err == nil
+}
@@ -0,0 +1,94 @@
+package addons
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedDigest string
+
+func (d fixedDigest) Digest(ctx context.Context, image string) (string, error) {
+	return string(d), nil
+}
+
+func cacheEntries(t *testing.T, dir string) int {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	assert.NoError(t, err)
+	return len(files)
+}
+
+func TestBuildCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cacheDir := filepath.Join(dir, "cache")
+
+	addon, err := Get("weave-net")
+	assert.NoError(t, err)
+	build := func(config BuildOptions) string {
+		output, err := ioutil.TempDir(dir, "output")
+		assert.NoError(t, err)
+		config.OutputDirectory = output
+		config.CacheDirectory = cacheDir
+		config.YAML = true
+		manifests, err := addon.Build(config)
+		assert.NoError(t, err)
+		assert.Len(t, manifests, 2)
+		data, err := ioutil.ReadFile(manifests[0])
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	built := build(BuildOptions{})
+	assert.Equal(t, 1, cacheEntries(t, cacheDir))
+
+	// Tamper with the cached manifest to tell cached builds apart.
+	entries, err := ioutil.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	cached := filepath.Join(cacheDir, entries[0].Name(), addon.EntryPoint)
+	data, err := ioutil.ReadFile(cached)
+	assert.NoError(t, err)
+	assert.Equal(t, built, string(data))
+	assert.NoError(t, ioutil.WriteFile(cached, []byte("# cached\n"), 0660))
+
+	assert.Equal(t, "# cached\n", build(BuildOptions{}))
+	assert.Equal(t, 1, cacheEntries(t, cacheDir))
+
+	// Different options are different builds.
+	assert.Contains(t, build(BuildOptions{ImageRepository: "example.com/org"}), "example.com/org/weave-kube")
+	assert.Contains(t, build(BuildOptions{Network: Network{PodCIDR: "10.32.0.0/12"}}), "10.32.0.0/12")
+	assert.Equal(t, 3, cacheEntries(t, cacheDir))
+
+	// Digests aren't cached.
+	build(BuildOptions{PinDigests: true, Resolver: fixedDigest("sha256:" + strings.Repeat("0", 64))})
+	assert.Equal(t, 3, cacheEntries(t, cacheDir))
+}
+
+func TestBuildCacheSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build-cache-secrets")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cacheDir := filepath.Join(dir, "cache")
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "id_rsa"), []byte(deployKey), 0600))
+
+	addon, err := Get("flux")
+	assert.NoError(t, err)
+	_, err = addon.Build(BuildOptions{
+		BasePath:              dir,
+		OutputDirectory:       dir,
+		CacheDirectory:        cacheDir,
+		Params:                map[string]string{"gitURL": "git@github.com:weaveworks/wksctl", "gitDeployKey": "id_rsa"},
+		AllowPlaintextSecrets: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cacheEntries(t, cacheDir))
+}
@@ -0,0 +1,236 @@
+package addons
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Injection describes where a CNI addon reads a setting from. Exactly one of
+// Param, Env or ConfigMap is set.
+type Injection struct {
+	// Param is the addon parameter receiving the value, for jsonnet addons.
+	Param string `json:"param,omitempty"`
+	// Env is the environment variable of Container receiving the value.
+	Env       string `json:"env,omitempty"`
+	Container string `json:"container,omitempty"`
+	// ConfigMap is the name of the ConfigMap holding the value under Key.
+	ConfigMap string `json:"configMap,omitempty"`
+	Key       string `json:"key,omitempty"`
+}
+
+// CNI describes how the cluster network settings are injected into a CNI
+// addon.
+type CNI struct {
+	// PodCIDR is where the pod network CIDR block goes.
+	PodCIDR *Injection `json:"podCIDR,omitempty"`
+	// MTU is where the MTU of the pod network goes.
+	MTU *Injection `json:"mtu,omitempty"`
+	// Namespace is the namespace of the addon objects. Namespaced objects
+	// without namespace are moved to it.
+	Namespace string `json:"namespace,omitempty"`
+	// MaxPodCIDRPrefixLength is the longest pod CIDR prefix the addon
+	// supports, eg. 26 when the addon allocates /26 blocks to nodes.
+	MaxPodCIDRPrefixLength int `json:"maxPodCIDRPrefixLength,omitempty"`
+}
+
+// Network holds the cluster network settings injected into CNI addons.
+type Network struct {
+	// PodCIDR is the pod network CIDR block.
+	PodCIDR string
+	// MTU of the pod network, left to the addon default when 0.
+	MTU int
+}
+
+// IsCNI returns true if the addon installs a pod network.
+func (a *Addon) IsCNI() bool {
+	return a.CNI != nil
+}
+
+// GetCNI returns the CNI addon with the corresponding shortName.
+func GetCNI(shortName string) (Addon, error) {
+	addon, err := Get(shortName)
+	if err != nil {
+		return addon, err
+	}
+	if !addon.IsCNI() {
+		return addon, fmt.Errorf("addon: %s isn't a CNI addon", shortName)
+	}
+	return addon, nil
+}
+
+// CNINamespaces returns the namespace of each embedded CNI addon, indexed by
+// addon name.
+func CNINamespaces() map[string]string {
+	namespaces := make(map[string]string)
+	for _, addon := range List() {
+		if addon.IsCNI() && addon.CNI.Namespace != "" {
+			namespaces[addon.ShortName] = addon.CNI.Namespace
+		}
+	}
+	return namespaces
+}
+
+// ValidatePodCIDR checks the addon supports prefixLength long pod CIDR
+// blocks.
+func (a *Addon) ValidatePodCIDR(prefixLength int) error {
+	if !a.IsCNI() || a.CNI.MaxPodCIDRPrefixLength == 0 {
+		return nil
+	}
+	if prefixLength > a.CNI.MaxPodCIDRPrefixLength {
+		return fmt.Errorf("%s requires a pod network of /%d or larger", a.ShortName, a.CNI.MaxPodCIDRPrefixLength)
+	}
+	return nil
+}
+
+// networkSetting is a network setting paired with where it goes.
+type networkSetting struct {
+	injection *Injection
+	value     string
+}
+
+// networkSettings returns the network settings to inject, in a stable order.
+func (a *Addon) networkSettings(network *Network) []networkSetting {
+	var settings []networkSetting
+	if a.CNI.PodCIDR != nil && network.PodCIDR != "" {
+		settings = append(settings, networkSetting{a.CNI.PodCIDR, network.PodCIDR})
+	}
+	if a.CNI.MTU != nil && network.MTU != 0 {
+		settings = append(settings, networkSetting{a.CNI.MTU, strconv.Itoa(network.MTU)})
+	}
+	return settings
+}
+
+// networkParams returns the addon parameters receiving network settings.
+// Parameters given explicitly take precedence.
+func (a *Addon) networkParams(config *BuildOptions) map[string]string {
+	if !a.IsCNI() {
+		return nil
+	}
+	params := make(map[string]string)
+	for _, setting := range a.networkSettings(&config.Network) {
+		param := setting.injection.Param
+		if param == "" {
+			continue
+		}
+		if _, ok := config.Params[param]; !ok {
+			params[param] = setting.value
+		}
+	}
+	return params
+}
+
+// clusterScopedKinds are the kinds of the objects without namespace found in
+// CNI manifests.
+var clusterScopedKinds = map[string]bool{
+	"APIService":                     true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CustomResourceDefinition":       true,
+	"MutatingWebhookConfiguration":   true,
+	"Namespace":                      true,
+	"PodSecurityPolicy":              true,
+	"PriorityClass":                  true,
+	"ValidatingWebhookConfiguration": true,
+}
+
+func withEnv(container, name, value string) transform {
+	return func(o object) (object, error) {
+		forEachContainer(o, func(c object) {
+			if c.String("name") != container {
+				return
+			}
+			env := c.ObjectArray("env")
+			found := false
+			for _, e := range env {
+				if e.String("name") == name {
+					e.SetString("value", value)
+					delete(e, "valueFrom")
+					found = true
+				}
+			}
+			if !found {
+				env = append(env, object{"name": name, "value": value})
+			}
+			var list []interface{}
+			for _, e := range env {
+				list = append(list, map[string]interface{}(e))
+			}
+			c.Set("env", list)
+		})
+		return o, nil
+	}
+}
+
+func withConfigMapKey(name, key, value string) transform {
+	return func(o object) (object, error) {
+		if o.String("kind") != "ConfigMap" || o.String("metadata.name") != name {
+			return o, nil
+		}
+		data := o.Object("data")
+		if data == nil {
+			data = newObject()
+		}
+		data[key] = value
+		o.SetObject("data", data)
+		return o, nil
+	}
+}
+
+func withDefaultNamespace(namespace string) transform {
+	return func(o object) (object, error) {
+		if o.String("metadata.namespace") != "" || clusterScopedKinds[o.String("kind")] {
+			return o, nil
+		}
+		o.SetString("metadata.namespace", namespace)
+		return o, nil
+	}
+}
+
+// networkTransforms returns the transforms injecting the network settings into
+// the addon objects.
+func (a *Addon) networkTransforms(network *Network) []transform {
+	if !a.IsCNI() {
+		return nil
+	}
+	var transforms []transform
+	if a.CNI.Namespace != "" {
+		transforms = append(transforms, withDefaultNamespace(a.CNI.Namespace))
+	}
+	for _, setting := range a.networkSettings(network) {
+		injection := setting.injection
+		switch {
+		case injection.Env != "":
+			transforms = append(transforms, withEnv(injection.Container, injection.Env, setting.value))
+		case injection.ConfigMap != "":
+			transforms = append(transforms, withConfigMapKey(injection.ConfigMap, injection.Key, setting.value))
+		}
+	}
+	return transforms
+}
+
+// ConfigureNetwork injects the network settings into a manifest of the addon,
+// eg. a copy of the addon manifest kept in a git repository.
+func (a *Addon) ConfigureNetwork(manifest []byte, network Network) ([]byte, error) {
+	m, err := parseManifest("", manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.transform(a.networkTransforms(&network)...); err != nil {
+		return nil, err
+	}
+	return m.marshal(true)
+}
+
+// ManifestYAML concatenates the built manifests of an addon into a single
+// multi-document YAML manifest.
+func ManifestYAML(manifests []string) ([]byte, error) {
+	all := &manifestFile{}
+	for _, filename := range manifests {
+		m, err := readManifestFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		all.docs = append(all.docs, m.docs...)
+	}
+	return all.marshal(true)
+}
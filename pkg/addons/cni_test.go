@@ -0,0 +1,94 @@
+package addons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cniManifest = `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: weave-net
+spec:
+  template:
+    spec:
+      containers:
+      - name: weave
+        image: weaveworks/weave-kube:2.7.0
+        env:
+        - name: IPALLOC_RANGE
+          valueFrom:
+            configMapKeyRef:
+              name: weave-net
+              key: range
+      - name: weave-npc
+        image: weaveworks/weave-npc:2.7.0
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: weave-net
+`
+
+func TestConfigureNetwork(t *testing.T) {
+	addon, err := GetCNI("weave-net")
+	assert.NoError(t, err)
+
+	data, err := addon.ConfigureNetwork([]byte(cniManifest), Network{PodCIDR: "10.32.0.0/12", MTU: 1376})
+	assert.NoError(t, err)
+	m, err := parseManifest("", data)
+	assert.NoError(t, err)
+	var objects []object
+	m.forEachObject(func(o object) {
+		objects = append(objects, o)
+	})
+
+	ds := findObject(objects, "DaemonSet", "weave-net")
+	assert.Equal(t, "kube-system", ds.String("metadata.namespace"))
+	containers := ds.ObjectArray("spec.template.spec.containers")
+	assert.Equal(t, []object{
+		{"name": "IPALLOC_RANGE", "value": "10.32.0.0/12"},
+		{"name": "WEAVE_MTU", "value": "1376"},
+	}, containers[0].ObjectArray("env"))
+	assert.Nil(t, containers[1].ObjectArray("env"))
+
+	role := findObject(objects, "ClusterRole", "weave-net")
+	assert.Equal(t, "", role.String("metadata.namespace"))
+}
+
+func TestConfigureNetworkConfigMap(t *testing.T) {
+	addon, err := GetCNI("calico")
+	assert.NoError(t, err)
+
+	data, err := addon.ConfigureNetwork([]byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: calico-config
+data:
+  veth_mtu: "0"
+`), Network{MTU: 1440})
+	assert.NoError(t, err)
+	m, err := parseManifest("", data)
+	assert.NoError(t, err)
+	m.forEachObject(func(o object) {
+		assert.Equal(t, "1440", o.String("data.veth_mtu"))
+	})
+}
+
+func TestCNIAddons(t *testing.T) {
+	_, err := GetCNI("flux")
+	assert.EqualError(t, err, "addon: flux isn't a CNI addon")
+
+	assert.Equal(t, map[string]string{
+		"calico":    "kube-system",
+		"cilium":    "kube-system",
+		"weave-net": "kube-system",
+	}, CNINamespaces())
+
+	calico, err := GetCNI("calico")
+	assert.NoError(t, err)
+	assert.NoError(t, calico.ValidatePodCIDR(16))
+	assert.NoError(t, calico.ValidatePodCIDR(26))
+	assert.EqualError(t, calico.ValidatePodCIDR(27), "calico requires a pod network of /26 or larger")
+}
@@ -0,0 +1,59 @@
+package addons
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/weaveworks/wksctl/pkg/registry"
+)
+
+// DigestResolver resolves container images to the digest of their manifest.
+type DigestResolver interface {
+	Digest(ctx context.Context, image string) (string, error)
+}
+
+// pinDigests rewrites the container images of the built objects to include
+// their digest. Images already referenced by digest are left untouched.
+func pinDigests(config *BuildOptions, files []*manifestFile) error {
+	resolver := config.Resolver
+	if resolver == nil {
+		client, err := registry.NewClient()
+		if err != nil {
+			return err
+		}
+		resolver = client
+	}
+
+	ctx := context.Background()
+	digests := make(map[string]string)
+	var firstErr error
+	for _, m := range files {
+		m.forEachObject(func(o object) {
+			forEachContainer(o, func(container object) {
+				image, err := container.GetString("image")
+				if err != nil || firstErr != nil {
+					return
+				}
+				ref, err := registry.NewImage(image)
+				if err != nil {
+					firstErr = fmt.Errorf("failed to pin image %s: %v", image, err)
+					return
+				}
+				if ref.Digest != "" {
+					return
+				}
+				digest, ok := digests[image]
+				if !ok {
+					if digest, err = resolver.Digest(ctx, image); err != nil {
+						firstErr = fmt.Errorf("failed to pin image %s: %v", image, err)
+						return
+					}
+					digests[image] = digest
+				}
+				ref.Digest = digest
+				container.SetString("image", ref.String())
+			})
+		})
+	}
+	return firstErr
+}
@@ -0,0 +1,57 @@
+package addons
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/registrytest"
+)
+
+func TestPinDigests(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	r := registrytest.New()
+	defer r.Close()
+
+	addon, err := Get("weave-net")
+	assert.NoError(t, err)
+	options := BuildOptions{
+		OutputDirectory: dir,
+		ImageRepository: r.Host(),
+		PinDigests:      true,
+		Resolver:        &registry.Client{},
+	}
+
+	// Images aren't in the mirror.
+	_, err = addon.Build(options)
+	assert.Error(t, err)
+
+	images, err := addon.ListImages()
+	assert.NoError(t, err)
+	digests := make(map[string]bool)
+	for _, image := range images {
+		digests[r.PushImage(image.User+"/"+image.Name, image.Tag, image.String())] = true
+	}
+
+	manifests, err := addon.Build(options)
+	assert.NoError(t, err)
+	m, err := readManifestFile(manifests[0])
+	assert.NoError(t, err)
+	n := 0
+	m.forEachObject(func(o object) {
+		forEachContainer(o, func(container object) {
+			ref, err := registry.NewImage(container.String("image"))
+			assert.NoError(t, err)
+			assert.Equal(t, r.Host(), ref.Registry)
+			assert.NotEmpty(t, ref.Tag)
+			assert.True(t, digests[ref.Digest])
+			n++
+		})
+	})
+	assert.True(t, n > 0)
+}
@@ -0,0 +1,238 @@
+package addons
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema"
+)
+
+const (
+	// testsDirectory is the directory of the addon golden tests, next to
+	// addon.json.
+	testsDirectory = "tests"
+	goldenSuffix   = ".golden.yaml"
+	// testBuildID is the build ID of test builds, so the golden files don't
+	// change with every change of the objects.
+	testBuildID = "test"
+)
+
+// TestCase is a golden test of an addon, read from tests/<name>.yaml: the
+// addon is built with the test parameters and the built objects compared with
+// tests/<name>.golden.yaml.
+type TestCase struct {
+	Name string `json:"-"`
+	// Params are the addon parameters. Files are relative to the tests
+	// directory.
+	Params map[string]string `json:"params,omitempty"`
+	// ExtVars are the jsonnet external variables.
+	ExtVars map[string]string `json:"extVars,omitempty"`
+	// ImageRepository overrides the container images repository.
+	ImageRepository string `json:"imageRepository,omitempty"`
+	// PodCIDR and MTU are the network settings given to CNI addons.
+	PodCIDR string `json:"podCIDR,omitempty"`
+	MTU     int    `json:"mtu,omitempty"`
+}
+
+// TestResult is the outcome of an addon test.
+type TestResult struct {
+	Name string
+	// Diff is the unified diff between the golden file and the built objects.
+	Diff string
+	Err  error
+	// Updated is set when the golden file has been rewritten.
+	Updated bool
+}
+
+// Failed returns true if the test failed.
+func (r *TestResult) Failed() bool {
+	return r.Err != nil || r.Diff != ""
+}
+
+// TestOptions holds the options of RunTests.
+type TestOptions struct {
+	// Update rewrites the golden files with the built objects instead of
+	// comparing them. Only addons loaded from a directory can be updated.
+	Update bool
+	// Validator, if not nil, validates the built objects.
+	Validator *schema.Validator
+}
+
+func parseTestCase(data []byte) (TestCase, error) {
+	var c TestCase
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return c, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&c)
+	return c, err
+}
+
+// TestCases returns the golden tests of the addon, ordered by name.
+func (a *Addon) TestCases() ([]TestCase, error) {
+	dir, err := a.fs().Open(a.absEntryPoint(testsDirectory))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+	files, err := dir.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []TestCase
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || path.Ext(name) != ".yaml" || strings.HasSuffix(name, goldenSuffix) {
+			continue
+		}
+		data, err := a.readFile(path.Join(testsDirectory, name))
+		if err != nil {
+			return nil, err
+		}
+		c, err := parseTestCase([]byte(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid test case %s: %v", name, err)
+		}
+		c.Name = strings.TrimSuffix(name, ".yaml")
+		cases = append(cases, c)
+	}
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].Name < cases[j].Name
+	})
+	return cases, nil
+}
+
+// goldenOutput concatenates the built manifests, leaving the inventory out.
+func goldenOutput(manifests []string) (string, error) {
+	var b strings.Builder
+	for i, filename := range manifests[:len(manifests)-1] {
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		fmt.Fprintf(&b, "# %s\n", filepath.Base(filename))
+		b.Write(data)
+	}
+	return b.String(), nil
+}
+
+func (a *Addon) runTest(c *TestCase, opts *TestOptions) TestResult {
+	result := TestResult{Name: c.Name}
+
+	outputDir, err := ioutil.TempDir("", "wksctl-addon-test")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer os.RemoveAll(outputDir)
+
+	config := BuildOptions{
+		OutputDirectory: outputDir,
+		Params:          c.Params,
+		ExtVars:         c.ExtVars,
+		ImageRepository: c.ImageRepository,
+		Network:         Network{PodCIDR: c.PodCIDR, MTU: c.MTU},
+		YAML:            true,
+		BuildID:         testBuildID,
+		// Test files hold made up secrets.
+		AllowPlaintextSecrets: true,
+	}
+	if a.dir != "" {
+		config.BasePath = filepath.Join(a.dir, testsDirectory)
+	}
+	if err := a.ValidateOptions(&config); err != nil {
+		result.Err = err
+		return result
+	}
+	manifests, err := a.Build(config)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if opts.Validator != nil {
+		if err := Validate(opts.Validator, manifests); err != nil {
+			result.Err = err
+			return result
+		}
+	}
+	actual, err := goldenOutput(manifests)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	golden := path.Join(testsDirectory, c.Name+goldenSuffix)
+	if opts.Update {
+		if a.dir == "" {
+			result.Err = fmt.Errorf("can't update the golden files of embedded addon %s", a.ShortName)
+			return result
+		}
+		result.Err = ioutil.WriteFile(filepath.Join(a.dir, filepath.FromSlash(golden)), []byte(actual), 0644)
+		result.Updated = result.Err == nil
+		return result
+	}
+	expected, err := a.readFile(golden)
+	if os.IsNotExist(err) {
+		result.Err = fmt.Errorf("missing golden file %s, update the tests to create it", golden)
+		return result
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if expected != actual {
+		result.Diff, result.Err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(expected),
+			B:        difflib.SplitLines(actual),
+			FromFile: golden,
+			ToFile:   "built",
+			Context:  3,
+		})
+	}
+	return result
+}
+
+// RunTests runs the golden tests of the addon and checks its images can be
+// listed. The images check is reported as the "list-images" test.
+func (a *Addon) RunTests(opts TestOptions) ([]TestResult, error) {
+	cases, err := a.TestCases()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TestResult
+	for i := range cases {
+		results = append(results, a.runTest(&cases[i], &opts))
+	}
+
+	images := TestResult{Name: "list-images"}
+	list, err := a.ListImages()
+	switch {
+	case err != nil:
+		images.Err = err
+	case len(list) == 0:
+		images.Err = errors.New("no container images found")
+	}
+	results = append(results, images)
+
+	return results, nil
+}
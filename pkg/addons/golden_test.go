@@ -0,0 +1,88 @@
+package addons
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema"
+)
+
+func TestGoldenAllAddons(t *testing.T) {
+	v, err := schema.New("")
+	assert.NoError(t, err)
+
+	for _, addon := range List() {
+		t.Run(addon.ShortName, func(t *testing.T) {
+			results, err := addon.RunTests(TestOptions{Validator: v})
+			assert.NoError(t, err)
+			assert.True(t, len(results) > 1, "addon has no tests")
+			for _, r := range results {
+				assert.False(t, r.Failed(), "%s: %v\n%s", r.Name, r.Err, r.Diff)
+			}
+		})
+	}
+}
+
+const testAddonManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+data:
+  image: busybox:1.32
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: default
+spec:
+  containers:
+  - name: pod
+    image: busybox:1.32
+`
+
+func TestGoldenUpdate(t *testing.T) {
+	root, err := ioutil.TempDir("", "golden")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+	dir := filepath.Join(root, "test-addon")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "tests"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "addon.json"), []byte(
+		`{"kind": "yaml", "category": "test", "name": "Test", "entryPoint": "manifest.yaml"}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifest.yaml"), []byte(testAddonManifest), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "tests", "mirror.yaml"), []byte(
+		"imageRepository: registry.example.com\n"), 0644))
+
+	addon, err := Load(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-addon", addon.ShortName)
+
+	results, err := addon.RunTests(TestOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.EqualError(t, results[0].Err, "missing golden file tests/mirror.golden.yaml, update the tests to create it")
+	assert.False(t, results[1].Failed(), "%v", results[1].Err)
+
+	results, err = addon.RunTests(TestOptions{Update: true})
+	assert.NoError(t, err)
+	assert.True(t, results[0].Updated)
+	golden, err := ioutil.ReadFile(filepath.Join(dir, "tests", "mirror.golden.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(golden), "# manifest.yaml\n")
+	assert.Contains(t, string(golden), "image: registry.example.com/busybox:1.32")
+
+	results, err = addon.RunTests(TestOptions{})
+	assert.NoError(t, err)
+	assert.False(t, results[0].Failed())
+
+	// Changing the addon makes the test fail with a diff.
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifest.yaml"), []byte(testAddonManifest[:len(testAddonManifest)-len("busybox:1.32\n")]+"busybox:1.33\n"), 0644))
+	results, err = addon.RunTests(TestOptions{})
+	assert.NoError(t, err)
+	assert.True(t, results[0].Failed())
+	assert.Contains(t, results[0].Diff, "-  - image: registry.example.com/busybox:1.32\n+  - image: registry.example.com/busybox:1.33\n")
+}
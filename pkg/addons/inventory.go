@@ -0,0 +1,212 @@
+package addons
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// BuildLabel is the label holding the ID of the build which produced a
+	// Kubernetes object.
+	BuildLabel = "wksctl.weave.works/addon-build"
+	// InventoryLabel identifies addon inventory ConfigMaps. Its value is the
+	// addon name.
+	InventoryLabel = "wksctl.weave.works/addon-inventory"
+	// InventoryNamespace is the namespace of the addon inventory ConfigMaps.
+	InventoryNamespace = "kube-system"
+	// OwnerAnnotation is set on the namespaces created by an addon, to the
+	// addon name. Namespaces are often shared with other addons or workloads,
+	// addons only delete the namespaces they own.
+	OwnerAnnotation = "wksctl.weave.works/addon-owner"
+)
+
+// ObjectRef identifies a Kubernetes object.
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+func (r *ObjectRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// Object returns an object with r's type, namespace and name, suitable to get
+// or delete the object from the API server.
+func (r *ObjectRef) Object() *unstructured.Unstructured {
+	o := &unstructured.Unstructured{}
+	o.SetAPIVersion(r.APIVersion)
+	o.SetKind(r.Kind)
+	o.SetNamespace(r.Namespace)
+	o.SetName(r.Name)
+	return o
+}
+
+// Inventory records the objects built for an addon, in the order they are
+// applied.
+type Inventory struct {
+	Addon   string
+	BuildID string
+	// Deps are the addons this addon depends on.
+	Deps    []string
+	Objects []ObjectRef
+}
+
+// InventoryName returns the name of the inventory ConfigMap of an addon.
+func InventoryName(addon string) string {
+	return "wksctl-addon-" + addon
+}
+
+// Contains returns whether ref is part of the inventory.
+func (inv *Inventory) Contains(ref ObjectRef) bool {
+	for _, o := range inv.Objects {
+		if o == ref {
+			return true
+		}
+	}
+	return false
+}
+
+func (inv *Inventory) configMap() (object, error) {
+	deps, err := json.Marshal(inv.Deps)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := json.Marshal(inv.Objects)
+	if err != nil {
+		return nil, err
+	}
+	return object{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      InventoryName(inv.Addon),
+			"namespace": InventoryNamespace,
+			"labels": map[string]interface{}{
+				InventoryLabel: inv.Addon,
+				BuildLabel:     inv.BuildID,
+			},
+		},
+		"data": map[string]interface{}{
+			"buildID": inv.BuildID,
+			"deps":    string(deps),
+			"objects": string(objects),
+		},
+	}, nil
+}
+
+// InventoryFromConfigMap decodes an inventory ConfigMap.
+func InventoryFromConfigMap(cm *unstructured.Unstructured) (*Inventory, error) {
+	addon := cm.GetLabels()[InventoryLabel]
+	if addon == "" {
+		return nil, fmt.Errorf("%s/%s is not an addon inventory", cm.GetNamespace(), cm.GetName())
+	}
+	data, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+	inv := &Inventory{
+		Addon:   addon,
+		BuildID: data["buildID"],
+	}
+	if data["deps"] != "" {
+		if err := json.Unmarshal([]byte(data["deps"]), &inv.Deps); err != nil {
+			return nil, fmt.Errorf("invalid inventory for addon %s: %v", addon, err)
+		}
+	}
+	if data["objects"] != "" {
+		if err := json.Unmarshal([]byte(data["objects"]), &inv.Objects); err != nil {
+			return nil, fmt.Errorf("invalid inventory for addon %s: %v", addon, err)
+		}
+	}
+	return inv, nil
+}
+
+// buildID computes an ID from the content of the built manifests, independently
+// of their format.
+func buildID(files []*manifestFile) (string, error) {
+	h := sha256.New()
+	for _, m := range files {
+		for _, doc := range m.docs {
+			data, err := json.Marshal(doc)
+			if err != nil {
+				return "", err
+			}
+			h.Write(data)
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)[:8]), nil
+}
+
+func setLabel(o object, key, value string) {
+	metadata, ok := o["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = make(map[string]interface{})
+		o["metadata"] = metadata
+	}
+	labels, ok := metadata["labels"].(map[string]interface{})
+	if !ok {
+		labels = make(map[string]interface{})
+		metadata["labels"] = labels
+	}
+	labels[key] = value
+}
+
+func objectRef(o object) ObjectRef {
+	return ObjectRef{
+		APIVersion: o.String("apiVersion"),
+		Kind:       o.String("kind"),
+		Namespace:  o.String("metadata.namespace"),
+		Name:       o.String("metadata.name"),
+	}
+}
+
+// label labels the built objects with the addon name and build ID and returns
+// the addon inventory.
+func (a *Addon) label(config *BuildOptions, files []*manifestFile) (*Inventory, error) {
+	id := config.BuildID
+	if id == "" {
+		var err error
+		if id, err = buildID(files); err != nil {
+			return nil, err
+		}
+	}
+
+	inv := &Inventory{
+		Addon:   a.ShortName,
+		BuildID: id,
+		Deps:    config.Deps,
+	}
+	for _, m := range files {
+		m.forEachObject(func(o object) {
+			setLabel(o, NameLabel, a.ShortName)
+			setLabel(o, BuildLabel, id)
+			inv.Objects = append(inv.Objects, objectRef(o))
+		})
+	}
+	return inv, nil
+}
+
+// ReadInventory returns the inventory written by Build among manifests.
+func ReadInventory(manifests []string) (*Inventory, error) {
+	for _, filename := range manifests {
+		m, err := readManifestFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range m.docs {
+			if doc.String("kind") != "ConfigMap" || doc.String("metadata.namespace") != InventoryNamespace {
+				continue
+			}
+			cm := &unstructured.Unstructured{Object: doc}
+			if cm.GetLabels()[InventoryLabel] != "" {
+				return InventoryFromConfigMap(cm)
+			}
+		}
+	}
+	return nil, fmt.Errorf("no addon inventory found")
+}
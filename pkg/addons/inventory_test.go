@@ -0,0 +1,65 @@
+package addons
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildInventory(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	addon, err := Get("weave-net")
+	assert.NoError(t, err)
+
+	for _, asYAML := range []bool{false, true} {
+		manifests, err := addon.Build(BuildOptions{
+			OutputDirectory: dir,
+			YAML:            asYAML,
+			Deps:            []string{"cni"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(manifests))
+
+		inv, err := ReadInventory(manifests)
+		assert.NoError(t, err)
+		assert.Equal(t, "weave-net", inv.Addon)
+		assert.Equal(t, []string{"cni"}, inv.Deps)
+		assert.Contains(t, inv.Objects, ObjectRef{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "kube-system", Name: "weave-net"})
+
+		m, err := readManifestFile(manifests[0])
+		assert.NoError(t, err)
+		n := 0
+		m.forEachObject(func(o object) {
+			labels := o.Object("metadata").Object("labels")
+			assert.Equal(t, "weave-net", labels[NameLabel])
+			assert.Equal(t, inv.BuildID, labels[BuildLabel])
+			n++
+		})
+		assert.Equal(t, len(inv.Objects), n)
+	}
+}
+
+func TestRemovalOrder(t *testing.T) {
+	order, err := RemovalOrder([]Inventory{
+		{Addon: "cni"},
+		{Addon: "flux", Deps: []string{"memcached", "cni"}},
+		{Addon: "memcached", Deps: []string{"cni"}},
+	})
+	assert.NoError(t, err)
+	var names []string
+	for _, inv := range order {
+		names = append(names, inv.Addon)
+	}
+	assert.Equal(t, []string{"flux", "memcached", "cni"}, names)
+
+	_, err = RemovalOrder([]Inventory{
+		{Addon: "a", Deps: []string{"b"}},
+		{Addon: "b", Deps: []string{"a"}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package addons
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Jsonnet external variables describing the cluster addons are deployed to,
+// accessible with std.extVar().
+const (
+	ExtVarClusterName       = "clusterName"
+	ExtVarKubernetesVersion = "kubernetesVersion"
+	// ExtVarPodCIDRBlocks is a comma separated list of CIDRs.
+	ExtVarPodCIDRBlocks = "podCIDRBlocks"
+	// ExtVarServiceCIDRBlocks is a comma separated list of CIDRs.
+	ExtVarServiceCIDRBlocks = "serviceCIDRBlocks"
+	// ExtVarNamespace is the namespace of the wksctl controller.
+	ExtVarNamespace = "namespace"
+)
+
+var extVars = []string{
+	ExtVarClusterName,
+	ExtVarKubernetesVersion,
+	ExtVarPodCIDRBlocks,
+	ExtVarServiceCIDRBlocks,
+	ExtVarNamespace,
+}
+
+// nativeFunctions are made available to jsonnet scripts through std.native(),
+// eg. std.native("sha256")("foo").
+var nativeFunctions = []*jsonnet.NativeFunction{
+	{
+		Name:   "base64",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			s, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("base64: expected a string, got %T", args[0])
+			}
+			return base64.StdEncoding.EncodeToString([]byte(s)), nil
+		},
+	},
+	{
+		Name:   "base64Decode",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			s, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("base64Decode: expected a string, got %T", args[0])
+			}
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, err
+			}
+			return string(data), nil
+		},
+	},
+	{
+		Name:   "sha256",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			s, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("sha256: expected a string, got %T", args[0])
+			}
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:]), nil
+		},
+	},
+	{
+		Name:   "parseYaml",
+		Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			s, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("parseYaml: expected a string, got %T", args[0])
+			}
+			return parseYAML([]byte(s))
+		},
+	},
+}
+
+// parseYAML parses YAML into JSON values. A multi-document input gives an
+// array of its documents.
+func parseYAML(data []byte) (interface{}, error) {
+	reader := kyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	var docs []interface{}
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		j, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(j, []byte("null")) {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal(j, &v); err != nil {
+			return nil, err
+		}
+		docs = append(docs, v)
+	}
+	if len(docs) == 1 {
+		return docs[0], nil
+	}
+	return docs, nil
+}
+
+// makeVM creates a jsonnet VM importing files from the addons in fs and the
+// library paths of config. The files of fs are cached in cache.
+func makeVM(config *BuildOptions, fs http.FileSystem, cache *importCache) *jsonnet.VM {
+	vm := jsonnet.MakeVM()
+
+	importer := newVFSImporter(fs, cache)
+	importer.searchPaths = []string{"/", "/vendor"}
+	for _, dir := range config.LibraryPaths {
+		importer.libraryPaths = append(importer.libraryPaths, resolvePath(config.BasePath, dir))
+	}
+	vm.Importer(importer)
+
+	for _, name := range extVars {
+		vm.ExtVar(name, config.ExtVars[name])
+	}
+	for name, value := range config.ExtVars {
+		vm.ExtVar(name, value)
+	}
+	for _, f := range nativeFunctions {
+		vm.NativeFunction(f)
+	}
+
+	return vm
+}
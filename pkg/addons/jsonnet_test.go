@@ -0,0 +1,61 @@
+package addons
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/addons/assets"
+)
+
+func evaluate(t *testing.T, config *BuildOptions, script string) string {
+	vm := makeVM(config, assets.Assets, newImportCache())
+	output, err := vm.EvaluateSnippet("test.jsonnet", script)
+	assert.NoError(t, err)
+	return output
+}
+
+func TestNativeFunctions(t *testing.T) {
+	tests := []struct {
+		script   string
+		expected string
+	}{
+		{`std.native("base64")("foo")`, `"Zm9v"`},
+		{`std.native("base64Decode")("Zm9v")`, `"foo"`},
+		{`std.native("sha256")("foo")`, `"2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"`},
+		{`std.native("parseYaml")("a: 1")`, `{"a":1}`},
+		{`std.native("parseYaml")("a: 1\n---\nb: [x]\n")`, `[{"a":1},{"b":["x"]}]`},
+	}
+	for _, test := range tests {
+		assert.JSONEq(t, test.expected, evaluate(t, &BuildOptions{}, test.script), test.script)
+	}
+}
+
+func TestExtVars(t *testing.T) {
+	// Known external variables are always defined.
+	assert.Equal(t, "\"\"\n", evaluate(t, &BuildOptions{}, `std.extVar("clusterName")`))
+	assert.Equal(t, "\"10.96.0.0/12\"\n", evaluate(t, &BuildOptions{
+		ExtVars: map[string]string{ExtVarServiceCIDRBlocks: "10.96.0.0/12"},
+	}, `std.extVar("serviceCIDRBlocks")`))
+}
+
+func TestLibraryPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, lib := range []string{"lib1", "lib2"} {
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, lib), 0700))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, lib, "org.libsonnet"), []byte(
+			`{ name: "`+lib+`", helper: import "helper.libsonnet" }`), 0600))
+	}
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "lib1", "helper.libsonnet"), []byte(`"helper"`), 0600))
+
+	config := &BuildOptions{BasePath: dir, LibraryPaths: []string{"lib2", "lib1"}}
+	// The right-most library path wins, files import relatively to themselves.
+	assert.JSONEq(t, `{"name": "lib1", "helper": "helper"}`, evaluate(t, config, `import "org.libsonnet"`))
+	// Embedded libraries are still available.
+	evaluate(t, config, `import "mixin.libsonnet"`)
+}
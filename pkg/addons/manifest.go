@@ -0,0 +1,233 @@
+package addons
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/weaveworks/libgitops/pkg/serializer"
+)
+
+// manifestFile is a manifest written by an addon build. It's made of one or
+// more documents, some of which can be List objects.
+type manifestFile struct {
+	filename string
+	docs     []object
+}
+
+func readManifestFile(filename string) (*manifestFile, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseManifest(filename, data)
+}
+
+func parseManifest(filename string, data []byte) (*manifestFile, error) {
+	// Manifests written as JSON are a stream of JSON documents.
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseJSONManifest(filename, data)
+	}
+
+	frames, err := serializer.ReadFrameList(serializer.NewYAMLFrameReader(serializer.FromBytes(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &manifestFile{filename: filename}
+	for _, frame := range frames {
+		doc, err := newObjectFromYAML(frame)
+		if err != nil {
+			return nil, err
+		}
+		if doc.IsEmpty() {
+			continue
+		}
+		m.docs = append(m.docs, doc)
+	}
+	return m, nil
+}
+
+func parseJSONManifest(filename string, data []byte) (*manifestFile, error) {
+	m := &manifestFile{filename: filename}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := newObject()
+		if err := decoder.Decode(&doc); err == io.EOF {
+			return m, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if !doc.IsEmpty() {
+			m.docs = append(m.docs, doc)
+		}
+	}
+}
+
+func isList(o object) bool {
+	_, err := o.GetObjectArray("items")
+	return err == nil && strings.HasSuffix(o.String("kind"), "List")
+}
+
+// forEachObject calls cb on every Kubernetes object of the manifest,
+// descending into Lists.
+func (m *manifestFile) forEachObject(cb func(o object)) {
+	for _, doc := range m.docs {
+		forEachObject(doc, cb)
+	}
+}
+
+func forEachObject(o object, cb func(o object)) {
+	if !isList(o) {
+		cb(o)
+		return
+	}
+	for _, item := range o.ObjectArray("items") {
+		forEachObject(item, cb)
+	}
+}
+
+// transform applies transforms to every Kubernetes object of the manifest.
+func (m *manifestFile) transform(transforms ...transform) error {
+	for i, doc := range m.docs {
+		transformed, err := applyTransforms(doc, transforms)
+		if err != nil {
+			return err
+		}
+		m.docs[i] = transformed
+	}
+	return nil
+}
+
+func (m *manifestFile) write(asYAML bool) error {
+	data, err := m.marshal(asYAML)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(m.filename, data, 0660)
+}
+
+// marshal returns the documents of the manifest, as multi-document YAML or as a
+// stream of JSON documents.
+func (m *manifestFile) marshal(asYAML bool) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, doc := range m.docs {
+		var data []byte
+		var err error
+		if asYAML {
+			if i > 0 {
+				buf.WriteString("---\n")
+			}
+			data, err = doc.toYAML()
+		} else {
+			data, err = doc.toJSON()
+			data = append(data, '\n')
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// postBuild rewrites the built manifests: it applies the image repository, the
+// network settings of CNI addons, the user provided transforms and the image
+// pull secret, seals secret parameters, pins images if asked to, labels the
+// objects, then writes the manifests and the addon inventory next to them.
+// Nothing is written before secret parameters are sealed.
+// It returns the full list of manifests, the inventory being last.
+func (a *Addon) postBuild(config *BuildOptions, files []*manifestFile) ([]string, error) {
+	var manifests []string
+	for _, m := range files {
+		manifests = append(manifests, m.filename)
+	}
+
+	// Rewrite the images of every object, whether or not the addon exposes an
+	// imageRepository parameter.
+	if config.ImageRepository != "" {
+		if _, err := UpdateImage("image", config.ImageRepository); err != nil {
+			return nil, err
+		}
+		for _, m := range files {
+			if err := m.transform(withImageRepository(config.ImageRepository, config.ImageRewritten)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if transforms := a.networkTransforms(&config.Network); len(transforms) > 0 {
+		for _, m := range files {
+			if err := m.transform(transforms...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var transforms []transform
+	for i := range config.Transforms {
+		t, err := config.Transforms[i].transforms(config)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, t...)
+	}
+	if len(transforms) > 0 {
+		for _, m := range files {
+			if err := m.transform(transforms...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(config.ImagePullSecret) > 0 {
+		m, err := a.addImagePullSecret(config, files)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			files = append(files, m)
+			manifests = append(manifests, m.filename)
+		}
+	}
+
+	material, err := a.secretMaterial(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(material) > 0 {
+		if err := protectSecrets(config, files, material); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.PinDigests {
+		if err := pinDigests(config, files); err != nil {
+			return nil, err
+		}
+	}
+
+	inv, err := a.label(config, files)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range files {
+		if err := m.write(config.YAML); err != nil {
+			return nil, err
+		}
+	}
+
+	cm, err := inv.configMap()
+	if err != nil {
+		return nil, err
+	}
+	filename := filepath.Join(config.OutputDirectory, a.ShortName+"-inventory"+extension(config))
+	m := &manifestFile{filename: filename, docs: []object{cm}}
+	if err := m.write(config.YAML); err != nil {
+		return nil, err
+	}
+	return append(manifests, filename), nil
+}
@@ -0,0 +1,212 @@
+package addons
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const multiDocManifest = `apiVersion: v1
+kind: List
+items:
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: app
+    namespace: apps
+  spec:
+    template:
+      spec:
+        containers:
+        - image: quay.io/org/app:v1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  initContainers:
+  - image: busybox
+  containers:
+  - image: registry.example.com:5000/org/pod:v2
+`
+
+func TestImageRepositoryTransform(t *testing.T) {
+	m, err := parseManifest("", []byte(multiDocManifest))
+	assert.NoError(t, err)
+
+	var rewrites []ImageRewrite
+	err = m.transform(withImageRepository("mirror:5000", func(r ImageRewrite) {
+		rewrites = append(rewrites, r)
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []ImageRewrite{
+		{Object: "Deployment/apps/app", From: "quay.io/org/app:v1", To: "mirror:5000/org/app:v1"},
+		{Object: "Pod/pod", From: "busybox", To: "mirror:5000/busybox"},
+		{Object: "Pod/pod", From: "registry.example.com:5000/org/pod:v2", To: "mirror:5000/org/pod:v2"},
+	}, rewrites)
+}
+
+func TestTransforms(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "rename.jsonnet"), []byte(
+		"function(object) object + { metadata+: { name: 'renamed-' + object.metadata.name } }"), 0600)
+	assert.NoError(t, err)
+
+	m, err := parseManifest("", []byte(multiDocManifest))
+	assert.NoError(t, err)
+
+	config := &BuildOptions{BasePath: dir}
+	tr := Transform{
+		Labels:       map[string]string{"team": "infra"},
+		NodeSelector: map[string]string{"node-role": "addons"},
+		Tolerations: []corev1.Toleration{
+			{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "addons", Effect: corev1.TaintEffectNoSchedule},
+		},
+		DefaultResources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		},
+		PriorityClassName: "system-cluster-critical",
+		Jsonnet:           "rename.jsonnet",
+	}
+	transforms, err := tr.transforms(config)
+	assert.NoError(t, err)
+	// Applying transforms twice doesn't duplicate tolerations.
+	transforms = append(transforms, transforms...)
+	assert.NoError(t, m.transform(transforms...))
+
+	deployment := m.docs[0].ObjectArray("items")[0]
+	assert.Equal(t, "renamed-renamed-app", deployment.String("metadata.name"))
+	assert.Equal(t, "infra", deployment.String("metadata.labels.team"))
+	assert.Equal(t, "infra", deployment.String("spec.template.metadata.labels.team"))
+
+	pod := m.docs[1]
+	assert.Equal(t, "infra", pod.String("metadata.labels.team"))
+	for _, spec := range []object{deployment.Object("spec.template.spec"), pod.Object("spec")} {
+		assert.Equal(t, "addons", spec.String("nodeSelector.node-role"))
+		assert.Equal(t, "system-cluster-critical", spec.String("priorityClassName"))
+		assert.Len(t, spec.ObjectArray("tolerations"), 1)
+		for _, c := range spec.ObjectArray("containers") {
+			assert.Equal(t, "100m", c.String("resources.requests.cpu"))
+		}
+	}
+	// Pods aren't templates.
+	_, err = pod.Get("spec.template")
+	assert.Error(t, err)
+
+	_, err = (&Transform{Jsonnet: "missing.jsonnet"}).transforms(config)
+	assert.Error(t, err)
+}
+
+func TestBuildRewritesAllImages(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// flux-helm-op doesn't expose an imageRepository parameter.
+	for _, name := range []string{"flux", "flux-helm-op", "weave-net"} {
+		addon, err := Get(name)
+		assert.NoError(t, err)
+
+		var rewrites []ImageRewrite
+		manifests, err := addon.autoBuild(BuildOptions{
+			OutputDirectory: dir,
+			ImageRepository: "mirror:5000/wks",
+			ImageRewritten: func(r ImageRewrite) {
+				rewrites = append(rewrites, r)
+			},
+		})
+		assert.NoError(t, err)
+
+		for _, manifest := range manifests {
+			data, err := ioutil.ReadFile(manifest)
+			assert.NoError(t, err)
+			images, err := ListImagesFromManifest(data)
+			assert.NoError(t, err)
+			for _, image := range images {
+				assert.Equal(t, "mirror:5000", image.Registry, name)
+				assert.Equal(t, "wks", image.User, name)
+			}
+		}
+		// The flux addon uses its imageRepository parameter.
+		if name != "flux" {
+			assert.NotEmpty(t, rewrites, name)
+		}
+	}
+}
+
+const pullSecret = `apiVersion: bitnami.com/v1alpha1
+kind: SealedSecret
+metadata:
+  name: registry-credentials
+  namespace: kube-system
+  annotations:
+    sealedsecrets.bitnami.com/cluster-wide: "true"
+spec:
+  encryptedData:
+    .dockerconfigjson: AgBy3i4OJSWK+PiTySYZZA==
+  template:
+    type: kubernetes.io/dockerconfigjson
+    metadata:
+      name: registry-credentials
+      annotations:
+        sealedsecrets.bitnami.com/cluster-wide: "true"
+`
+
+func TestBuildImagePullSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	addon, err := Get("flux")
+	assert.NoError(t, err)
+	manifests, err := addon.autoBuild(BuildOptions{
+		OutputDirectory: dir,
+		YAML:            true,
+		ImagePullSecret: []byte(pullSecret),
+	})
+	assert.NoError(t, err)
+
+	namespaces := make(map[string]bool)
+	var secrets []object
+	for _, filename := range manifests {
+		m, err := readManifestFile(filename)
+		assert.NoError(t, err)
+		m.forEachObject(func(o object) {
+			if o.String("kind") == "SealedSecret" {
+				secrets = append(secrets, o)
+				return
+			}
+			ref := objectRef(o)
+			forEachPodSpec(o, func(spec object) {
+				namespaces[o.String("metadata.namespace")] = true
+				secrets := spec.ObjectArray("imagePullSecrets")
+				if assert.Len(t, secrets, 1, ref.String()) {
+					assert.Equal(t, "registry-credentials", secrets[0].String("name"))
+				}
+			})
+		})
+	}
+	assert.NotEmpty(t, namespaces)
+	assert.Len(t, secrets, len(namespaces))
+	for _, secret := range secrets {
+		assert.True(t, namespaces[secret.String("metadata.namespace")])
+		// The copies are part of the addon.
+		assert.Equal(t, "flux", secret.Object("metadata.labels")[NameLabel])
+	}
+
+	// The secret must be sealed cluster-wide.
+	_, err = addon.autoBuild(BuildOptions{
+		OutputDirectory: dir,
+		ImagePullSecret: []byte(strings.Replace(pullSecret, `"true"`, `"false"`, -1)),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package addons
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/weaveworks/wksctl/pkg/registry"
+)
+
+// parallelize calls f with every index of [0, n), running at most workers
+// calls at a time. workers defaults to the number of CPUs.
+func parallelize(n, workers int, f func(i int)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
+
+// BuildRequest is an addon build run by BuildAll.
+type BuildRequest struct {
+	Addon   *Addon
+	Options BuildOptions
+}
+
+// BuildResult is the outcome of a BuildRequest.
+type BuildResult struct {
+	// Manifests are the files written by the build, see Build.
+	Manifests []string
+	Err       error
+}
+
+// BuildAll runs the addon builds concurrently, at most workers at a time, and
+// returns their results in the order of the requests. workers defaults to the
+// number of CPUs. The ImageRewritten callbacks of the requests may be called
+// concurrently.
+func BuildAll(requests []BuildRequest, workers int) []BuildResult {
+	results := make([]BuildResult, len(requests))
+	parallelize(len(requests), workers, func(i int) {
+		r := &requests[i]
+		results[i].Manifests, results[i].Err = r.Addon.Build(r.Options)
+	})
+	return results
+}
+
+// ImagesResult is the outcome of listing the images of an addon.
+type ImagesResult struct {
+	Images []registry.Image
+	Err    error
+}
+
+// ListAllImages lists the container images of addons concurrently, at most
+// workers at a time, and returns them in the order of addons. workers defaults
+// to the number of CPUs.
+func ListAllImages(addons []Addon, workers int) []ImagesResult {
+	results := make([]ImagesResult, len(addons))
+	parallelize(len(addons), workers, func(i int) {
+		results[i].Images, results[i].Err = addons[i].ListImages()
+	})
+	return results
+}
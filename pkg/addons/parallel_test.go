@@ -0,0 +1,65 @@
+package addons
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build-all")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	params := map[string]map[string]string{
+		"flux": {"gitURL": "git@github.com:weaveworks/wksctl"},
+	}
+	var requests []BuildRequest
+	var expected []string
+	for _, addon := range List() {
+		addon := addon
+		options := BuildOptions{Params: params[addon.ShortName], YAML: true}
+		options.OutputDirectory = filepath.Join(dir, "serial-"+addon.ShortName)
+		assert.NoError(t, os.Mkdir(options.OutputDirectory, 0700))
+		manifests, err := addon.Build(options)
+		assert.NoError(t, err)
+		data, err := ioutil.ReadFile(manifests[0])
+		assert.NoError(t, err)
+
+		// Build every addon a few times.
+		for i := 0; i < 3; i++ {
+			options.OutputDirectory = filepath.Join(dir, addon.ShortName+"-"+strconv.Itoa(i))
+			assert.NoError(t, os.Mkdir(options.OutputDirectory, 0700))
+			requests = append(requests, BuildRequest{Addon: &addon, Options: options})
+			expected = append(expected, string(data))
+		}
+	}
+
+	results := BuildAll(requests, 4)
+	assert.Len(t, results, len(requests))
+	for i, result := range results {
+		assert.NoError(t, result.Err)
+		data, err := ioutil.ReadFile(result.Manifests[0])
+		assert.NoError(t, err)
+		assert.Equal(t, expected[i], string(data), requests[i].Addon.ShortName)
+	}
+}
+
+func TestParallelize(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		done := make([]int, 10)
+		parallelize(len(done), workers, func(i int) {
+			done[i]++
+		})
+		for i := range done {
+			assert.Equal(t, 1, done[i])
+		}
+	}
+	parallelize(0, 0, func(i int) {
+		t.Fail()
+	})
+}
@@ -0,0 +1,198 @@
+package addons
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+// GetInventory retrieves the inventory of an addon from the cluster. It
+// returns nil if the addon isn't installed.
+func GetInventory(ctx context.Context, c *client.Client, addon string) (*Inventory, error) {
+	ref := ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: InventoryNamespace, Name: InventoryName(addon)}
+	cm, err := c.Get(ctx, ref.Object())
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return InventoryFromConfigMap(cm)
+}
+
+// ListInventories retrieves the inventories of all the addons installed in the
+// cluster.
+func ListInventories(ctx context.Context, c *client.Client) ([]Inventory, error) {
+	cms, err := c.ListKind(ctx, configMapGVK, InventoryNamespace, InventoryLabel)
+	if err != nil {
+		return nil, err
+	}
+	var inventories []Inventory
+	for i := range cms {
+		inv, err := InventoryFromConfigMap(&cms[i])
+		if err != nil {
+			return nil, err
+		}
+		inventories = append(inventories, *inv)
+	}
+	sort.Slice(inventories, func(i, j int) bool {
+		return inventories[i].Addon < inventories[j].Addon
+	})
+	return inventories, nil
+}
+
+// NewNamespaces returns the namespaces of the inventory which don't exist in
+// the cluster yet. They are owned by the addon once applied, see
+// ClaimNamespaces.
+func NewNamespaces(ctx context.Context, c *client.Client, inv *Inventory) ([]ObjectRef, error) {
+	var namespaces []ObjectRef
+	for _, ref := range inv.Objects {
+		if ref.Kind != "Namespace" {
+			continue
+		}
+		if _, err := c.Get(ctx, ref.Object()); apierrors.IsNotFound(err) {
+			namespaces = append(namespaces, ref)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return namespaces, nil
+}
+
+// ClaimNamespaces marks namespaces as owned by addon.
+func ClaimNamespaces(ctx context.Context, c *client.Client, addon string, namespaces []ObjectRef) error {
+	for _, ref := range namespaces {
+		if err := c.Annotate(ctx, ref.Object(), OwnerAnnotation, addon); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// owns returns whether addon can delete the object referenced by ref: all
+// objects but the namespaces it didn't create.
+func owns(ctx context.Context, c *client.Client, addon string, ref ObjectRef) (bool, error) {
+	if ref.Kind != "Namespace" {
+		return true, nil
+	}
+	live, err := c.Get(ctx, ref.Object())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return live.GetAnnotations()[OwnerAnnotation] == addon, nil
+}
+
+// deleteObjects deletes the objects of addon in the reverse order they were
+// applied in. Namespaces the addon doesn't own are left alone.
+func deleteObjects(ctx context.Context, c *client.Client, addon string, objects []ObjectRef) error {
+	for i := len(objects) - 1; i >= 0; i-- {
+		owned, err := owns(ctx, c, addon, objects[i])
+		if err != nil {
+			return err
+		}
+		if !owned {
+			log.Infof("keeping %s, not created by addon %s", objects[i].String(), addon)
+			continue
+		}
+		log.Infof("deleting %s", objects[i].String())
+		if err := c.Delete(ctx, objects[i].Object()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune deletes the objects of the previous build of an addon which aren't
+// part of the current one.
+func Prune(ctx context.Context, c *client.Client, previous, current *Inventory) error {
+	var stale []ObjectRef
+	for _, o := range previous.Objects {
+		if !current.Contains(o) {
+			stale = append(stale, o)
+		}
+	}
+	return deleteObjects(ctx, c, previous.Addon, stale)
+}
+
+// Remove deletes all the objects of an addon, then its inventory.
+func Remove(ctx context.Context, c *client.Client, inv *Inventory) error {
+	if err := deleteObjects(ctx, c, inv.Addon, inv.Objects); err != nil {
+		return err
+	}
+	ref := ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: InventoryNamespace, Name: InventoryName(inv.Addon)}
+	return c.Delete(ctx, ref.Object())
+}
+
+// RemovalOrder sorts inventories in reverse dependency order: an addon comes
+// before the addons it depends on.
+func RemovalOrder(inventories []Inventory) ([]Inventory, error) {
+	byName := make(map[string]*Inventory)
+	for i := range inventories {
+		byName[inventories[i].Addon] = &inventories[i]
+	}
+
+	// Depth-first topological sort on the dependencies, which gives the
+	// installation order.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var order []Inventory
+	var visit func(name string) error
+	visit = func(name string) error {
+		inv, ok := byName[name]
+		if !ok {
+			return nil
+		}
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle involving addon %s", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range inv.Deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, *inv)
+		return nil
+	}
+	for i := range inventories {
+		if err := visit(inventories[i].Addon); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// Dependents returns the installed addons depending on addon.
+func Dependents(inventories []Inventory, addon string) []string {
+	var dependents []string
+	for _, inv := range inventories {
+		for _, dep := range inv.Deps {
+			if dep == addon {
+				dependents = append(dependents, inv.Addon)
+			}
+		}
+	}
+	return dependents
+}
@@ -0,0 +1,103 @@
+package addons
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+var namespaceGVK = schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+
+func newTestClient(objects ...runtime.Object) *client.Client {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(namespaceGVK, meta.RESTScopeRoot)
+	mapper.Add(configMapGVK, meta.RESTScopeNamespace)
+	return &client.Client{
+		Dynamic: fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...),
+		Mapper:  mapper,
+	}
+}
+
+func namespace(name string, annotations map[string]string) *unstructured.Unstructured {
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(namespaceGVK)
+	o.SetName(name)
+	o.SetAnnotations(annotations)
+	return o
+}
+
+func configMap(namespace, name string) *unstructured.Unstructured {
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(configMapGVK)
+	o.SetNamespace(namespace)
+	o.SetName(name)
+	return o
+}
+
+func exists(t *testing.T, c *client.Client, ref ObjectRef) bool {
+	_, err := c.Get(context.Background(), ref.Object())
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	assert.NoError(t, err)
+	return true
+}
+
+func TestRemoveKeepsSharedNamespaces(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(
+		namespace("flux", map[string]string{OwnerAnnotation: "flux"}),
+		namespace("kube-system", nil),
+		namespace("shared", map[string]string{OwnerAnnotation: "other"}),
+		configMap("flux", "flux-config"),
+	)
+	fluxNS := ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "flux"}
+	kubeSystem := ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "kube-system"}
+	shared := ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "shared"}
+	config := ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "flux", Name: "flux-config"}
+
+	err := Remove(ctx, c, &Inventory{
+		Addon:   "flux",
+		Objects: []ObjectRef{fluxNS, kubeSystem, shared, config},
+	})
+	assert.NoError(t, err)
+	assert.False(t, exists(t, c, fluxNS))
+	assert.False(t, exists(t, c, config))
+	assert.True(t, exists(t, c, kubeSystem))
+	assert.True(t, exists(t, c, shared))
+}
+
+func TestClaimNamespaces(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(namespace("kube-system", nil))
+	fluxNS := ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "flux"}
+	kubeSystem := ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "kube-system"}
+	inv := &Inventory{Addon: "flux", Objects: []ObjectRef{fluxNS, kubeSystem}}
+
+	namespaces, err := NewNamespaces(ctx, c, inv)
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectRef{fluxNS}, namespaces)
+
+	// Applying the addon creates its namespace.
+	_, err = c.Dynamic.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}).
+		Create(ctx, namespace("flux", nil), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, ClaimNamespaces(ctx, c, "flux", namespaces))
+
+	live, err := c.Get(ctx, fluxNS.Object())
+	assert.NoError(t, err)
+	assert.Equal(t, "flux", live.GetAnnotations()[OwnerAnnotation])
+
+	assert.NoError(t, Remove(ctx, c, inv))
+	assert.False(t, exists(t, c, fluxNS))
+	assert.True(t, exists(t, c, kubeSystem))
+}
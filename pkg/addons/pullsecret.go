@@ -0,0 +1,91 @@
+package addons
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	ssv1alpha1 "github.com/bitnami-labs/sealed-secrets/pkg/apis/sealed-secrets/v1alpha1"
+)
+
+// parsePullSecret parses the SealedSecret of the ImagePullSecret build option.
+// It must be sealed cluster-wide to be unsealed in the namespaces of the
+// addon.
+func parsePullSecret(data []byte) (object, error) {
+	ss, err := newObjectFromYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image pull secret: %v", err)
+	}
+	if ss.String("kind") != "SealedSecret" {
+		return nil, errors.New("invalid image pull secret: expected a SealedSecret")
+	}
+	if ss.String("metadata.name") == "" {
+		return nil, errors.New("invalid image pull secret: missing name")
+	}
+	annotations := ss.Object("spec.template.metadata.annotations")
+	if annotations[ssv1alpha1.SealedSecretClusterWideAnnotation] != "true" {
+		return nil, fmt.Errorf("image pull secret %s must be sealed with the cluster-wide scope", ss.String("metadata.name"))
+	}
+	return ss, nil
+}
+
+func withImagePullSecret(name string, namespaces map[string]bool) transform {
+	return func(o object) (object, error) {
+		namespace := o.String("metadata.namespace")
+		if namespace == "" {
+			namespace = "default"
+		}
+		forEachPodSpec(o, func(spec object) {
+			namespaces[namespace] = true
+			items, _ := spec["imagePullSecrets"].([]interface{})
+			for _, s := range spec.ObjectArray("imagePullSecrets") {
+				if s.String("name") == name {
+					return
+				}
+			}
+			spec["imagePullSecrets"] = append(items, map[string]interface{}{"name": name})
+		})
+		return o, nil
+	}
+}
+
+// addImagePullSecret makes the pods of the addon pull images with the
+// ImagePullSecret, adding a manifest with a copy of the SealedSecret in each of
+// their namespaces.
+func (a *Addon) addImagePullSecret(config *BuildOptions, files []*manifestFile) (*manifestFile, error) {
+	ss, err := parsePullSecret(config.ImagePullSecret)
+	if err != nil {
+		return nil, err
+	}
+	name := ss.String("metadata.name")
+	namespaces := make(map[string]bool)
+	for _, m := range files {
+		if err := m.transform(withImagePullSecret(name, namespaces)); err != nil {
+			return nil, err
+		}
+	}
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	var sorted []string
+	for namespace := range namespaces {
+		sorted = append(sorted, namespace)
+	}
+	sort.Strings(sorted)
+	filename := filepath.Join(config.OutputDirectory, a.ShortName+"-image-pull-secret"+extension(config))
+	m := &manifestFile{filename: filename}
+	for _, namespace := range sorted {
+		secret, err := newObjectFromYAML(config.ImagePullSecret)
+		if err != nil {
+			return nil, err
+		}
+		secret.SetString("metadata.namespace", namespace)
+		if _, err := secret.Get("spec.template.metadata.namespace"); err == nil {
+			secret.SetString("spec.template.metadata.namespace", namespace)
+		}
+		m.docs = append(m.docs, secret)
+	}
+	return m, nil
+}
@@ -0,0 +1,54 @@
+package addons
+
+import "fmt"
+
+// JSONSchemaDraft is the JSON Schema version of the generated schemas.
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema is the subset of JSON Schema needed to describe addon parameters.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type"`
+	Default              string                 `json:"default,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+}
+
+// ParamsSchema returns a JSON Schema validating the params of the addon, as
+// given in the addons section of the cluster manifest.
+func (a *Addon) ParamsSchema() *JSONSchema {
+	additionalProperties := false
+	schema := &JSONSchema{
+		Schema:               JSONSchemaDraft,
+		Title:                fmt.Sprintf("%s parameters", a.ShortName),
+		Description:          a.Description,
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: &additionalProperties,
+	}
+	for _, param := range a.Params {
+		description := param.Description
+		switch param.Kind {
+		case ParamKindFile:
+			description += " (path to a file, relative to the cluster manifest)"
+		case ParamKindSecret:
+			description += " (path to a file sealed with the cluster sealed secrets certificate, relative to the cluster manifest"
+			if param.SecretRefTarget != "" {
+				description += ", or " + SecretRefPrefix + "<name> of an existing Secret"
+			}
+			description += ")"
+		}
+		schema.Properties[param.Name] = &JSONSchema{
+			Description: description,
+			Type:        "string",
+			Default:     param.DefaultValue,
+		}
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+	return schema
+}
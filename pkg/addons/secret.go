@@ -0,0 +1,205 @@
+package addons
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	ssv1alpha1 "github.com/bitnami-labs/sealed-secrets/pkg/apis/sealed-secrets/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/cert"
+)
+
+// SecretRefPrefix prefixes the value of secret parameters naming an existing
+// Secret instead of a file, eg. "secretRef:flux-git-deploy".
+const SecretRefPrefix = "secretRef:"
+
+// secretRef returns the name of the Secret referenced by the value of a secret
+// parameter, if it references one.
+func secretRef(input string) (string, bool) {
+	if !strings.HasPrefix(input, SecretRefPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(input, SecretRefPrefix), true
+}
+
+func (p *Param) secretValue(config *BuildOptions, input string) (string, error) {
+	if name, ok := secretRef(input); ok {
+		if p.SecretRefTarget == "" {
+			return "", fmt.Errorf("addon: parameter '%s' can't reference an existing Secret", p.Name)
+		}
+		if name == "" {
+			return "", fmt.Errorf("addon: parameter '%s' references a Secret without a name", p.Name)
+		}
+		return name, nil
+	}
+	content, err := ioutil.ReadFile(resolvePath(config.BasePath, input))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(content), nil
+}
+
+// secretMaterial returns, indexed by parameter name, the values of the secret
+// parameters given in the build options which mustn't end up in plaintext in
+// the built objects: the file contents, raw and base64-encoded.
+func (a *Addon) secretMaterial(config *BuildOptions) (map[string][]string, error) {
+	material := make(map[string][]string)
+	for k, v := range config.Params {
+		param := a.Param(k)
+		if param == nil || param.Kind != ParamKindSecret {
+			continue
+		}
+		if _, ok := secretRef(v); ok {
+			continue
+		}
+		content, err := ioutil.ReadFile(resolvePath(config.BasePath, v))
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(content)) == 0 {
+			continue
+		}
+		material[k] = []string{string(content), base64.StdEncoding.EncodeToString(content)}
+	}
+	return material, nil
+}
+
+// containsSecret returns the name of the secret parameter whose value is found
+// in v, if any.
+func containsSecret(v interface{}, material map[string][]string) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		for param, values := range material {
+			for _, value := range values {
+				if strings.Contains(v, value) {
+					return param, true
+				}
+			}
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			if param, ok := containsSecret(e, material); ok {
+				return param, true
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if param, ok := containsSecret(e, material); ok {
+				return param, true
+			}
+		}
+	}
+	return "", false
+}
+
+func isSecret(o object) bool {
+	return o.String("apiVersion") == "v1" && o.String("kind") == "Secret"
+}
+
+// parseSealedSecretCert returns the public key of a PEM encoded sealed secrets
+// certificate.
+func parseSealedSecretCert(data []byte) (*rsa.PublicKey, error) {
+	certs, err := cert.ParseCertsPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed secrets certificate: %v", err)
+	}
+	key, ok := certs[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("invalid sealed secrets certificate: expected a RSA public key")
+	}
+	return key, nil
+}
+
+// sealSecret turns a Secret into a SealedSecret encrypted with key.
+func sealSecret(o object, key *rsa.PublicKey) (object, error) {
+	data, err := o.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := json.Unmarshal(data, secret); err != nil {
+		return nil, err
+	}
+	if secret.Namespace == "" {
+		return nil, fmt.Errorf("Secret %s needs a namespace to be sealed", secret.Name)
+	}
+	ss, err := ssv1alpha1.NewSealedSecret(scheme.Codecs, key, secret)
+	if err != nil {
+		return nil, err
+	}
+	ss.APIVersion = ssv1alpha1.SchemeGroupVersion.String()
+	ss.Kind = "SealedSecret"
+	if data, err = json.Marshal(ss); err != nil {
+		return nil, err
+	}
+	sealed, err := newObjectFromJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	delete(sealed.Object("metadata"), "creationTimestamp")
+	delete(sealed.Object("spec.template.metadata"), "creationTimestamp")
+	return sealed, nil
+}
+
+// withSealedSecrets seals the Secrets holding the value of secret parameters.
+func withSealedSecrets(material map[string][]string, key *rsa.PublicKey) transform {
+	return func(o object) (object, error) {
+		if !isSecret(o) {
+			return o, nil
+		}
+		if _, ok := containsSecret(map[string]interface{}(o), material); !ok {
+			return o, nil
+		}
+		return sealSecret(o, key)
+	}
+}
+
+// protectSecrets makes sure the value of secret parameters isn't written in
+// plaintext: the Secrets holding them are sealed with the sealed secrets
+// certificate. Plaintext secret material is otherwise only written when
+// explicitly allowed.
+func protectSecrets(config *BuildOptions, files []*manifestFile, material map[string][]string) error {
+	if len(config.SealedSecretCert) > 0 {
+		key, err := parseSealedSecretCert(config.SealedSecretCert)
+		if err != nil {
+			return err
+		}
+		for _, m := range files {
+			if err := m.transform(withSealedSecrets(material, key)); err != nil {
+				return err
+			}
+		}
+	}
+	if config.AllowPlaintextSecrets {
+		return nil
+	}
+
+	var errs []string
+	for _, m := range files {
+		m.forEachObject(func(o object) {
+			param, ok := containsSecret(map[string]interface{}(o), material)
+			if !ok {
+				return
+			}
+			ref := objectRef(o)
+			reason := "a sealed secrets certificate is required"
+			if !isSecret(o) {
+				reason = "only Secrets can be sealed"
+			}
+			errs = append(errs, fmt.Sprintf("secret parameter '%s' would be written in plaintext to %s: %s", param, ref.String(), reason))
+		})
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("addon: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -1,81 +1,137 @@
 package addons
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
-)
 
-type transform func(object) object
+	"github.com/ghodss/yaml"
+	"github.com/weaveworks/wksctl/pkg/addons/assets"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
+)
 
-func list(items []object) object {
-	return object{
-		"apiVersion": "v1",
-		"kind":       "List",
-		"items":      items,
-	}
-}
+type transform func(object) (object, error)
 
-func transformList(l object, transforms ...transform) object {
-	items, err := l.GetObjectArray("items")
-	if err != nil {
-		fmt.Println(err)
+// applyTransforms transforms o, or the items of o if o is a List.
+func applyTransforms(o object, transforms []transform) (object, error) {
+	if isList(o) {
+		var items []interface{}
+		for _, item := range o.ObjectArray("items") {
+			transformed, err := applyTransforms(item, transforms)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, map[string]interface{}(transformed))
+		}
+		o["items"] = items
+		return o, nil
 	}
-
-	var transformedItems []object
-	for _, item := range items {
-		for _, t := range transforms {
-			item = t(item)
+	for _, t := range transforms {
+		var err error
+		if o, err = t(o); err != nil {
+			return nil, err
 		}
-		transformedItems = append(transformedItems, item)
 	}
-
-	n := list(transformedItems)
-	return n
+	return o, nil
 }
 
-func forEachContainer(o object, cb func(container object)) {
+// forEachPodSpec calls cb on the spec of Pods and on the pod templates of
+// workloads (Deployments, DaemonSets, Jobs, CronJobs, PodTemplates, ...).
+func forEachPodSpec(o object, cb func(spec object)) {
 	paths := []string{
-		"spec.template.spec.initContainers",
-		"spec.template.spec.containers",
-		"spec.jobTemplate.spec.template.spec.initContainers",
-		"spec.jobTemplate.spec.template.spec.containers",
+		"spec",
+		"template.spec",
+		"spec.template.spec",
+		"spec.jobTemplate.spec.template.spec",
 	}
 	for _, path := range paths {
-		containers, err := o.GetObjectArray(path)
+		spec, err := o.GetObject(path)
 		if err != nil {
 			continue
 		}
-		for _, container := range containers {
-			cb(container)
+		if _, err := spec.Get("containers"); err != nil {
+			continue
 		}
+		cb(spec)
 	}
+}
 
+// forEachPodTemplateMetadata calls cb on the metadata of the pods created by
+// o, creating it if needed.
+func forEachPodTemplateMetadata(o object, cb func(metadata object)) {
+	for _, path := range []string{"spec.template", "spec.jobTemplate.spec.template"} {
+		template, err := o.GetObject(path)
+		if err != nil {
+			continue
+		}
+		if _, err := template.GetObject("metadata"); err != nil {
+			template.SetObject("metadata", newObject())
+		}
+		cb(template.Object("metadata"))
+	}
+}
+
+// forEachContainer calls cb on the containers and init containers of Pods and
+// of the pod templates of workloads (Deployments, DaemonSets, Jobs, CronJobs,
+// ...).
+func forEachContainer(o object, cb func(container object)) {
+	forEachPodSpec(o, func(spec object) {
+		for _, path := range []string{"initContainers", "containers"} {
+			containers, err := spec.GetObjectArray(path)
+			if err != nil {
+				continue
+			}
+			for _, container := range containers {
+				cb(container)
+			}
+		}
+	})
 }
 
-func withImageRepository(repository string) transform {
-	return func(o object) object {
+// ImageRewrite records a container image rewritten to use a different
+// repository.
+type ImageRewrite struct {
+	// Object is the Kubernetes object containing the image, eg.
+	// "Deployment/flux/flux".
+	Object string
+	From   string
+	To     string
+}
+
+func withImageRepository(repository string, report func(ImageRewrite)) transform {
+	return func(o object) (object, error) {
+		ref := objectRef(o)
 		forEachContainer(o, func(container object) {
 			image, err := container.GetString("image")
 			if err != nil {
 				return
 			}
 			updatedImage, err := UpdateImage(image, repository)
-			if err != nil {
+			if err != nil || updatedImage == image {
 				return
 			}
 			container.SetString("image", updatedImage)
+			if report != nil {
+				report(ImageRewrite{Object: ref.String(), From: image, To: updatedImage})
+			}
 		})
-		return o
+		return o, nil
 	}
 }
 
 // UpdateImage updates the provided container image's fully-qualified name with
-// the provided repository.
+// the provided repository. When the repository has an organisation, it
+// replaces the whole path of the image, nested or not, the way registry-sync
+// mirrors images, see mirror.Destination.
 func UpdateImage(image, repository string) (string, error) {
 	if repository == "" {
 		return image, nil
 	}
-	ref, err := parseImageReference(image)
+	ref, err := registry.NewImage(image)
 	if err != nil {
 		return "", err
 	}
@@ -85,11 +141,215 @@ func UpdateImage(image, repository string) (string, error) {
 	if len(repositoryParts) > 2 {
 		return "", fmt.Errorf("Invalid repository. Expected: \"host:port\" or \"host:port/org\" but got: %s", repository)
 	}
-	ref.Domain = repositoryParts[0]
+	ref.Registry = repositoryParts[0]
 	if len(repositoryParts) == 2 {
-		// Override the organisation with the one provided in the repository:
-		ref.Organisation = repositoryParts[1]
+		// Override the path with the organisation provided in the repository:
+		ref.User = repositoryParts[1]
 	}
 
 	return ref.String(), nil
 }
+
+// Transform describes modifications made to every Kubernetes object of an
+// addon once built. Transforms are specified in the cluster manifest, under
+// the "transforms" key of an addon.
+type Transform struct {
+	// Labels are added to objects and to the pods they create.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are added to objects and to the pods they create.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// NodeSelector is merged into the node selector of pods.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to pods not tolerating them already.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// DefaultResources sets the requests and limits of containers not
+	// specifying them.
+	DefaultResources *corev1.ResourceRequirements `json:"defaultResources,omitempty"`
+	// PriorityClassName is set on pods without a priority class.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Jsonnet is the path to a jsonnet file evaluating to a function taking a
+	// Kubernetes object and returning the transformed object. Relative paths
+	// are resolved against the BuildOptions BasePath.
+	Jsonnet string `json:"jsonnet,omitempty"`
+}
+
+// ParseTransforms parses a JSON or YAML list of transforms.
+func ParseTransforms(data []byte) ([]Transform, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var transforms []Transform
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&transforms); err != nil {
+		return nil, err
+	}
+	return transforms, nil
+}
+
+// toObject converts a value to its JSON object representation.
+func toObject(v interface{}) (object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return newObjectFromJSON(bytes.NewReader(data))
+}
+
+func setStringMap(o object, path string, values map[string]string) {
+	for k, v := range values {
+		m, err := o.GetObject(path)
+		if err != nil {
+			m = newObject()
+			o.SetObject(path, m)
+		}
+		m[k] = v
+	}
+}
+
+func withMetadata(labels, annotations map[string]string) transform {
+	return func(o object) (object, error) {
+		setStringMap(o, "metadata.labels", labels)
+		setStringMap(o, "metadata.annotations", annotations)
+		forEachPodTemplateMetadata(o, func(metadata object) {
+			setStringMap(metadata, "labels", labels)
+			setStringMap(metadata, "annotations", annotations)
+		})
+		return o, nil
+	}
+}
+
+func withNodeSelector(selector map[string]string) transform {
+	return func(o object) (object, error) {
+		forEachPodSpec(o, func(spec object) {
+			setStringMap(spec, "nodeSelector", selector)
+		})
+		return o, nil
+	}
+}
+
+func withTolerations(tolerations []corev1.Toleration) (transform, error) {
+	for i := range tolerations {
+		if _, err := toObject(&tolerations[i]); err != nil {
+			return nil, err
+		}
+	}
+	return func(o object) (object, error) {
+		forEachPodSpec(o, func(spec object) {
+			existing := spec.ObjectArray("tolerations")
+			items, _ := spec["tolerations"].([]interface{})
+		next:
+			for i := range tolerations {
+				// Objects don't share their tolerations.
+				t, _ := toObject(&tolerations[i])
+				for _, e := range existing {
+					if e.Equal(t) {
+						continue next
+					}
+				}
+				items = append(items, map[string]interface{}(t))
+			}
+			spec["tolerations"] = items
+		})
+		return o, nil
+	}, nil
+}
+
+func withDefaultResources(resources *corev1.ResourceRequirements) (transform, error) {
+	defaults, err := toObject(resources)
+	if err != nil {
+		return nil, err
+	}
+	return func(o object) (object, error) {
+		forEachContainer(o, func(container object) {
+			for _, kind := range []string{"requests", "limits"} {
+				for name, quantity := range defaults.Object(kind) {
+					path := "resources." + kind + "." + name
+					if _, err := container.Get(path); err == nil {
+						continue
+					}
+					container.Set(path, quantity)
+				}
+			}
+		})
+		return o, nil
+	}, nil
+}
+
+func withPriorityClassName(name string) transform {
+	return func(o object) (object, error) {
+		forEachPodSpec(o, func(spec object) {
+			if spec.String("priorityClassName") == "" {
+				spec.SetString("priorityClassName", name)
+			}
+		})
+		return o, nil
+	}
+}
+
+func withJsonnet(config *BuildOptions, filename string) (transform, error) {
+	// Imports are resolved relatively to absolute paths.
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	script, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return func(o object) (object, error) {
+		input, err := o.toJSON()
+		if err != nil {
+			return nil, err
+		}
+		vm := makeVM(config, assets.Assets, embeddedImportCache)
+		vm.TLACode("object", string(input))
+		output, err := vm.EvaluateSnippet(filename, string(script))
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %s: %v", filename, objectRef(o), err)
+		}
+		transformed, err := newObjectFromJSON(strings.NewReader(output))
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %s: %v", filename, objectRef(o), err)
+		}
+		return transformed, nil
+	}, nil
+}
+
+// transforms returns the list of transforms t describes, in the order they
+// are applied.
+func (t *Transform) transforms(config *BuildOptions) ([]transform, error) {
+	var transforms []transform
+	if len(t.Labels) > 0 || len(t.Annotations) > 0 {
+		transforms = append(transforms, withMetadata(t.Labels, t.Annotations))
+	}
+	if len(t.NodeSelector) > 0 {
+		transforms = append(transforms, withNodeSelector(t.NodeSelector))
+	}
+	if len(t.Tolerations) > 0 {
+		tolerations, err := withTolerations(t.Tolerations)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, tolerations)
+	}
+	if t.DefaultResources != nil {
+		resources, err := withDefaultResources(t.DefaultResources)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, resources)
+	}
+	if t.PriorityClassName != "" {
+		transforms = append(transforms, withPriorityClassName(t.PriorityClassName))
+	}
+	if t.Jsonnet != "" {
+		jsonnet, err := withJsonnet(config, resolvePath(config.BasePath, t.Jsonnet))
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, jsonnet)
+	}
+	return transforms, nil
+}
@@ -46,6 +46,34 @@ func TestUpdateImage(t *testing.T) {
 			expectedImage: "registry.weave.works/wkp/grafana:x.y.z",
 			expectedError: nil,
 		},
+		// Nested paths are replaced by the organisation, tags and digests are
+		// kept:
+		{
+			image:         "registry.example.com/team/sub/app:v1@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			repository:    "registry.weave.works/wkp",
+			expectedImage: "registry.weave.works/wkp/app:v1@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			expectedError: nil,
+		},
+		{
+			image:         "registry.example.com/team/sub/app:v1",
+			repository:    "172.17.0.2:5000",
+			expectedImage: "172.17.0.2:5000/team/sub/app:v1",
+			expectedError: nil,
+		},
+		// As for docker, "team" isn't a registry host, it has no dot nor port,
+		// and the path is replaced too:
+		{
+			image:         "team/sub/app:v1",
+			repository:    "registry.weave.works/wkp",
+			expectedImage: "registry.weave.works/wkp/app:v1",
+			expectedError: nil,
+		},
+		{
+			image:         "team/sub/app:v1",
+			repository:    "172.17.0.2:5000",
+			expectedImage: "172.17.0.2:5000/team/sub/app:v1",
+			expectedError: nil,
+		},
 		// WKS controller's image shouldn't change if no repository is specified:
 		{
 			image:         "quay.io/wksctl/controller:master",
@@ -0,0 +1,77 @@
+package addons
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema"
+)
+
+// ValidationError is a schema violation found in a built manifest.
+type ValidationError struct {
+	// File is the manifest containing the invalid object.
+	File string
+	// Object identifies the invalid object, eg. "Deployment/flux/flux".
+	Object string
+	schema.FieldError
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.File, e.Object, e.FieldError.Error())
+}
+
+// ValidationErrors are all the schema violations found in built manifests.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	var lines []string
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate validates the objects of built manifests against the schemas known
+// to v, and against the CustomResourceDefinitions of the manifests. It returns
+// ValidationErrors when objects are invalid.
+func Validate(v *schema.Validator, manifests []string) error {
+	var files []*manifestFile
+	for _, filename := range manifests {
+		m, err := readManifestFile(filename)
+		if err != nil {
+			return err
+		}
+		files = append(files, m)
+	}
+
+	v = v.Copy()
+	for _, m := range files {
+		var err error
+		m.forEachObject(func(o object) {
+			if err == nil && schema.IsCRD(o) {
+				err = v.AddCRD(o)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %v", m.filename, err)
+		}
+	}
+
+	var errs ValidationErrors
+	for _, m := range files {
+		m.forEachObject(func(o object) {
+			ref := objectRef(o)
+			for _, e := range v.Validate(o) {
+				errs = append(errs, ValidationError{
+					File:       m.filename,
+					Object:     ref.String(),
+					FieldError: e,
+				})
+			}
+		})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -2,19 +2,29 @@ package addons
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
+	"sync"
 
 	"github.com/google/go-jsonnet"
-	"github.com/weaveworks/wksctl/pkg/addons/assets"
 )
 
-// vfsImport implements a jsonnet VM Importer for vfsgen static data.
+// vfsImport implements a jsonnet VM Importer for vfsgen static data, layered
+// with library directories on the filesystem.
 type vfsImporter struct {
 	searchPaths []string
-	assets      http.FileSystem
-	cache       map[string]*cacheEntry
+	// libraryPaths are filesystem directories searched before searchPaths.
+	libraryPaths []string
+	assets       http.FileSystem
+	// assetsCache caches the files of assets. It can be shared between
+	// importers of the same assets.
+	assetsCache *importCache
+	// filesCache caches the files of the filesystem for the duration of a
+	// build.
+	filesCache *importCache
 }
 
 type cacheEntry struct {
@@ -22,9 +32,49 @@ type cacheEntry struct {
 	contents jsonnet.Contents
 }
 
-func newVFSImporter() *vfsImporter {
+// importCache caches the contents of imported files, indexed by path. It's
+// safe for concurrent use.
+type importCache struct {
+	mu      sync.RWMutex
+	entries map[string]*cacheEntry
+}
+
+func newImportCache() *importCache {
+	return &importCache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// embeddedImportCache is shared by the builds of the embedded addons: their
+// files never change.
+var embeddedImportCache = newImportCache()
+
+// get returns the cache entry of key, calling load to build it when missing.
+// Concurrent loads of the same key may happen, they are expected to return
+// the same entry.
+func (c *importCache) get(key string, load func() (*cacheEntry, error)) (*cacheEntry, error) {
+	c.mu.RLock()
+	entry := c.entries[key]
+	c.mu.RUnlock()
+	if entry != nil {
+		return entry, nil
+	}
+
+	entry, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return entry, nil
+}
+
+func newVFSImporter(assets http.FileSystem, assetsCache *importCache) *vfsImporter {
 	return &vfsImporter{
-		cache: make(map[string]*cacheEntry),
+		assets:      assets,
+		assetsCache: assetsCache,
+		filesCache:  newImportCache(),
 	}
 }
 
@@ -36,31 +86,91 @@ func (importer *vfsImporter) tryPath(dir, importedPath string) (found bool, cont
 		absPath = path.Join(dir, importedPath)
 	}
 
-	entry := importer.cache[absPath]
-	if entry == nil {
-		// Build cache entry.
-		s, err := assets.ReadAll(absPath)
+	entry, _ := importer.assetsCache.get(absPath, func() (*cacheEntry, error) {
+		s, err := importer.readAll(absPath)
 		if os.IsNotExist(err) {
-			entry = &cacheEntry{
+			return &cacheEntry{
 				exists: false,
-			}
-		} else {
-			entry = &cacheEntry{
-				exists:   true,
-				contents: jsonnet.MakeContents(s),
-			}
+			}, nil
 		}
+		return &cacheEntry{
+			exists:   true,
+			contents: jsonnet.MakeContents(s),
+		}, nil
+	})
 
-		importer.cache[absPath] = entry
+	return entry.exists, entry.contents, absPath, nil
+}
+
+func (importer *vfsImporter) readAll(path string) (string, error) {
+	f, err := importer.assets.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	return string(data), err
+}
+
+// tryFile is tryPath for files on the filesystem.
+func (importer *vfsImporter) tryFile(dir, importedPath string) (found bool, contents jsonnet.Contents, foundHere string, err error) {
+	absPath := importedPath
+	if !filepath.IsAbs(importedPath) {
+		absPath = filepath.Join(dir, importedPath)
+	}
+
+	entry, err := importer.filesCache.get(absPath, func() (*cacheEntry, error) {
+		data, err := ioutil.ReadFile(absPath)
+		if os.IsNotExist(err) {
+			return &cacheEntry{
+				exists: false,
+			}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &cacheEntry{
+			exists:   true,
+			contents: jsonnet.MakeContents(string(data)),
+		}, nil
+	})
+	if err != nil {
+		return false, jsonnet.Contents{}, "", err
 	}
 
 	return entry.exists, entry.contents, absPath, nil
 }
 
+// isAsset returns whether filename is part of the embedded assets, as opposed
+// to a file on the filesystem.
+func (importer *vfsImporter) isAsset(filename string) bool {
+	f, err := importer.assets.Open(filename)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
 func (importer *vfsImporter) Import(importedFrom, importedPath string) (contents jsonnet.Contents, foundAt string, err error) {
 	dir, _ := path.Split(importedFrom)
 	found, content, foundHere, err := importer.tryPath(dir, importedPath)
 
+	// Files on the filesystem import relatively to themselves.
+	if !found && filepath.IsAbs(importedFrom) && !importer.isAsset(importedFrom) {
+		found, content, foundHere, err = importer.tryFile(filepath.Dir(importedFrom), importedPath)
+		if err != nil {
+			return jsonnet.Contents{}, "", err
+		}
+	}
+
+	for i := len(importer.libraryPaths) - 1; !found && i >= 0; i-- {
+		found, content, foundHere, err = importer.tryFile(importer.libraryPaths[i], importedPath)
+		if err != nil {
+			return jsonnet.Contents{}, "", err
+		}
+	}
+
 	for i := len(importer.searchPaths) - 1; !found && i >= 0; i-- {
 		found, content, foundHere, err = importer.tryPath(importer.searchPaths[i], importedPath)
 		if err != nil {
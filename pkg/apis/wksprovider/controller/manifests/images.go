@@ -0,0 +1,52 @@
+package manifests
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/registry"
+)
+
+// WksControllerManifest is the manifest of the WKS controller, whose image can
+// be overridden when applying the cluster.
+const WksControllerManifest = "04_controller.yaml"
+
+// ControllerManifests are the manifests of the controllers deployed in the
+// cluster.
+var ControllerManifests = []string{
+	WksControllerManifest,
+	"06_sealed_secret_controller.yaml",
+}
+
+// ListImages lists the container images used by the controller manifests.
+func ListImages() ([]registry.Image, error) {
+	var images []registry.Image
+	for _, name := range ControllerManifests {
+		manifestImages, err := ListManifestImages(name)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, manifestImages...)
+	}
+	return images, nil
+}
+
+// ListManifestImages lists the container images used by one of the controller
+// manifests.
+func ListManifestImages(name string) ([]registry.Image, error) {
+	file, err := Manifests.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+	images, err := addons.ListImagesFromManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract images from %s: %v", name, err)
+	}
+	return images, nil
+}
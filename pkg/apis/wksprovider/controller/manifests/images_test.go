@@ -0,0 +1,19 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListImages(t *testing.T) {
+	images, err := ListImages()
+	assert.NoError(t, err)
+
+	var names []string
+	for _, image := range images {
+		assert.NotEmpty(t, image.Tag)
+		names = append(names, image.Name)
+	}
+	assert.Equal(t, []string{"cluster-api-existinginfra-controller", "sealed-secrets-controller"}, names)
+}
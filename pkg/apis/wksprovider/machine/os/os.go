@@ -19,10 +19,15 @@ import (
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/cluster/machine"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/plan"
 	capeiresource "github.com/weaveworks/cluster-api-provider-existinginfra/pkg/plan/resource"
+	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/plan/runners/sudo"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/scheme"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/specs"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/utilities/object"
 	"github.com/weaveworks/libgitops/pkg/serializer"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/plan/recipe"
+	"github.com/weaveworks/wksctl/pkg/plan/runners/ssh"
+	wksspecs "github.com/weaveworks/wksctl/pkg/specs"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
@@ -38,8 +43,9 @@ var (
 
 // SetupSeedNode installs Kubernetes on this machine, and store the provided
 // manifests in the API server, so that the rest of the cluster can then be
-// set up by the WKS controller.
-func SetupSeedNode(o *capeios.OS, params capeios.SeedNodeParams) error {
+// set up by the WKS controller. Once the seed node is set up, it waits on the
+// readiness checks of the CNI addon it installed, if any.
+func SetupSeedNode(o *capeios.OS, params capeios.SeedNodeParams, cniReadiness []addons.ReadinessCheck) error {
 	ctx := context.Background()
 	sp, updatedParams, err := createSecretPlan(o, params)
 	if err != nil {
@@ -53,10 +59,15 @@ func SetupSeedNode(o *capeios.OS, params capeios.SeedNodeParams) error {
 	if err != nil {
 		return err
 	}
-	if sp != nil {
+	if sp != nil || len(cniReadiness) > 0 {
 		b := plan.NewBuilder()
-		b.AddResource("install:secret-support", sp)
+		if sp != nil {
+			b.AddResource("install:secret-support", sp)
+		}
 		b.AddResource("install:seed-node", p)
+		if len(cniReadiness) > 0 {
+			b.AddResource("wait:cni", recipe.BuildReadinessPlan("cni", cniReadiness), plan.DependOn("install:seed-node"))
+		}
 		plan, err := b.Plan()
 		if err != nil {
 			return err
@@ -244,37 +255,10 @@ func createAuthConfigMapManifest(authnSecretName, authzSecretName string, authnC
 // Decrypts secret, adds plan resources to install files found inside, plus a kubeconfig file pointing to them.
 // returns the sealed file contents, decrypted contents, secret name, kubeconfig, error if any
 func processSecret(b *plan.Builder, key *rsa.PrivateKey, configDir, secretFileName, URL string) ([]byte, map[string][]byte, string, []byte, error) {
-	// Read the file contents at configDir/secretFileName
-	contents, err := getConfigFileContents(configDir, secretFileName)
+	contents, secret, err := unsealSecretFile(key, configDir, secretFileName)
 	if err != nil {
 		return nil, nil, "", nil, err
 	}
-
-	// Create a new YAML FrameReader from the given bytes
-	fr := serializer.NewYAMLFrameReader(serializer.FromBytes(contents))
-	// Create the secret to decode into
-	ss := &ssv1alpha1.SealedSecret{}
-	// Decode the Sealed Secret into the object
-	// In the future, if we wish to support other kinds of secrets than SealedSecrets, we
-	// can just change this to do .Decode(fr), and switch on the type
-	if err := scheme.Serializer.Decoder().DecodeInto(fr, ss); err != nil {
-		return nil, nil, "", nil, errors.Wrapf(err, "couldn't decode the file %q into a sealed secret", secretFileName)
-	}
-
-	fingerprint, err := crypto.PublicKeyFingerprint(&key.PublicKey)
-	if err != nil {
-		return nil, nil, "", nil, err
-	}
-	keys := map[string]*rsa.PrivateKey{fingerprint: key}
-
-	codecs := scheme.Serializer.Codecs()
-	if codecs == nil {
-		return nil, nil, "", nil, fmt.Errorf("codecs must not be nil")
-	}
-	secret, err := ss.Unseal(*codecs, keys)
-	if err != nil {
-		return nil, nil, "", nil, errors.Wrap(err, "Could not unseal auth secret")
-	}
 	decrypted := map[string][]byte{}
 	secretName := secret.Name
 	for _, key := range pemKeys {
@@ -322,6 +306,105 @@ func processSecret(b *plan.Builder, key *rsa.PrivateKey, configDir, secretFileNa
 	return contents, decrypted, secretName, authConfig, nil
 }
 
+// unsealSecretFile reads a SealedSecret from a file in the config directory
+// and decrypts it. It returns the file contents and the unsealed Secret.
+func unsealSecretFile(key *rsa.PrivateKey, configDir, secretFileName string) ([]byte, *v1.Secret, error) {
+	// Read the file contents at configDir/secretFileName
+	contents, err := getConfigFileContents(configDir, secretFileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Create a new YAML FrameReader from the given bytes
+	fr := serializer.NewYAMLFrameReader(serializer.FromBytes(contents))
+	// Create the secret to decode into
+	ss := &ssv1alpha1.SealedSecret{}
+	// Decode the Sealed Secret into the object
+	// In the future, if we wish to support other kinds of secrets than SealedSecrets, we
+	// can just change this to do .Decode(fr), and switch on the type
+	if err := scheme.Serializer.Decoder().DecodeInto(fr, ss); err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't decode the file %q into a sealed secret", secretFileName)
+	}
+
+	fingerprint, err := crypto.PublicKeyFingerprint(&key.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := map[string]*rsa.PrivateKey{fingerprint: key}
+
+	codecs := scheme.Serializer.Codecs()
+	if codecs == nil {
+		return nil, nil, fmt.Errorf("codecs must not be nil")
+	}
+	secret, err := ss.Unseal(*codecs, keys)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Could not unseal secret %q", secretFileName)
+	}
+	return contents, secret, nil
+}
+
+// ReadRegistryCredentials unseals the registry credentials of the cluster,
+// see the credentialsSecretFile of its registries, and returns their docker
+// configuration JSON.
+func ReadRegistryCredentials(privateKey, configDir, secretFileName string) ([]byte, error) {
+	if privateKey == "" {
+		return nil, errors.New("a sealed secrets key is required to unseal the registry credentials")
+	}
+	key, err := getPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	_, secret, err := unsealSecretFile(key, configDir, secretFileName)
+	if err != nil {
+		return nil, err
+	}
+	credentials, ok := secret.Data[v1.DockerConfigJsonKey]
+	if secret.Type != v1.SecretTypeDockerConfigJson || !ok {
+		return nil, fmt.Errorf("secret %q holding the registry credentials must be of type %s", secret.Name, v1.SecretTypeDockerConfigJson)
+	}
+	if err := wksspecs.CheckRegistryCredentials(credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// WriteRegistryCredentials writes the unsealed registry credentials to paths
+// on a machine, readable by root only. They are piped over SSH: unlike the
+// os.files of the cluster spec, they are never part of a plan, of a ConfigMap
+// or of the cluster manifest.
+func WriteRegistryCredentials(ctx context.Context, runner plan.Runner, credentials []byte, paths []string) error {
+	for _, path := range paths {
+		cmd := fmt.Sprintf("mkdir -p %s && umask 077 && cat > %s", filepath.Dir(path), path)
+		if output, err := runner.RunCommand(ctx, cmd, bytes.NewReader(credentials)); err != nil {
+			return errors.Wrapf(err, "failed to write %s: %s", path, output)
+		}
+	}
+	return nil
+}
+
+// WriteMachinesRegistryCredentials writes the unsealed registry credentials
+// on every machine of the machines manifest, logging in with the SSH key at
+// sshKeyPath.
+func WriteMachinesRegistryCredentials(ctx context.Context, clusterSpec *existinginfrav1.ClusterSpec, machinesManifest []byte, sshKeyPath string, credentials []byte) error {
+	_, eims, err := machine.Parse(ioutil.NopCloser(bytes.NewReader(machinesManifest)))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse machine manifest: ")
+	}
+	paths := wksspecs.RegistryCredentialsPaths(clusterSpec.CRI)
+	for _, eim := range eims {
+		sshClient, err := ssh.NewClientForMachine(&eim.Spec, clusterSpec.User, sshKeyPath, log.GetLevel() > log.InfoLevel)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create SSH client for machine %s", eim.Name)
+		}
+		err = WriteRegistryCredentials(ctx, &sudo.Runner{Runner: sshClient}, credentials, paths)
+		sshClient.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to write the registry credentials on machine %s", eim.Name)
+		}
+	}
+	return nil
+}
+
 // getConfigFileContents reads a config manifest from a file in the config directory.
 func getConfigFileContents(fileNameComponent ...string) ([]byte, error) {
 	return ioutil.ReadFile(filepath.Join(fileNameComponent...))
@@ -0,0 +1,336 @@
+// Package bundle creates and loads the archives installing clusters without
+// Internet access. Bundles hold container images, rendered addon manifests
+// and, optionally, RPM or DEB packages.
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/utilities/tarball"
+	"github.com/weaveworks/wksctl/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Bundles are gzipped tarballs of a directory holding:
+const (
+	// indexFile, the Index of the bundle,
+	indexFile = "bundle.json"
+	// imagesDirectory, an OCI image layout holding the images,
+	imagesDirectory = "images"
+	// manifestsDirectory, the rendered manifests,
+	manifestsDirectory = "manifests"
+	// packagesDirectory, the packages.
+	packagesDirectory = "packages"
+)
+
+// PackageType is the type of the packages of a bundle.
+type PackageType string
+
+// Package types.
+const (
+	PackageTypeRPM PackageType = "rpm"
+	PackageTypeDEB PackageType = "deb"
+)
+
+// Index describes the contents of a bundle.
+type Index struct {
+	WksctlVersion string `json:"wksctlVersion"`
+	// Images are the names of the images of the bundle, their references in
+	// the registries they were pulled from.
+	Images []string `json:"images"`
+	// Manifests are the paths of the manifests, relative to the manifests
+	// directory.
+	Manifests []string `json:"manifests,omitempty"`
+	// Packages is the type of the packages of the bundle, if any.
+	Packages PackageType `json:"packages,omitempty"`
+}
+
+// Contents are the contents of a bundle to create.
+type Contents struct {
+	// Images are the references of the images to pull.
+	Images []string
+	// ManifestsDirectory, if set, is a directory of manifests to bundle.
+	ManifestsDirectory string
+	// PackagesDirectory, if set, is a directory of RPM or DEB packages to
+	// bundle.
+	PackagesDirectory string
+}
+
+// Create pulls the images of contents, at most workers at a time, and writes
+// the bundle to path. workers defaults to the number of CPUs.
+func Create(ctx context.Context, client *registry.Client, path string, contents Contents, workers int) (*Index, error) {
+	dir, err := ioutil.TempDir("", "wksctl-bundle")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	index := &Index{WksctlVersion: version.Version, Images: contents.Images}
+	if contents.PackagesDirectory != "" {
+		if index.Packages, err = packageType(contents.PackagesDirectory); err != nil {
+			return nil, err
+		}
+		if err := copyDirectory(filepath.Join(dir, packagesDirectory), contents.PackagesDirectory); err != nil {
+			return nil, err
+		}
+	}
+	if contents.ManifestsDirectory != "" {
+		if err := copyDirectory(filepath.Join(dir, manifestsDirectory), contents.ManifestsDirectory); err != nil {
+			return nil, err
+		}
+		if index.Manifests, err = listFiles(filepath.Join(dir, manifestsDirectory)); err != nil {
+			return nil, err
+		}
+	}
+
+	layout, err := registry.OpenLayout(filepath.Join(dir, imagesDirectory))
+	if err != nil {
+		return nil, err
+	}
+	var failed []string
+	for _, result := range client.PullAll(ctx, contents.Images, layout, workers) {
+		if result.Err != nil {
+			failed = append(failed, result.Err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to pull %d images:\n%s", len(failed), strings.Join(failed, "\n"))
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, indexFile), data, 0644); err != nil {
+		return nil, err
+	}
+	t := tarball.Tarball{Path: path, Compression: "z"}
+	if err := t.Pack(dir); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// LoadOptions are the options of Load.
+type LoadOptions struct {
+	// Repository is where the images are pushed, "host:port" or
+	// "host:port/org", see the imageRepository field of the cluster spec.
+	Repository string
+	// OutputDirectory is where the manifests, the packages and their
+	// repository configuration are written.
+	OutputDirectory string
+	// PackagesURL is the URL the packages are served from once written to
+	// the output directory.
+	PackagesURL string
+	// Workers is the number of images pushed concurrently. It defaults to
+	// the number of CPUs.
+	Workers int
+}
+
+// LoadResult reports the loading of a bundle.
+type LoadResult struct {
+	Index *Index
+	// Images are the results of the image pushes.
+	Images []registry.CopyResult
+	// Files are the files written to the output directory.
+	Files []string
+}
+
+// Load pushes the images of the bundle at path to a registry and writes its
+// manifests and packages, along with the configuration of their repository, to
+// the output directory. Failed pushes are reported in the result and as an
+// error.
+func Load(ctx context.Context, client *registry.Client, path string, options LoadOptions) (*LoadResult, error) {
+	if options.Repository == "" {
+		return nil, errors.New("no repository to push images to")
+	}
+	dir, err := ioutil.TempDir("", "wksctl-bundle")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	t := tarball.Tarball{Path: path, Compression: "z"}
+	if err := t.Unpack(dir); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, indexFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s isn't a wksctl bundle", path)
+	}
+	result := &LoadResult{Index: &Index{}}
+	if err := json.Unmarshal(data, result.Index); err != nil {
+		return nil, errors.Wrapf(err, "invalid bundle %s", path)
+	}
+	if result.Index.Packages != "" && options.PackagesURL == "" {
+		return nil, errors.New("no URL to serve the packages of the bundle from")
+	}
+
+	if err := os.MkdirAll(options.OutputDirectory, 0755); err != nil {
+		return nil, err
+	}
+	if len(result.Index.Manifests) > 0 {
+		output := filepath.Join(options.OutputDirectory, manifestsDirectory)
+		if err := copyDirectory(output, filepath.Join(dir, manifestsDirectory)); err != nil {
+			return nil, err
+		}
+		for _, m := range result.Index.Manifests {
+			result.Files = append(result.Files, filepath.Join(output, m))
+		}
+	}
+	if result.Index.Packages != "" {
+		files, err := writePackages(dir, result.Index.Packages, options)
+		if err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, files...)
+	}
+
+	layout, err := registry.OpenLayout(filepath.Join(dir, imagesDirectory))
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]registry.PushRequest, 0, len(result.Index.Images))
+	for _, image := range result.Index.Images {
+		dst, err := addons.UpdateImage(image, options.Repository)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, registry.PushRequest{Image: image, Destination: dst})
+	}
+	result.Images = client.PushAll(ctx, layout, requests, options.Workers)
+	failed := 0
+	for _, r := range result.Images {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return result, fmt.Errorf("failed to push %d of %d images", failed, len(result.Images))
+	}
+	return result, nil
+}
+
+// packageType returns the type of the packages of a directory.
+func packageType(dir string) (PackageType, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	types := make(map[PackageType]bool)
+	for _, f := range files {
+		switch filepath.Ext(f) {
+		case ".rpm":
+			types[PackageTypeRPM] = true
+		case ".deb":
+			types[PackageTypeDEB] = true
+		}
+	}
+	switch {
+	case len(types) > 1:
+		return "", fmt.Errorf("%s mixes RPM and DEB packages", dir)
+	case types[PackageTypeRPM]:
+		return PackageTypeRPM, nil
+	case types[PackageTypeDEB]:
+		return PackageTypeDEB, nil
+	default:
+		return "", fmt.Errorf("%s holds no RPM or DEB packages", dir)
+	}
+}
+
+// repoConfigMap is the name of the ConfigMap holding the package repository
+// configuration, to be referenced by the os.files of the cluster spec.
+const repoConfigMap = "repo"
+
+// writePackages writes the packages of the bundle unpacked in dir and the
+// configuration of their repository to the output directory.
+func writePackages(dir string, packages PackageType, options LoadOptions) ([]string, error) {
+	output := filepath.Join(options.OutputDirectory, packagesDirectory)
+	if err := copyDirectory(output, filepath.Join(dir, packagesDirectory)); err != nil {
+		return nil, err
+	}
+
+	var name, config string
+	switch packages {
+	case PackageTypeRPM:
+		name = "local.repo"
+		config = fmt.Sprintf("[wksctl-bundle]\nname=wksctl bundle\nbaseurl=%s\nenabled=1\ngpgcheck=0\n", options.PackagesURL)
+	case PackageTypeDEB:
+		name = "local.list"
+		config = fmt.Sprintf("deb [trusted=yes] %s ./\n", options.PackagesURL)
+	default:
+		return nil, fmt.Errorf("unsupported package type %q", packages)
+	}
+	repoFile := filepath.Join(options.OutputDirectory, name)
+	if err := ioutil.WriteFile(repoFile, []byte(config), 0644); err != nil {
+		return nil, err
+	}
+
+	cm := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: repoConfigMap, Namespace: "system"},
+		Data:       map[string]string{name: config},
+	}
+	data, err := yaml.Marshal(&cm)
+	if err != nil {
+		return nil, err
+	}
+	configMapFile := filepath.Join(options.OutputDirectory, "repo-config.yaml")
+	if err := ioutil.WriteFile(configMapFile, data, 0644); err != nil {
+		return nil, err
+	}
+	return []string{output, repoFile, configMapFile}, nil
+}
+
+// listFiles returns the sorted paths of the files of dir, relative to dir.
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// copyDirectory copies the files of src to dst, creating dst.
+func copyDirectory(dst, src string) error {
+	files, err := listFiles(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(filepath.Join(src, f))
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Join(dst, filepath.Dir(f)), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dst, f), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
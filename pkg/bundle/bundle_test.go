@@ -0,0 +1,98 @@
+package bundle_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/bundle"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/registrytest"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func TestCreateLoad(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+	flux := src.PushImage("fluxcd/flux", "1.13.3", "flux layer")
+	src.PushImage("weaveworks/weave-kube", "2.7.0", "weave layer")
+
+	dir, err := ioutil.TempDir("", "wksctl-bundle-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeFile(t, filepath.Join(dir, "in", "manifests", "flux", "flux.yaml"), "kind: Deployment\n")
+	writeFile(t, filepath.Join(dir, "in", "manifests", "cni.yaml"), "kind: DaemonSet\n")
+	writeFile(t, filepath.Join(dir, "in", "packages", "kubelet-1.17.13-0.x86_64.rpm"), "rpm")
+
+	client := &registry.Client{}
+	path := filepath.Join(dir, "bundle.tar.gz")
+	images := []string{src.Host() + "/fluxcd/flux:1.13.3", src.Host() + "/weaveworks/weave-kube:2.7.0"}
+	index, err := bundle.Create(context.Background(), client, path, bundle.Contents{
+		Images:             images,
+		ManifestsDirectory: filepath.Join(dir, "in", "manifests"),
+		PackagesDirectory:  filepath.Join(dir, "in", "packages"),
+	}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, images, index.Images)
+	assert.Equal(t, []string{"cni.yaml", "flux/flux.yaml"}, index.Manifests)
+	assert.Equal(t, bundle.PackageTypeRPM, index.Packages)
+
+	out := filepath.Join(dir, "out")
+	result, err := bundle.Load(context.Background(), client, path, bundle.LoadOptions{
+		Repository:      dst.Host() + "/wks",
+		OutputDirectory: out,
+		PackagesURL:     "http://localhost:8080",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result.Images, 2)
+	assert.Equal(t, dst.Host()+"/wks/flux:1.13.3", result.Images[0].Destination)
+	assert.True(t, dst.HasManifest("wks/flux", "1.13.3"))
+	assert.True(t, dst.HasManifest("wks/flux", flux))
+	assert.True(t, dst.HasManifest("wks/weave-kube", "2.7.0"))
+
+	data, err := ioutil.ReadFile(filepath.Join(out, "manifests", "flux", "flux.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: Deployment\n", string(data))
+	_, err = os.Stat(filepath.Join(out, "packages", "kubelet-1.17.13-0.x86_64.rpm"))
+	assert.NoError(t, err)
+	data, err = ioutil.ReadFile(filepath.Join(out, "local.repo"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "baseurl=http://localhost:8080\n")
+	data, err = ioutil.ReadFile(filepath.Join(out, "repo-config.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "name: repo\n")
+	assert.Contains(t, string(data), "local.repo: |")
+
+	// Loading again only finds up to date images.
+	result, err = bundle.Load(context.Background(), client, path, bundle.LoadOptions{
+		Repository:      dst.Host() + "/wks",
+		OutputDirectory: out,
+		PackagesURL:     "http://localhost:8080",
+	})
+	assert.NoError(t, err)
+	for _, r := range result.Images {
+		assert.True(t, r.UpToDate)
+	}
+}
+
+func TestCreateMixedPackages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wksctl-bundle-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeFile(t, filepath.Join(dir, "packages", "kubelet.rpm"), "rpm")
+	writeFile(t, filepath.Join(dir, "packages", "kubelet.deb"), "deb")
+
+	_, err = bundle.Create(context.Background(), &registry.Client{}, filepath.Join(dir, "bundle.tar.gz"), bundle.Contents{
+		PackagesDirectory: filepath.Join(dir, "packages"),
+	}, 1)
+	assert.Error(t, err)
+}
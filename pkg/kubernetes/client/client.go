@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Client gives access to any kind of object stored in a Kubernetes API server.
+type Client struct {
+	Dynamic dynamic.Interface
+	Mapper  meta.RESTMapper
+}
+
+// NewFromKubeconfig creates a Client talking to the API server described by
+// the provided kubeconfig file.
+func NewFromKubeconfig(kubeconfig string) (*Client, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load kubeconfig %s", kubeconfig)
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create discovery client")
+	}
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create dynamic client")
+	}
+	cached := memory.NewMemCacheClient(dc)
+	mapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cached), cached)
+
+	return &Client{
+		Dynamic: dyn,
+		Mapper:  mapper,
+	}, nil
+}
+
+// ResourceFor resolves a user provided type, eg. "pods", "deployment" or
+// "deployments.apps", into a fully qualified resource.
+func (c *Client) ResourceFor(kind string) (schema.GroupVersionResource, error) {
+	fullySpecified, groupResource := schema.ParseResourceArg(kind)
+	if fullySpecified != nil {
+		if gvr, err := c.Mapper.ResourceFor(*fullySpecified); err == nil {
+			return gvr, nil
+		}
+	}
+	gvr, err := c.Mapper.ResourceFor(groupResource.WithVersion(""))
+	if err != nil {
+		return schema.GroupVersionResource{}, errors.Wrapf(err, "unknown object type %q", kind)
+	}
+	return gvr, nil
+}
+
+// List lists the objects of the given type, namespace and label selector.
+func (c *Client) List(ctx context.Context, kind, namespace, selector string) ([]unstructured.Unstructured, error) {
+	gvr, err := c.ResourceFor(kind)
+	if err != nil {
+		return nil, err
+	}
+	var ri dynamic.ResourceInterface = c.Dynamic.Resource(gvr)
+	if namespace != "" {
+		ri = c.Dynamic.Resource(gvr).Namespace(namespace)
+	}
+	list, err := ri.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %s", kind)
+	}
+	return list.Items, nil
+}
+
+// ObjectName returns a human readable identifier for o.
+func ObjectName(o *unstructured.Unstructured) string {
+	if o.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", o.GetKind(), o.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", o.GetKind(), o.GetNamespace(), o.GetName())
+}
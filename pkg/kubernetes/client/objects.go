@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/weaveworks/libgitops/pkg/serializer"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// ReadObjects decodes the Kubernetes objects contained in r. r can hold JSON
+// or (multi-document) YAML and List objects are flattened into their items.
+func ReadObjects(r io.Reader) ([]unstructured.Unstructured, error) {
+	frames, err := serializer.ReadFrameList(serializer.NewYAMLFrameReader(ioutil.NopCloser(r)))
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []unstructured.Unstructured
+	for _, frame := range frames {
+		data, err := yaml.YAMLToJSON(frame)
+		if err != nil {
+			return nil, err
+		}
+		if string(data) == "null" {
+			continue
+		}
+		o := unstructured.Unstructured{}
+		if err := o.UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+		if !o.IsList() {
+			objects = append(objects, o)
+			continue
+		}
+		if err := o.EachListItem(func(item runtime.Object) error {
+			objects = append(objects, *item.(*unstructured.Unstructured))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// ReadObjectsFromFile decodes the Kubernetes objects contained in the given
+// manifest file.
+func ReadObjectsFromFile(filename string) ([]unstructured.Unstructured, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	objects, err := ReadObjects(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", filename)
+	}
+	return objects, nil
+}
+
+// resourceInterface returns the dynamic client interface for objects of the
+// given type, in the given namespace for namespaced types.
+func (c *Client) resourceInterface(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	mapping, err := c.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unknown object type %s", gvk)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return c.Dynamic.Resource(mapping.Resource), nil
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return c.Dynamic.Resource(mapping.Resource).Namespace(namespace), nil
+}
+
+// Get retrieves the live version of o from the API server. Only the type,
+// namespace and name of o are used.
+func (c *Client) Get(ctx context.Context, o *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ri, err := c.resourceInterface(o.GroupVersionKind(), o.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return ri.Get(ctx, o.GetName(), metav1.GetOptions{})
+}
+
+// ListKind lists the objects of the given type in namespace matching selector.
+func (c *Client) ListKind(ctx context.Context, gvk schema.GroupVersionKind, namespace, selector string) ([]unstructured.Unstructured, error) {
+	ri, err := c.resourceInterface(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	list, err := ri.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %s", strings.ToLower(gvk.Kind))
+	}
+	return list.Items, nil
+}
+
+// Delete deletes o from the API server. Only the type, namespace and name of
+// o are used. Deleting an object which doesn't exist isn't an error.
+func (c *Client) Delete(ctx context.Context, o *unstructured.Unstructured) error {
+	ri, err := c.resourceInterface(o.GroupVersionKind(), o.GetNamespace())
+	if err != nil {
+		return err
+	}
+	propagation := metav1.DeletePropagationBackground
+	err = ri.Delete(ctx, o.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete %s", ObjectName(o))
+	}
+	return nil
+}
+
+// Annotate sets an annotation on the live version of o. Only the type,
+// namespace and name of o are used.
+func (c *Client) Annotate(ctx context.Context, o *unstructured.Unstructured, key, value string) error {
+	ri, err := c.resourceInterface(o.GroupVersionKind(), o.GetNamespace())
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := ri.Patch(ctx, o.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to annotate %s", ObjectName(o))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultWaitTimeout is how long Wait waits when no timeout is specified,
+// matching the kubectl wait default.
+const DefaultWaitTimeout = 30 * time.Second
+
+var pollInterval = 2 * time.Second
+
+// WaitArgs describes the objects to wait for and the condition they need to
+// meet.
+type WaitArgs struct {
+	// Kind is the object type to wait for, eg. "pods".
+	Kind string
+	// Namespace in which to search for the objects.
+	Namespace string
+	// Selector, if not empty, restricts the objects to wait for.
+	Selector string
+	// Condition uses the "kubectl wait --for" syntax: "delete" or
+	// "condition=<type>[=<status>]".
+	Condition string
+	// Timeout defaults to DefaultWaitTimeout.
+	Timeout time.Duration
+}
+
+type condition struct {
+	delete bool
+	kind   string
+	status string
+}
+
+func parseCondition(s string) (condition, error) {
+	if strings.ToLower(s) == "delete" {
+		return condition{delete: true}, nil
+	}
+	if !strings.HasPrefix(s, "condition=") {
+		return condition{}, fmt.Errorf("unsupported condition %q, expected \"delete\" or \"condition=<type>[=<status>]\"", s)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(s, "condition="), "=", 2)
+	c := condition{kind: parts[0], status: "True"}
+	if len(parts) == 2 {
+		c.status = parts[1]
+	}
+	if c.kind == "" {
+		return condition{}, fmt.Errorf("missing condition type in %q", s)
+	}
+	return c, nil
+}
+
+// ValidateCondition checks condition uses the "kubectl wait --for" syntax
+// supported by Wait.
+func ValidateCondition(condition string) error {
+	_, err := parseCondition(condition)
+	return err
+}
+
+// met returns whether the object has the condition with the expected status.
+func (c condition) met(o *unstructured.Unstructured) bool {
+	conditions, found, err := unstructured.NestedSlice(o.Object, "status", "conditions")
+	if err != nil || !found {
+		return false
+	}
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		kind, _ := cond["type"].(string)
+		status, _ := cond["status"].(string)
+		if strings.EqualFold(kind, c.kind) {
+			return strings.EqualFold(status, c.status)
+		}
+	}
+	return false
+}
+
+// Wait blocks until the objects described by args meet the wanted condition.
+// Like the KubectlWait plan resource, objects are assumed to eventually exist:
+// Wait keeps polling until they show up or the timeout expires.
+func (c *Client) Wait(ctx context.Context, args WaitArgs) error {
+	cond, err := parseCondition(args.Condition)
+	if err != nil {
+		return err
+	}
+	timeout := args.Timeout
+	if timeout == 0 {
+		timeout = DefaultWaitTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var pending []string
+	err = wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		objects, err := c.List(ctx, args.Kind, args.Namespace, args.Selector)
+		if err != nil {
+			log.Debugf("waiting for %s: %v", args.Kind, err)
+			return false, nil
+		}
+		if cond.delete {
+			pending = pending[:0]
+			for i := range objects {
+				pending = append(pending, ObjectName(&objects[i]))
+			}
+			return len(objects) == 0, nil
+		}
+		if len(objects) == 0 {
+			pending = []string{fmt.Sprintf("no %s found", args.Kind)}
+			return false, nil
+		}
+		pending = pending[:0]
+		for i := range objects {
+			if !cond.met(&objects[i]) {
+				pending = append(pending, ObjectName(&objects[i]))
+			}
+		}
+		return len(pending) == 0, nil
+	}, ctx.Done())
+	if err != nil {
+		return errors.Errorf("timed out after %v waiting for %s: %s", timeout, args.Condition, strings.Join(pending, ", "))
+	}
+	return nil
+}
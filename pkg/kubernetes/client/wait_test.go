@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+var podGVK = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+func pod(name string, labels map[string]string, ready string) *unstructured.Unstructured {
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(podGVK)
+	o.SetNamespace("kube-system")
+	o.SetName(name)
+	o.SetLabels(labels)
+	_ = unstructured.SetNestedSlice(o.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": ready},
+	}, "status", "conditions")
+	return o
+}
+
+func newTestClient(objects ...runtime.Object) *Client {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(podGVK, meta.RESTScopeNamespace)
+	return &Client{
+		Dynamic: fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			{Version: "v1", Resource: "pods"}: "PodList",
+		}, objects...),
+		Mapper: mapper,
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		input    string
+		valid    bool
+		expected condition
+	}{
+		{"delete", true, condition{delete: true}},
+		{"condition=Ready", true, condition{kind: "Ready", status: "True"}},
+		{"condition=Ready=false", true, condition{kind: "Ready", status: "false"}},
+		{"condition=", false, condition{}},
+		{"jsonpath={.status}", false, condition{}},
+	}
+
+	for _, test := range tests {
+		c, err := parseCondition(test.input)
+		if !test.valid {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, c)
+	}
+}
+
+func TestWait(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+
+	c := newTestClient(
+		pod("weave-net-1", map[string]string{"name": "weave-net"}, "True"),
+		pod("weave-net-2", map[string]string{"name": "weave-net"}, "True"),
+		pod("flux", map[string]string{"name": "flux"}, "False"),
+	)
+
+	err := c.Wait(context.Background(), WaitArgs{
+		Kind:      "pods",
+		Namespace: "kube-system",
+		Selector:  "name=weave-net",
+		Condition: "condition=Ready",
+	})
+	assert.NoError(t, err)
+
+	err = c.Wait(context.Background(), WaitArgs{
+		Kind:      "pod",
+		Namespace: "kube-system",
+		Selector:  "name=flux",
+		Condition: "condition=Ready",
+		Timeout:   50 * time.Millisecond,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Pod/kube-system/flux")
+
+	err = c.Wait(context.Background(), WaitArgs{
+		Kind:      "pods",
+		Namespace: "kube-system",
+		Selector:  "name=memcached",
+		Condition: "delete",
+	})
+	assert.NoError(t, err)
+}
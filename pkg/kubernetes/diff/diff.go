@@ -0,0 +1,282 @@
+// Package diff computes field-level differences between the desired state of
+// Kubernetes objects and their live version in a cluster.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Operation is the kind of change made to a field.
+type Operation string
+
+const (
+	// Added fields are only present in the desired object.
+	Added Operation = "+"
+	// Removed fields are only present in the live object.
+	Removed Operation = "-"
+	// Changed fields are present in both objects with a different value.
+	Changed Operation = "~"
+)
+
+// Change is a difference between a desired and a live object.
+type Change struct {
+	// Path of the field, eg. "spec.template.spec.containers[name=flux].image".
+	Path string
+	Op   Operation
+	Old  interface{}
+	New  interface{}
+}
+
+func (c Change) String() string {
+	switch c.Op {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", c.Path, format(c.New))
+	case Removed:
+		return fmt.Sprintf("- %s: %s", c.Path, format(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, format(c.Old), format(c.New))
+	}
+}
+
+func format(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// serverManagedFields are set by the API server and never part of the
+// desired state.
+var serverManagedFields = [][]string{
+	{"status"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+	{"metadata", "managedFields"},
+	{"metadata", "selfLink"},
+}
+
+// serverManagedAnnotations are added by Kubernetes components.
+var serverManagedAnnotations = []string{
+	lastAppliedAnnotation,
+	"deployment.kubernetes.io/revision",
+}
+
+// Strip returns a copy of o without the fields managed by the API server.
+func Strip(o *unstructured.Unstructured) *unstructured.Unstructured {
+	stripped := o.DeepCopy()
+	for _, field := range serverManagedFields {
+		unstructured.RemoveNestedField(stripped.Object, field...)
+	}
+	annotations := stripped.GetAnnotations()
+	for _, annotation := range serverManagedAnnotations {
+		delete(annotations, annotation)
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(stripped.Object, "metadata", "annotations")
+	} else {
+		stripped.SetAnnotations(annotations)
+	}
+	return stripped
+}
+
+// normalize round-trips v through JSON so numbers compare equal regardless of
+// how they were decoded.
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// Objects returns the changes needed to go from live to desired, ordered by
+// path.
+//
+// The API server defaults a lot of fields: map keys only present in the live
+// object are reported as removed only when they were part of the last
+// configuration applied with kubectl.
+func Objects(desired, live *unstructured.Unstructured) []Change {
+	var applied interface{}
+	if data, ok := live.GetAnnotations()[lastAppliedAnnotation]; ok {
+		if err := json.Unmarshal([]byte(data), &applied); err != nil {
+			applied = nil
+		}
+		applied = normalize(stripValue(applied))
+	}
+
+	var changes []Change
+	walk("", normalize(Strip(desired).Object), normalize(Strip(live).Object), applied, &changes)
+	return changes
+}
+
+// stripValue removes the server managed fields from a decoded object.
+func stripValue(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	return Strip(&unstructured.Unstructured{Object: m}).Object
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func walk(path string, desired, live, applied interface{}, changes *[]Change) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		if l, ok := live.(map[string]interface{}); ok {
+			walkMap(path, d, l, applied, changes)
+			return
+		}
+	case []interface{}:
+		if l, ok := live.([]interface{}); ok {
+			walkList(path, d, l, applied, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(desired, live) {
+		*changes = append(*changes, Change{Path: path, Op: Changed, Old: live, New: desired})
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func walkMap(path string, desired, live map[string]interface{}, applied interface{}, changes *[]Change) {
+	a, _ := applied.(map[string]interface{})
+
+	keys := sortedKeys(desired)
+	for k := range live {
+		if _, ok := desired[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		d, inDesired := desired[k]
+		l, inLive := live[k]
+		switch {
+		case inDesired && inLive:
+			walk(join(path, k), d, l, a[k], changes)
+		case inDesired:
+			*changes = append(*changes, Change{Path: join(path, k), Op: Added, New: d})
+		default:
+			if _, wasApplied := a[k]; wasApplied {
+				*changes = append(*changes, Change{Path: join(path, k), Op: Removed, Old: l})
+			}
+		}
+	}
+}
+
+// names returns the "name" field of the list items when all items have a
+// unique one.
+func names(list []interface{}) ([]string, bool) {
+	seen := make(map[string]bool)
+	var names []string
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := m["name"].(string)
+		if !ok || seen[name] {
+			return nil, false
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, true
+}
+
+func byName(list []interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, item := range list {
+		if o, ok := item.(map[string]interface{}); ok {
+			if name, ok := o["name"].(string); ok {
+				m[name] = item
+			}
+		}
+	}
+	return m
+}
+
+func walkList(path string, desired, live []interface{}, applied interface{}, changes *[]Change) {
+	a, _ := applied.([]interface{})
+
+	// Lists of named items, eg. containers, are matched by name.
+	desiredNames, ok1 := names(desired)
+	liveNames, ok2 := names(live)
+	if ok1 && ok2 && len(desired) > 0 && len(live) > 0 {
+		liveItems, appliedItems := byName(live), byName(a)
+		for i, name := range desiredNames {
+			itemPath := fmt.Sprintf("%s[name=%s]", path, name)
+			if l, ok := liveItems[name]; ok {
+				walk(itemPath, desired[i], l, appliedItems[name], changes)
+				continue
+			}
+			*changes = append(*changes, Change{Path: itemPath, Op: Added, New: desired[i]})
+		}
+		desiredItems := byName(desired)
+		for i, name := range liveNames {
+			if _, ok := desiredItems[name]; !ok {
+				*changes = append(*changes, Change{Path: fmt.Sprintf("%s[name=%s]", path, name), Op: Removed, Old: live[i]})
+			}
+		}
+		return
+	}
+
+	for i := 0; i < len(desired) || i < len(live); i++ {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i < len(desired) && i < len(live):
+			var appliedItem interface{}
+			if i < len(a) {
+				appliedItem = a[i]
+			}
+			walk(itemPath, desired[i], live[i], appliedItem, changes)
+		case i < len(desired):
+			*changes = append(*changes, Change{Path: itemPath, Op: Added, New: desired[i]})
+		default:
+			*changes = append(*changes, Change{Path: itemPath, Op: Removed, Old: live[i]})
+		}
+	}
+}
+
+// String formats changes one per line, indented with prefix.
+func String(changes []Change, prefix string) string {
+	var b strings.Builder
+	for _, c := range changes {
+		b.WriteString(prefix)
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func deployment(image string, replicas int64, extra map[string]interface{}) *unstructured.Unstructured {
+	o := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "flux",
+			"namespace": "flux",
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "flux", "image": image},
+					},
+				},
+			},
+		},
+	}}
+	for k, v := range extra {
+		o.Object[k] = v
+	}
+	return o
+}
+
+func TestStrip(t *testing.T) {
+	live := deployment("flux:1", 1, map[string]interface{}{"status": map[string]interface{}{"replicas": int64(1)}})
+	live.SetUID("1234")
+	live.SetResourceVersion("42")
+	live.SetAnnotations(map[string]string{lastAppliedAnnotation: "{}"})
+
+	assert.Equal(t, deployment("flux:1", 1, nil), Strip(live))
+}
+
+func TestObjects(t *testing.T) {
+	desired := deployment("flux:2", 1, nil)
+
+	// Defaulted fields are ignored.
+	live := deployment("flux:1", 1, nil)
+	_ = unstructured.SetNestedField(live.Object, "RollingUpdate", "spec", "strategy", "type")
+	changes := Objects(desired, live)
+	assert.Equal(t, []Change{
+		{Path: "spec.template.spec.containers[name=flux].image", Op: Changed, Old: "flux:1", New: "flux:2"},
+	}, changes)
+
+	// Unless they were applied.
+	live.SetAnnotations(map[string]string{
+		lastAppliedAnnotation: `{"spec":{"replicas":1,"strategy":{"type":"RollingUpdate"}}}`,
+	})
+	changes = Objects(desired, live)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, Change{Path: "spec.strategy", Op: Removed, Old: map[string]interface{}{"type": "RollingUpdate"}}, changes[0])
+
+	// Numbers decoded differently compare equal, added containers are
+	// reported by name.
+	desired = deployment("flux:1", 1, nil)
+	containers, _, _ := unstructured.NestedSlice(desired.Object, "spec", "template", "spec", "containers")
+	containers = append(containers, map[string]interface{}{"name": "memcached", "image": "memcached"})
+	_ = unstructured.SetNestedSlice(desired.Object, containers, "spec", "template", "spec", "containers")
+	desired.Object["spec"].(map[string]interface{})["replicas"] = float64(1)
+	changes = Objects(desired, deployment("flux:1", 1, nil))
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, "+ spec.template.spec.containers[name=memcached]: {\"image\":\"memcached\",\"name\":\"memcached\"}", changes[0].String())
+}
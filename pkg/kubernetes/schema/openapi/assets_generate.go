@@ -0,0 +1,21 @@
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	"github.com/shurcooL/vfsgen"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema/openapi"
+)
+
+func main() {
+	err := vfsgen.Generate(openapi.Specs, vfsgen.Options{
+		PackageName:  "openapi",
+		BuildTags:    "!dev",
+		VariableName: "Specs",
+	})
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
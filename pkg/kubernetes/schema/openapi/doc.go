@@ -0,0 +1,10 @@
+//go:generate go run -tags=dev assets_generate.go
+
+// Package openapi contains the OpenAPI definitions of the Kubernetes API, one
+// file per Kubernetes minor version, eg. "v1.20.json".
+//
+// Definitions are extracted from the swagger.json of the Kubernetes
+// repository with:
+//
+//	go run strip_swagger.go < kubernetes/api/openapi-spec/swagger.json > specs/v1.20.json
+package openapi
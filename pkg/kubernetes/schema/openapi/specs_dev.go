@@ -0,0 +1,12 @@
+// +build dev
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/utilities/fixeddate"
+)
+
+// Specs contains the OpenAPI definitions of the Kubernetes API.
+var Specs http.FileSystem = fixeddate.Dir("specs")
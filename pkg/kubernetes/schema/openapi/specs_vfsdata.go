@@ -0,0 +1,186 @@
+// Code generated by vfsgen; DO NOT EDIT.
+
+// +build !dev
+
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	pathpkg "path"
+	"time"
+)
+
+// Specs statically implements the virtual filesystem provided to vfsgen.
+var Specs = func() http.FileSystem {
+	fs := vfsgen۰FS{
+		"/": &vfsgen۰DirInfo{
+			name:    "/",
+			modTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"/v1.20.json": &vfsgen۰CompressedFileInfo{
+			name:             "v1.20.json",
+			modTime:          time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			uncompressedSize: 252617,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x7d\x49\x73\xe3\x38\x93\xe8\xfd\xfd\x0c\xbd\x39\xaa\x3c\xf1\x79\x26\x26\x5e\xf4\xcd\x65\xbb\xaa\xfd\x75\x2d\x6a\xcb\x55\x75\x98\xf0\x01\x22\xd3\x12\x3e\x93\x00\x1b\x04\x65\xab\xbf\xf0\x7f\x7f\x01\xee\x0b\x40\x02\xe0\xa2\xc5\xba\x74\x74\x59\x44\x22\x37\x24\x12\x99\x89\xc4\xbf\x67\x2e\x3c\x61\x82\x39\xa6\x24\x9c\xfd\xf6\xef\x19\xa6\x17\xcf\xff\x2f\xbc\x40\x01\xbe\x40\xae\x8f\xc3\x10\x53\xc2\x60\x8d\x43\xce\x90\xf8\xe8\x62\xfb\x8f\x8b\xaf\x11\x47\x1c\x93\xf5\x2f\x58\x6d\x28\x7d\x16\xc3\x02\x46\x03\x60\x1c\x43\x0c\x24\x1f\x79\x0f\x5b\x0c\x2f\x3f\x81\x85\x39\x7c\x0e\x7e\xfc\x3f\x7c\x17\xc0\xec\xb7\x59\xc8\x19\x26\xeb\xd9\xdb\x3c\xfb\x03\x62\x0c\xed\xc4\xbf\x1d\x0f\x03\xe1\xd7\x94\x3c\xe1\xb5\x18\xf0\x1f\x0c\x9e\x66\xbf\xcd\xfe\xef\x7f\x96\x30\xfe\x4f\x1d\x74\x53\x34\xaf\xcb\xf0\xde\xe6\xb3\x27\x84\xbd\x88\xc1\x82\x7a\xd8\xd9\x49\x31\xf2\x11\x77\x36\x2d\xbf\x13\xe4\x83\xf2\x87\x30\x40\x0e\x2c\xc1\x03\x87\x53\xa6\x81\xbf\x8f\x9c\x0d\x26\xc0\x76\x17\xc1\xf3\x5a\xfc\x21\xbc\xf0\x81\x23\x41\xc0\x17\xb4\x02\x2f\x07\xf5\x36\x9f\xd1\xd5\xbf\xc0\xe1\x23\x01\x67\x80\xc9\x96\x3a\x31\xfb\x5a\x88\x67\x91\x07\x55\x89\x5a\x0b\xe8\x3e\xf2\xe0\x17\xe6\x9b\xef\x01\x24\x7f\x0c\x65\xfa\x10\x62\x17\x6e\x9f\x9e\xc0\xe1\x0a\xfd\xc1\x3e\xd0\x88\x2f\xc1\xa1\xc4\x8d\x3f\x79\xa2\xcc\x47\x7c\xf6\xdb\x0c\x13\xfe\x5f\x97\xb3\x1c\x24\x26\x1c\xd6\xc0\x66\x6f\x31\xb5\x7f\x45\x98\x81\x3b\xfb\xed\x7f\x13\x79\xd6\x14\xaf\x3a\xef\x5c\xa9\xda\x8f\x39\xf4\x44\x38\x02\x23\x8b\xb5\x94\xcc\x1a\x25\xbf\x4b\x16\x56\x80\xd3\x19\xa5\x2c\x78\xc6\xc4\x95\x6b\x32\x70\xe4\x22\x8e\xfa\xa9\xca\xf7\x98\xb2\xaf\xc0\x91\x80\xf9\x92\x60\x3c\x90\x0e\xd4\x6d\x4a\x5d\x01\xe6\xb3\xd7\x0f\xcf\xd1\x0a\x18\x01\x0e\xe1\x87\x40\x2c\xcd\x0f\x3e\xb0\x35\x7c\x78\x86\xdd\xec\xb7\x4c\x78\x92\xaf\xe2\x69\x60\x2d\x3e\x8a\x07\xcc\xde\x0a\xe0\xa9\xb0\x6a\xe3\xd6\x8c\x46\xc1\x87\x6d\xc2\xe9\x0f\x09\x57\xff\xf7\xdf\xb3\xf8\xcf\x02\x23\x29\x11\x82\x44\x4c\x67\x99\x14\x66\xad\x92\x9d\xcf\xb6\x99\x1c\x67\xdb\x7f\xcc\xde\x1e\x07\x50\x97\x2f\x38\xe4\xc6\x2a\x93\x4b\x6e\x70\x11\x56\x09\x96\x2c\xe8\x91\xb5\x55\xb0\x23\xd1\xd5\xda\x32\x4f\x28\x7d\xdc\xb7\x0e\xc4\xe2\xb2\xd5\x03\x89\xc9\x94\x49\xfe\xb3\xc0\xd6\x70\xdf\x2d\x14\xc6\x70\x20\xad\xe0\xa2\x3f\x8e\x41\x48\x23\xe6\x80\xe1\xb0\xd0\xa1\x81\x6c\xfb\x7d\xb3\xb4\xc4\x4b\x60\x5b\xec\xc0\x3d\x3c\x01\x03\xe2\x40\x93\xa1\xdd\xfb\xbd\xf4\xd7\x00\xf1\x8d\xfc\x07\xca\xb8\xed\x3e\x95\x4c\x97\x4c\x6d\xbd\xf9\xfc\x44\x1e\x76\xcf\xae\xdc\xe9\xba\x72\x67\x1f\x6d\x08\x1f\xad\xb1\x4c\xde\xad\x97\xd6\x34\x18\xc7\xef\xa7\x75\x48\xd7\x76\x87\x6e\x07\xbb\x6f\x5f\xad\x83\xe8\xf7\xe9\xad\x69\x88\xcc\x56\x1b\x64\x1b\x57\x43\xfc\x0e\xfa\x18\x11\xd7\x83\x8a\x5d\x5c\xed\x38\xcc\xe6\x4d\xb6\x87\x89\xbb\xd2\x4b\x0b\x1a\x2e\xcf\xdb\x7c\x16\x31\x6f\x38\xa7\x6a\x05\x1c\x1d\x5b\xbc\x28\xc1\xf9\xec\x69\x1c\x76\xd0\x28\x91\xd2\x21\x7b\x25\x8f\x03\x2e\x99\xf7\xea\x70\xc8\xed\xc7\xbb\x8a\x0d\xc5\x2c\xd0\xde\x68\xba\xb5\x67\xcf\x9e\x47\x37\x82\xe7\x50\x91\x8e\xeb\x61\xa3\x16\xe7\xa0\xd1\xd0\x41\xa3\x84\xaf\xef\x2f\x72\x94\xd0\x7d\x6c\xe1\xa3\xb3\x67\xb7\xd7\x18\xd2\x89\xbb\x6c\xe7\x28\x51\x87\x71\x78\x67\xa1\x22\x9b\x1d\xfa\xa0\x83\x46\x5a\x6a\x7e\x8e\x1c\x8d\xe6\xbe\x1d\x64\x0c\x49\xe1\x01\x59\x07\x92\x02\x2c\x90\x02\x86\x09\x07\x46\x90\x77\xb1\xfd\x07\xf2\x82\x4d\x3a\x07\xb0\x25\xa7\x0c\xad\xa1\xa4\xf1\x8d\xd5\x90\x7c\x78\x77\x23\xd5\x39\x17\x1c\xea\xa2\x95\x07\x16\xae\x48\x4d\x73\x3c\x1c\xf2\x0f\xd9\x08\x88\x29\x01\xe2\x50\x17\x93\xb5\x7a\x41\xf6\xa0\xbf\x9b\xf2\x83\xd9\x4f\xc2\x00\x1c\x2d\xad\xd2\x25\x77\x29\x00\x0a\xc0\x1c\xf1\x28\x1c\x16\x74\x02\xb2\x6e\x3d\x62\x12\xf2\x09\x7b\x5a\x91\x1c\x87\x1c\xab\x86\x09\xa9\x89\xb7\x6a\x2e\x12\xc4\x9b\xf6\x42\x97\xc6\x6b\x4a\x5c\x2c\x77\x43\x3c\x14\xf2\x07\x86\x48\x18\xff\xfe\x80\x7d\xe8\xa7\x08\x31\x84\x58\xad\xc2\x10\xad\xe5\x0e\x31\x5d\xc5\x68\xbb\x9f\x81\x40\xe1\x1e\x95\x3d\xb8\xff\xf9\x6f\x89\x07\x27\x04\x84\x42\x85\x7e\x17\x9a\xa1\x58\xc4\xb2\xa5\x58\x16\x78\xfc\x6b\x0e\x27\x9f\xeb\x71\xa0\x05\x3b\xd5\xe6\xad\x8b\xcf\xc9\xed\xd4\xc6\x6b\x4c\xb2\x2d\xf7\x5d\x67\xcb\xd4\xee\x0d\xa2\x31\xcb\x5c\xa3\x6b\x5b\x3c\xf5\x7d\x4a\x6e\x3b\xb7\x9a\xf9\xcc\xc9\x96\xfd\x48\x3a\x54\x98\x15\x9d\xad\xd2\x47\x81\x70\xec\xc3\x7c\xb1\x3d\xb6\x6c\xa8\x3e\x0a\x92\x55\x5d\x9e\x6f\x38\x32\x64\xbe\x84\x29\x0d\x65\x57\xa3\x93\x94\xce\x7d\x3f\x08\xc5\x12\xb9\xa6\x84\x33\xea\x79\xc0\x44\xc0\xc4\x6a\xa3\xb7\x5a\xa7\x2c\x22\xe2\x48\x7d\x71\x8f\x5e\x6e\x5f\x39\x90\x8c\x21\x93\x7a\x0d\xac\x44\x72\xe7\x66\x50\x33\x21\xf9\xd8\xbe\xfe\x7e\x10\x84\x85\xc1\x90\x88\xa3\x2b\x03\xac\x14\xe4\x64\x1b\x80\x52\x93\x4e\xee\x6c\xd6\x21\x2b\xad\x8c\x7d\xca\xae\x1b\x04\x3e\x25\x4b\xe0\xa7\xe2\x58\xd7\xc8\xb2\x71\xa0\xeb\x20\x0a\x47\x79\x38\xa9\x15\x7c\x37\x15\xd4\x81\xf9\xb4\xd3\xb9\xa6\x8f\x7a\x5b\x49\xce\xa8\x89\x0d\x4f\x21\xd1\xd3\xb6\x37\x55\xfe\x9a\x6a\x6f\xe6\x29\x56\x85\xe2\x63\x72\x0f\xc8\xdd\x99\xc4\xb5\x8b\x7d\xf3\x77\x1c\x72\xca\x76\x5f\xb0\x8f\xb9\xe6\xd0\x70\x9c\xa4\x01\x07\x3f\xf0\x10\xd7\x8a\x2c\x39\x94\x81\x00\xb2\xa0\xee\x43\x3a\x2c\x33\x56\x51\xe0\x8a\x7f\xe5\xc1\x5e\x0b\x25\xfc\x51\x05\xd1\x38\xe5\x67\x48\x97\x70\x36\x5d\x5f\x6a\x3f\xdd\xf3\x70\xe2\x26\x47\x44\x57\x20\xd6\x2e\xbb\xd2\x3a\x1a\x87\xdd\x53\x6b\xa3\x15\x76\x9f\xcf\x9c\x88\x31\x20\xfc\x5b\xe4\xaf\x80\x2d\x9d\x0d\xb8\x91\x07\xae\x26\xb5\x2e\x84\x42\x12\x76\x83\x49\x3c\xea\x6a\x8b\xb0\x27\x02\x7a\x46\xa3\xbe\xe2\x30\xb4\x9a\x2e\x5e\x9e\x46\x23\x7e\x10\x64\x88\xa2\x75\x78\x24\x59\x2f\x56\xec\xac\xad\x0b\x85\x54\xa5\xec\x53\x8a\xb1\xca\x33\xd3\x65\xf5\xa3\xb1\xf8\xab\xcb\x4b\xf8\x7a\x98\xac\x93\xcf\x4c\xd6\xc8\x7d\x79\xa0\x64\xb7\x32\x8f\xd9\xa6\x98\x43\xe0\xd1\x9d\x0f\xe4\xd4\x7c\xc8\x9c\xae\x1e\x4e\x64\x01\x63\x14\x2f\xb2\x60\xbd\xee\x46\x9c\x8f\xd8\x83\x1f\x29\xe0\x26\xea\xf7\x5e\x7d\xd3\x9c\xfb\x53\x3b\xa7\xf9\xc4\xa7\xee\x9d\x56\x39\x6c\xbc\x2a\x86\xf4\x4f\x03\x14\x85\x50\x66\xec\x8a\x52\x0f\x50\xec\x9a\x04\x8c\xae\x19\x84\xe1\x0d\x20\xd7\xc3\x04\x4c\x3d\xdf\xc0\xc3\x0e\x3a\x5e\x47\x39\xb4\xf1\x6d\x4b\xb6\x34\x1d\xdd\xe5\xad\x31\xe0\x08\x93\x3f\x60\x17\x0e\xe6\x9d\x0f\xe8\x48\xd7\xf7\x86\xa6\x39\xc8\x1c\xa8\x7b\x33\x79\xef\xc5\x03\x97\x6c\x2c\x23\xbb\xe0\x7d\xd2\x69\xee\xee\xde\x74\x0d\x19\x7d\x1e\x11\x5b\xe1\xa5\xce\xac\xd1\xa8\x37\x73\x8d\x1b\xd9\xbd\x94\xec\x37\xb6\xfe\x65\xca\x89\x93\x8b\x51\x16\x74\xd9\xfb\x97\x25\x18\x63\xf8\x97\x05\x78\xdd\x9d\xb4\x18\x71\x8e\x53\xea\xaa\xf5\xc4\xbe\x60\x31\xf1\x89\xfb\x82\x35\x0e\x1b\x6b\xf0\xb0\xb1\x4a\xa3\x4d\xe0\x90\xe3\x93\x2a\x0f\xc8\x58\xef\x87\x77\x7b\x7a\x79\x30\x32\xd3\x35\xb2\x07\xf3\x14\x79\xde\x2e\x96\x92\xe1\x86\x7f\xc8\xce\x4f\x23\x43\x9d\x8e\xd5\xd5\x0f\x79\x98\xaa\xb9\x0e\xd1\x6b\x2d\xc4\x68\xb4\x50\x22\x8e\xbd\x0b\x4c\x78\xc8\xd9\xc5\x1d\xe1\xdf\xd9\xd2\xd0\x2b\x51\xb8\x3b\x32\x3c\x97\x11\x5b\x0f\x88\xe1\xfc\xa0\x68\x17\xab\x18\x9e\x22\x2f\x5e\xd0\x2a\xaf\x32\x40\x8c\x63\x99\x92\xf6\x71\x67\x4b\x33\x9f\x96\x67\x58\x66\xa9\xb5\x6b\x58\x91\xcb\x08\xbe\x61\x99\xfb\x9a\x5b\x6b\x69\xc8\xd9\x3b\xd4\xd6\xed\x89\xdd\xc3\xb2\x5c\x4f\xdb\x3f\xac\xf3\xd8\x5c\x8b\xe5\x1e\x62\x40\xdd\xaf\x88\xa0\x35\x88\x0d\xa1\xad\x2b\xc0\xd1\x47\xf1\x92\xeb\x0f\xdf\x54\x17\x00\xf7\x9e\x0e\x2f\x89\xaa\x9e\x10\x9f\xcf\xb6\xd4\x8b\x7c\xb8\xf6\x10\xf6\xb3\x19\xcd\x56\x4c\x8e\xb2\xd0\x9a\x90\x03\xe1\x3f\x0b\x90\xcd\xb5\xa3\x11\x39\xac\xb2\xd4\xdc\x54\x1c\x52\x4a\x5e\x6a\xec\xa7\x49\xca\x9b\x9e\x1b\xb2\x51\x45\x19\xe6\xa0\xa5\xfb\xa3\xc7\x1a\x63\xd5\x6e\x45\xdf\x32\xa8\xd8\xc7\x8f\x57\xaf\xbd\x91\x62\x8e\x32\x47\xd4\x3e\xf8\x18\xf1\x0d\x10\x8e\x9d\xbc\x69\xd0\x47\x1a\x11\x37\xf1\xeb\x5a\x6e\xbc\xdb\xba\x9d\xca\x4b\xd4\x11\x76\x87\xc0\xfe\x81\x3e\x03\xb9\x87\xbf\x22\x08\x4f\xc5\x59\x6e\xa5\xd1\xc2\x6f\x6e\x87\xd7\x72\x5b\xaa\xaf\x53\x52\x9d\xb8\x7e\x79\xa3\x22\xb9\x2e\x17\xa5\x9b\x27\x4d\xd9\x47\x2e\x16\xba\x6c\xd8\x9c\x60\x55\x59\x0e\x76\x0c\x96\x2e\xa9\xb7\xf9\x0c\x5e\x03\x9c\x18\x59\x45\x90\x4d\xa7\x32\xbe\xa0\xeb\xb1\xcf\x62\x51\x6d\xa9\x05\x8e\xe2\x7c\x11\x72\xe4\x07\xc3\x1c\x53\xb8\x98\x5c\xe3\x54\x11\x7f\x36\x97\xe2\x61\x4b\xb0\x68\x55\x71\xe2\xc6\x41\x90\x38\xa0\x6d\x88\xc1\xed\xdb\x34\xc4\x62\xb3\xb5\x0c\x39\x43\x86\x32\x0c\x2d\xfa\x6b\xad\x94\xca\x48\xb1\x1d\x8a\xa5\xb9\x54\xf5\x19\xc0\x18\x65\xa5\x9f\x4a\x1b\x72\x08\xcc\x4e\x67\x7e\x84\xc0\xee\xc8\x13\xb5\xe0\x45\x3e\x54\x62\x87\x38\x8b\x97\x18\x72\x13\x1f\x1b\x79\x8b\xca\x07\x06\x92\xab\xa3\xb4\xb6\xe8\x88\x24\xf7\x57\x12\xb6\x29\x9c\x1c\x53\x6e\x24\x77\xef\x4f\xdf\x66\x35\xe8\x1c\xc0\x70\x35\x61\x1e\xa0\xf5\x52\x74\x89\xd0\xe3\xcf\xa1\xd9\x31\x15\xc7\x8f\xdb\x98\x25\x54\xd9\x5b\xb4\xda\xf8\x77\x6a\xd6\x28\xc3\x7f\xe7\x36\xfe\x0b\x75\x90\xb7\x8c\xe2\x6f\xaf\x1c\x07\xc2\xf0\xd4\xac\x5b\x85\x5c\x09\xa5\x76\xf6\xad\x13\xea\xb8\x16\xae\x98\xbe\x6e\xe0\x94\x02\xd5\xf0\xd5\x2a\x44\x7d\x13\x7d\xe4\x92\xbe\x7f\x57\x9c\x33\xbc\x8a\xd2\x50\x65\x3d\xc9\xa6\x68\x9b\xb7\x05\xb6\x1a\x40\x41\x4b\x68\x88\x6e\x66\x4d\x04\x48\xf1\xc1\x8f\xfb\x2f\x86\x2b\x4c\x20\x69\x34\xa4\x26\xcf\x64\xfc\xa3\x21\x4d\x3a\x7c\x4d\xa5\x6d\xd5\xfa\x4e\xfa\x6b\xd6\xc4\x51\xfa\x63\x18\xad\x5a\x7f\x57\x08\xb3\xa4\x53\xbd\x05\xdd\x2e\x65\xcb\x56\x99\x19\x51\x22\xb4\x3d\x4d\xd3\xcb\xfd\x68\xd4\x12\xbc\xa7\x77\x64\xc5\xe5\xd4\x9e\x98\x25\x57\xc9\xd4\xd4\x90\xb7\x71\xab\xcd\x9a\x56\xad\x93\x31\x2b\xe5\xfb\x47\x69\x61\xf5\x84\x2f\x03\xfe\x66\xbf\x6c\x84\xd9\x79\x37\xab\xa6\x44\xec\x70\x8b\xa6\x0c\xf4\x00\xd6\x4c\x59\xa0\x3d\x96\x4c\x9d\x55\xd2\x76\xc3\xaa\x5d\xd7\x58\x1f\xcf\x8e\xf8\xe9\x98\xef\x21\x4c\xb7\xae\xd9\x3e\xb4\x93\xeb\x71\x6f\x23\xed\x27\xef\x71\xd6\xb9\x32\x40\xe3\x79\xf4\x45\x15\x63\x71\x81\x60\xd5\x6f\xb0\x45\x5e\x14\xcf\x78\xab\x8c\xc4\x28\x2b\xd0\xea\x69\xad\x14\x89\x47\x3b\x3a\x9b\x3b\x43\x53\x81\x35\xb0\xc5\xc4\xa1\x7e\xe0\x01\x07\x39\xc5\xa4\x7a\x54\x34\xac\x20\xe9\x3a\x78\xb6\x1c\x11\x06\x98\xad\x7d\xaa\x46\x55\x44\x79\x5e\x09\xe1\x15\x5e\x19\x0a\x2d\x09\x92\xbd\xaf\xb8\x50\x42\xf3\x28\x7b\x92\x1a\xf4\x81\x47\x88\xd4\xf1\xf0\x26\x79\x87\x11\x2b\x6a\xe0\x72\x2a\x01\xa3\xb4\x99\xff\x39\x6a\xd4\xc1\x99\x73\xe8\xc8\x56\xb7\xde\x55\xfc\xa8\x95\xe4\x53\xb4\xf8\x7a\x91\x24\x13\x83\xbf\x9f\x98\x52\xdb\x66\x33\xd8\x89\x40\x69\x6c\xed\x6c\xd3\x7b\x0a\x31\xb5\x51\x3c\xf0\xba\x3a\x9a\x60\x93\xe5\xaa\x9a\x32\xec\xa4\x34\x58\x67\x67\xff\xf8\x4d\xff\xb0\x66\xff\x60\xe2\x51\x7b\x0a\x47\xed\x79\x07\x1a\x3b\x30\xd5\xa5\xbf\x27\x15\x9d\xea\xd8\x4f\x0e\x3f\x44\x25\x3f\xef\x8e\x1a\xa7\x92\x9c\xb7\xa6\x0e\x56\xd1\xd0\x41\xe2\xfe\x4d\xdc\x77\x9d\xd1\x30\x4c\x37\xa4\xa9\x6f\xc9\xd4\xc8\x8c\x87\x6b\x3e\xf4\x58\x25\xe2\x77\xc1\x60\x4a\x38\xf2\x16\xd4\xbd\x4a\x7f\x03\x36\x1c\x01\x7b\xde\x7c\x35\x68\xb5\xda\x79\x75\xe0\x0e\x74\x07\xbd\x98\xac\xd8\x59\x55\x62\xeb\xce\xf1\x68\x20\x3e\xd5\xf5\x6b\x3d\x4d\x3c\xb9\x1b\xd9\x06\xf2\xd4\xba\xa4\xad\xaf\xe4\xb2\x4e\x1d\x86\x17\x35\xe3\x5e\x40\x46\x23\xe2\xf9\x1f\x10\x5b\x83\xc1\xb5\x29\x5d\x3b\x2b\x94\x23\x06\x7d\xbd\xf8\xf1\x83\x63\x2f\xdd\x2c\x16\xc0\x1c\x20\x3c\xed\x7d\x60\x7a\xef\xb3\x86\xf1\xbc\xc2\xa6\x41\xcc\xab\xf2\xd6\x72\x72\x2f\xb7\x17\x31\xb6\x77\x82\xd3\xd6\xce\x86\xa3\x3c\x14\xf2\x65\xcc\xaf\xc1\xba\x56\x58\x5e\x3c\x96\xf7\xb5\xce\xc9\x69\x12\x68\x26\xc9\x98\xc8\x93\xdc\x16\x63\xca\x7a\x6f\x82\x09\x94\x11\xb7\xbc\x44\x00\x66\xc6\xb0\x20\xad\x21\x38\x36\x64\xb7\x49\x35\x2b\xfa\xcd\x5b\xed\x63\xd1\xee\x03\x32\x1b\xbd\xbe\x4c\x7c\x6a\xf1\xa2\x0e\x12\x3a\x96\x39\xc7\x5f\x81\x33\xec\x2c\xf3\xec\x4a\xbd\xb9\x42\xfa\xb9\x59\xb2\x27\xb1\xd4\x57\x5b\x60\x68\x0d\x25\x03\xa7\xc9\x8a\xca\xf0\x9f\xc8\x8b\xac\xec\xcd\x45\x76\x24\xb8\xf8\x33\x42\x84\x63\xbe\x53\x3e\x18\x9d\x53\x39\x20\x47\x95\xed\x2a\xda\x38\x9a\xda\x32\x6b\xce\x55\xc7\x0f\xca\x3a\xdd\x13\x4a\xc6\x53\x09\x26\x7d\x39\x7d\xfc\xc7\xb0\x94\x92\xdb\xd7\xe4\xf5\xba\xf6\xc5\xe7\xc7\xbf\x2a\x3b\xd0\x24\x3f\x8f\xf4\x44\xfc\x78\x4b\x30\x83\x3d\xe6\xba\x2e\x71\xae\xbf\x74\xda\x3d\xb8\x71\x58\x94\x02\x1f\x18\xea\xfe\x14\x4a\x2d\x9f\x1a\xad\x16\xe2\x7a\x3f\x81\x8d\x76\x82\x7b\x3a\x76\x1d\xc0\xf7\x1d\xe2\xb8\x54\x26\x10\x74\x49\x38\xb7\xe5\xeb\xbd\xa6\xf6\x11\x2f\xea\x58\xe5\xe7\xa0\xd1\xb0\xab\x64\xb0\xf0\x51\x6c\xe2\xfb\xcb\x3d\xdd\x84\x53\xdb\x56\x17\xf5\xe4\x41\x2a\x0d\x57\x74\xb4\x20\x93\x96\x85\x96\x1d\x38\xac\x5a\xdd\xd9\x18\x56\x89\x84\xd2\xdd\xfd\xeb\xb0\xea\x90\xee\x46\xca\xe9\xce\x11\xb1\xf6\x88\x58\xa5\x03\xa2\x85\x0e\x96\x56\xa5\xfa\x84\x7b\x5f\x2a\xda\xb4\x5a\x64\xad\xb1\x8a\xb8\x97\x56\xe2\xa8\xdb\x4e\x20\x3d\x86\xbd\xcd\x33\x36\x58\x42\xcd\x1d\xbd\x0a\xcc\x80\xba\xd6\x4a\xbf\xa0\x6e\x58\x87\xc7\x7a\x32\x57\xc5\x53\x03\x07\xc3\x5e\x6f\xba\x62\x23\x63\x69\x4e\x6e\x36\x06\xd6\x9c\x1c\xee\x80\x9a\x93\xc3\x1c\x48\x73\x72\x78\x03\x6b\x4e\xcd\x14\x8f\xa5\x39\x92\x55\x25\x79\x5c\x61\x8c\xb3\x78\xc7\xa9\x39\x1c\x33\x00\x33\x8a\x7f\x32\x45\x04\x26\x45\x7f\x5e\x3d\xea\x97\xe7\xed\xab\x03\xca\x07\x36\x4e\x26\x1e\x73\x7c\x9a\xa5\xa5\x04\x7d\xe3\x3d\x8d\xdd\xd0\x34\x70\x1a\x1e\x5b\xc8\xb4\x2d\x7a\x26\x99\xb5\x1f\x4f\xf7\x11\xee\xdc\x87\xc0\x34\x42\x92\x7d\xb9\xaa\x97\x63\x3b\x8d\x2c\x5a\x7f\xf6\x68\x29\xde\xa9\xa5\xc4\x8c\xd9\x76\x39\x69\x0a\xd7\x6e\x97\xb8\x4c\xfd\xfc\x24\xe8\xa1\x62\x47\xbe\x3b\xf4\x48\x0a\x5e\x8e\x92\x7e\xed\x47\x75\x2c\xd8\xc2\x2d\xb6\x51\x91\xde\x4c\x39\x91\x4c\xe9\xa5\x51\xa6\xb4\x9f\xd8\xee\x5c\x20\x1c\x3f\x61\x60\x23\xab\x7f\x8a\x6c\x3e\x47\x7f\xae\xb4\x5b\xce\x41\xb5\x79\x68\x4e\xab\x78\x93\xe1\x6e\xc1\x9c\xdf\x17\x57\xcb\xe4\x4f\xc5\xfb\x34\x55\xc6\x04\xc0\x30\x75\xcd\x1e\x39\x54\x9c\x6e\xe7\xb3\x6d\xb6\xa3\x98\x16\x1f\xc6\x3f\x67\xe3\xe7\x35\xa4\x7a\xd1\x9d\x17\xe0\xd7\xc8\x16\xec\xc0\xd0\x37\x08\x2b\xe1\xb0\x24\x10\x9b\xf8\x6c\x2d\x2f\x04\x85\x1c\xad\xf2\xbd\xfc\x17\x26\x2e\x7d\x31\x91\xc8\x9b\x05\x83\xde\x57\xa2\xf8\x72\xcc\x44\xf1\xe5\x61\x27\x8a\x2f\x35\x52\x60\x4a\x12\x3e\xc2\x06\x6d\x31\x95\x28\x46\xfc\xfb\x0d\x7d\x21\xfd\x57\x4e\xb2\x46\xb3\x84\xd4\x8f\x60\x30\x88\xc3\x2d\x8c\x73\xc2\xbc\x37\x0b\xf7\x96\x30\xbf\x3c\x27\xcc\xf5\x13\xe6\xbd\xac\x85\x3c\x09\xb6\x2a\xd9\x90\x01\xa5\x97\x9b\xa6\xb7\xf9\xde\x72\xf2\x97\x07\x99\x93\xbf\xdc\x63\x4e\xfe\x72\x6f\x39\xf9\xcb\xfd\xe7\xe4\x2f\xcf\x39\xf9\x43\xc8\xc9\x4b\x0e\x57\xfa\x91\xc6\x29\x02\xac\xb6\x11\x80\x09\x4a\x0d\x2e\xc7\x2e\x35\xb8\x1c\xa5\xd4\xe0\x72\xf0\x52\x83\xcb\x11\x4a\x0d\x2e\xf7\x52\x6a\x70\x39\x4d\xa9\xc1\xe5\xd8\xa5\x06\x97\xa3\x94\x1a\x5c\x0e\x5e\x6a\x70\x39\x42\xa9\xc1\xe5\x5e\x4a\x0d\x6a\xd1\x3b\x55\x82\xd9\x3c\x11\x31\x4e\x66\xba\x3b\x2e\x35\x46\x76\xbf\x17\x67\xcb\x21\xc5\x43\x67\xef\xd0\x5c\x34\xe5\x98\x4e\xd9\x8b\x0b\xa1\xc3\xf0\x0a\xd2\xe7\x26\x47\xf1\x9f\xe7\xc7\x15\x63\xaf\xb3\x64\x5e\xaf\x45\xb0\xd1\x5e\x9d\xf2\x93\x31\x62\xee\xc7\x29\xdf\xae\xc8\x7e\x93\x2e\x0b\x91\xe8\xd6\x82\xbc\x9f\xd4\x90\x76\x25\xc7\x7b\x4b\x0b\x0d\x52\x8c\x31\x41\x3e\xbc\x3f\x69\x13\xca\xdd\x2a\xb9\xdd\x41\xe1\x4a\x3c\x81\x2f\xce\x93\xff\xa4\xab\xd3\xc8\xcd\x94\x29\x32\x4f\xbe\x54\x46\x0f\x93\x5d\x89\x41\x16\xd1\x52\xc1\xe8\x8e\x76\x0b\x65\x24\x3a\xf2\x02\x0b\x46\x57\x03\x86\x5f\xde\x75\xaa\xa1\xcc\xf6\x89\x72\x09\xe5\x29\x4f\x2e\x59\xd0\x54\x7c\x9d\xc6\x4b\x8d\xf5\xdb\x94\x82\xc3\xf1\x16\x6e\x00\xb9\x1e\x26\x60\xf2\xb2\xfb\x7c\xb6\x42\xce\x33\x7d\x7a\xfa\x82\x7d\xcc\x75\x6b\xda\x92\x3e\x76\x59\x00\x59\x63\x84\x8f\x48\x84\xbc\x65\xb3\xc7\x48\xa9\x6b\x60\x80\x18\xf2\x3c\xf0\x70\xe8\x5b\xf4\x2c\x19\xb2\x92\x16\xfc\xc0\x43\x5c\x2b\x6c\xe0\x50\x06\x02\xc8\x82\xba\x0f\xe9\xb0\x3c\x31\xc1\xbd\x54\x12\x57\x4f\x1c\xd8\x27\x4c\x70\xb8\x01\x57\x8b\xb6\xfa\xa2\xcd\x30\x32\x58\xaf\xca\x13\x6e\xac\x2b\xc6\x92\x1e\xce\xf2\x59\x66\x1f\xe4\x5b\x40\xdd\x42\xd4\xd6\x63\x20\x06\x7d\xf0\x81\xad\xe1\xc3\x33\xec\x66\xbf\x65\xf6\x4f\xf2\x55\xc8\x19\xe2\xb0\x16\x1f\xc5\x03\x04\xec\x27\x84\x3d\x4d\x91\xc5\x36\x95\xf1\xe1\xf8\x14\x46\x8e\x03\xe0\xea\x6b\x8c\x8e\x66\xe4\x55\xf4\xe4\xe4\xdc\x9b\x0a\x69\xf6\x7e\x4e\x15\xcc\x28\x0e\x4f\xc6\x7e\x9d\x16\xc5\x52\xb4\x26\xde\x8b\x2b\x73\x9f\xfa\xa6\x5c\x66\xb1\xb5\x80\x94\xf9\xa2\xe4\x10\xe2\xec\x5a\x2a\xd3\x12\x9b\xf3\x4f\xba\x0a\x7f\xc7\x21\xa7\x6c\x67\xb2\x35\xff\x8b\xae\x1e\x0c\xb6\xaf\x2a\xf2\xff\x2c\x06\xe7\xeb\xc7\xd9\x80\x9b\x3e\x38\x22\x73\x46\x19\xc7\x64\x6d\xe7\x78\xc4\xf6\x2d\x0c\x9f\x22\xcf\x92\xd6\x30\x0a\x03\x20\xd2\xd6\xcb\x8d\x7c\x7b\x4a\x45\x95\x41\x8f\x16\x36\xb3\x7b\x5b\xd5\x5e\x5e\x99\xef\x20\xbb\x21\x57\x5b\x60\x49\x5e\x39\x21\x62\xa8\x1d\xc6\x68\xc7\xa8\xab\x86\x2c\xd0\x75\x40\x07\x5c\x3d\xda\x2e\x91\x17\x6c\x4e\x74\x3b\xac\xd1\x66\xbd\x1f\xd6\xe1\x4c\xb7\x21\xa6\x33\xab\x0c\x6e\x0d\xb1\x69\xb7\xc4\xda\xe4\xef\x76\x4f\x34\x93\xd1\x91\xed\x8a\x19\xf6\xe7\x6d\x71\x3e\xd3\x35\x0c\xef\x66\x5f\x54\x29\xc7\x71\x6f\x8c\x8e\xc0\xfb\x09\x3b\x88\x43\x28\xc6\x5d\x17\xff\x5e\xe2\x35\x11\x65\xde\xf0\x57\x04\x16\xa6\xf6\x20\xf7\x4a\x6d\x72\xcd\xb7\x4f\x7d\xd0\xe3\x3d\x9a\x53\xc1\xa1\xfe\x66\x8e\x5a\xb4\x1d\xe1\x48\x6d\xca\xf6\x50\xc0\x2f\xe0\xfe\x08\x5c\xc4\xe1\x9d\x45\xea\xb5\x85\x32\x91\x9f\xa4\x8d\xcf\xc9\xb9\x4e\x76\x8b\x4e\x27\x0f\x60\x66\xad\x0e\xfb\xf5\xf4\x1a\x4f\x3d\x1c\xf2\x0f\xd9\x07\x9c\xfa\xd8\x99\xa5\xb2\x81\xb0\xea\xf7\xac\x76\x1c\x66\xf3\x1a\x74\x2d\x68\x21\x5e\x13\x60\xca\x2e\x0f\xea\x37\xa7\xd0\x1a\x06\x27\x2d\x0a\x81\xe9\x65\xaf\x59\x6e\x95\x4b\x04\x0c\x65\x0d\x94\xc9\xfa\x62\xc0\x40\xdc\xb7\x8c\xfa\x5b\x6c\x36\x3a\xf2\xf0\x51\x20\xf2\x01\x61\xb9\x9a\x50\x49\x84\x8f\x02\x53\xc7\x29\x0d\x19\xbd\x2b\xef\xa9\x83\xe6\xde\x2e\x54\x17\xfc\x61\x22\x13\x83\xf8\x4c\xf2\x48\xb1\x19\x3d\x67\xef\xa9\xbf\xf7\xf4\x38\xe4\xa2\xdd\x8f\xe7\xd4\x81\xd4\xd9\x7d\x52\xb9\x4f\x43\xac\xc1\xb3\x23\x75\x82\x8e\xd4\xa0\x46\xe1\xd8\x1c\x28\xed\x4d\x67\x3f\x5e\x14\xa5\xcc\xc5\x24\x7b\x87\xf3\xe2\x0b\xa0\xf0\x44\xde\xc2\x92\x92\xa6\x88\xc9\x19\x5a\xcc\x32\xe4\xba\xc5\x4c\x38\xd8\x75\xb0\x94\xe1\x36\xd5\x76\x27\x15\xf9\xc9\xed\x6a\x9d\x32\xd2\x0a\x00\x28\x75\x48\x12\x76\x8f\x29\xe8\xef\xc7\x7d\xc5\x0e\xa3\x99\x33\xb7\xa1\x9e\x0b\x2c\xa9\x94\xe7\xf2\x3c\x8d\x27\x70\xba\x89\x92\x9b\xca\x66\x3d\x8e\xe2\xa1\x85\x5f\xab\x3b\x8c\x01\x81\x97\x41\x09\x35\xb3\x52\x89\x49\x3d\x5d\x53\x55\xa2\x6f\x3f\xf6\x4a\xe1\xc9\x29\xb1\xdc\x8f\xe5\x2a\xab\xc1\xd9\x7c\x59\x88\xed\x6c\xc8\xa6\x37\x64\x49\xa2\xf5\xea\xd7\xf2\x56\x9c\xfd\xb1\xf3\xd1\xa3\xce\xf3\x92\x53\x06\x3f\xa9\x17\xf9\xa0\xba\xa7\xf4\x14\x3e\xa8\x2e\xfe\x06\x88\x71\x6c\x70\x71\x96\x01\x72\xbf\x13\x6f\x27\x2f\x0a\xdf\xc6\x68\xdc\xdd\x74\xbb\xf7\xf9\x97\x8f\x9a\x44\x3f\xc5\x7c\x95\xf4\xe7\x23\xd4\x85\xf2\xaf\xba\xf9\xea\x6f\xe5\x71\xc9\x75\x76\x1b\x30\x0b\xea\xd6\xa1\x10\x8e\x6d\x21\x95\x87\x6a\xeb\x03\xe7\x48\x24\xd5\x13\x1d\x90\xdd\xf8\xdd\x62\x07\x16\x88\x6f\x7a\x74\xe6\x8c\xbf\x9a\x97\x61\xe9\x0a\xee\xef\x88\xc1\x0d\x0e\x9f\xdb\x55\xd4\x89\x57\xc8\xfa\x2b\x75\xe5\x7a\xea\xe2\xf0\x59\x79\x86\x15\x3f\xfe\xb8\xbf\x93\xfe\xd6\xa2\xfb\x4a\x23\xdf\xa6\xe4\xf5\xbb\xc3\x19\x5e\x05\x16\x26\x8c\xf9\x84\x3d\x58\x00\x0b\x71\xc8\x45\x97\xf3\x56\x16\xb5\x2f\xbd\x10\x1c\x06\xbc\xa5\x2b\x76\xf6\x73\x18\x20\x47\xf1\xcd\x06\x31\xf8\xa6\xa5\x0e\xa5\xd9\xca\xe3\x4c\x49\x1f\x93\xe0\xa9\x88\xf9\x88\x89\x2b\xa0\x1e\xb4\x33\xa9\x7f\x21\x55\x59\xce\x23\x6f\xd7\xdf\xd3\x55\x29\xfc\x92\x8c\x8d\x9d\x87\xaa\x04\xbd\xeb\xe5\x9d\xee\xba\x71\x28\xe1\x8c\x7a\x1e\xb0\xdb\xd7\x00\x11\xd1\x35\x95\x69\xf7\x0e\xcb\xe6\xcb\x07\x15\xd5\x4d\x05\xdc\x45\xb4\xf2\x70\xb8\x19\x0a\xb0\xcb\xf0\x16\x98\xa9\x35\x13\xfb\xe0\xc0\x88\x08\x90\x4b\x8e\xd6\x30\x14\x40\x1d\xef\xe1\x8a\x73\x86\x57\x11\x87\xb0\x2d\x68\xab\x8a\x45\x16\x4b\x35\x81\xf6\x3b\x22\xae\xa7\x61\x03\x52\x9e\xd7\x86\x69\x9a\x80\xeb\xe5\x5d\xbb\x0a\xee\x47\xa2\x5f\xa8\x83\x3c\x49\x59\xde\x74\x52\x90\x33\x59\x97\xab\x28\x88\x9b\xfe\x66\x93\xd5\xac\xab\xeb\x9a\x05\xef\x5d\x46\x03\x93\x11\x9a\xde\xd7\x35\x04\x9b\x4f\x4b\x5d\x5b\xe4\x53\x82\x39\x65\x86\x79\x87\x40\xe5\xbb\xe9\xec\x90\x62\x9f\x6d\x71\x09\xfa\x2f\x6a\x11\xd3\xef\x5e\x60\x39\xe5\x8f\x26\x7c\x3d\x71\x6e\xaa\x56\xe8\x48\x2c\xc5\xc4\x05\xa6\xab\xaa\x2d\x86\x49\x87\x51\xfd\xf5\x6a\xbc\xc3\x64\xc2\x88\x43\x22\x5f\xa5\x08\x23\xf2\xc0\xc3\x40\xf8\xdd\xe2\x9a\x92\x27\x2c\xf1\x5e\x39\xf6\x81\x46\x7c\xc0\x2e\xeb\xf9\xcc\xd4\x0f\x28\x01\xd2\x56\x33\x01\x8c\x51\xa6\xf0\x8e\xd5\x15\x0a\x13\x17\x71\xd6\xe9\x51\x16\xd5\xb7\x1f\x04\x6c\x53\x85\x6a\x76\x8e\x7c\xcd\x7a\xc2\x93\xcb\xdb\x50\xc7\x8b\xba\x90\x74\x8f\x19\xd5\x61\x93\xc5\xaa\xa5\xb3\x9f\x5c\xa0\x5a\x29\x1e\xbd\xf4\x5a\xc6\x24\x61\xc0\xbe\xa2\xc0\x58\x30\x2b\x4c\x10\xdb\xdd\xa4\x4c\x52\xf9\xb8\xed\xc5\x00\x32\x9b\xe7\x76\x40\xec\x86\x80\x7d\x3f\xe2\x68\xe5\x81\x7c\x8b\x39\xce\x05\x98\x89\xc9\x54\xae\xb7\x64\x6b\xdc\x80\x8b\x06\x09\xe3\x15\x11\x3c\x3d\xb3\x9e\xce\xff\x07\xec\xca\xad\x50\xaa\x18\xc4\xf6\xd3\xe4\xa9\xac\x0e\xcc\x2a\xaf\x21\xc1\x6e\xf6\x68\x88\xec\xe4\x16\x2a\x93\xea\x29\xdb\xa6\x32\x6f\x4d\xb5\x57\xa4\x19\x92\x7f\xa8\x94\x58\xe0\xe4\x01\x4f\x3e\xfa\xc3\x54\x9f\x48\x6b\x48\x37\xeb\x2b\xda\x26\x7d\x79\x05\x98\x24\xfe\x9f\x4c\x33\xcf\x72\x01\x0d\xbc\x4d\x75\x75\xc1\xa8\xf8\x4c\xea\x00\xda\xab\xe4\x1f\xb0\x7b\xa0\x71\x86\x42\xa2\x92\x93\x18\x8c\x8e\x58\x10\x3c\xa1\xc8\xe3\x59\xa6\x43\x23\xe7\x76\x9c\xbc\x28\x5e\xce\xab\xd9\x22\xb6\x36\x3c\xb0\x3b\xd4\xf7\x11\x31\x0c\xf7\x00\xd9\x5a\xb1\xec\x96\x6c\x7f\x22\x66\xee\x42\xa7\x8b\x42\xd3\x85\x06\xb2\xfd\xc4\xa8\x6f\x8b\xa1\x18\x5b\x6f\x8d\x5e\x90\x8e\x7d\xd5\x01\x29\xfe\x65\x11\x79\x5e\xcb\xd5\x6d\x0f\x3f\x81\xb3\x73\x3c\xa3\x86\x5a\x5f\xf2\x41\x31\x84\x2d\x10\x08\xc3\xb8\xb7\x9f\x51\x06\x34\x1e\xd0\xa6\x9a\x01\x65\xdc\x7a\xa7\x4a\x34\x72\x41\x19\x37\xae\x97\x74\x2a\xa3\xe7\x42\xa5\x39\x75\xa8\xd7\x59\x43\xd9\xa9\x37\x75\xc8\x9a\x0a\xc4\x00\xb9\xb8\x1f\x93\xb3\xbd\xc1\x88\x8d\x59\xf7\xd0\xfb\x64\x6f\xf0\x81\xf0\x30\x8d\x7a\x44\x0c\xf3\x9d\x60\x33\xbc\x72\xc3\xd0\x4f\x65\x68\x76\x27\x3f\x0a\xec\x69\x0b\xb9\x8b\x89\x22\x3e\x23\x7e\xfa\x4e\x1c\x85\x6f\xcd\x81\xf9\x69\xc1\xcb\xd7\x24\xd4\xa0\xcc\x9c\x4b\x3e\x55\x2f\x2b\xce\x5b\xe3\xed\x37\x71\x62\xdd\x4e\xb5\x7f\x96\x20\x98\x1b\xae\x52\x4a\x5f\x5b\xfb\x12\x9c\xbf\xd2\x88\xf0\x3e\x28\xc7\x00\xcc\x31\xf6\xc5\x30\x33\x84\x5f\x28\x7b\x16\x3d\x1e\x30\xd3\x2c\x75\x78\x34\xdc\xe7\xee\x32\x9b\xdb\x3c\xa0\x18\xee\x76\x21\xfe\x1b\x3e\xee\x38\x84\x36\x0f\xc7\x24\xf3\x99\x22\x1f\x9b\x1e\xf5\xab\x17\xd9\xcf\x1a\xae\xca\x86\x86\xfc\x6e\x21\xa5\x53\xfc\x64\x00\x49\xbd\x05\x64\xa6\xb7\x53\x90\x55\x02\x4c\x99\x22\x22\x11\x12\x89\xb2\x88\x90\x34\xdd\x6f\xbc\xf5\xc4\x20\xef\x53\x00\x25\x03\x02\xae\x3d\xb8\x87\x02\x86\xd0\x73\x84\x79\x2f\xec\x7e\xa5\x00\x8c\x1d\xbd\x0a\x6d\x0d\xae\xc5\x06\x1d\xdc\x2b\x3e\x41\xcb\x8f\x4e\x3e\xa9\x35\x5d\x1a\x68\x9f\xcf\xe0\x15\xf3\x6b\x7d\x7f\xfd\x29\xed\x1d\x3a\x0c\xb5\xf6\x97\xf2\xf0\x3a\x75\xe2\x75\xbb\x61\x0e\x28\xa0\xf2\x3a\xcc\xd9\x67\xb5\x04\x7f\x15\x2a\x5d\x6f\xd5\x62\xc1\x16\x1b\xdd\x89\x42\x73\x95\xe9\xf0\xc1\x15\xa3\xe2\x06\x39\xdc\xb0\x99\x6d\xcd\x62\xb5\xd9\x4e\xe1\x35\x2a\xdc\x10\x06\xb1\x0a\x5c\xc7\x5b\xb2\x91\xce\xa8\xdc\xac\x9e\x64\xc8\x0b\x10\x13\x0a\x6a\xe8\x66\xec\x2e\x98\xab\xa9\x69\x37\x08\x7c\x4a\x6e\x89\x1b\x50\x4c\x24\x5b\xa0\xf6\x7e\x55\x43\xd6\x64\xc3\x11\x2f\xc4\xbe\x20\xe6\x5e\x2d\xee\xc6\x09\x88\x94\x26\x48\xdc\xae\x38\x87\x6d\x5b\xef\x20\x87\xd6\x4c\x9f\x62\xf0\x5c\xc3\x24\x68\x12\x77\xfe\x24\x46\x96\x1b\x3c\xfb\xfa\x96\xb7\x25\xb5\x9f\x1c\x5b\x3e\x59\xa0\x75\x5f\x1e\xab\x7c\xb1\x2e\x30\xa8\x89\x6d\xf0\xf0\x10\x62\x45\xc3\xaa\xc9\xad\x1f\xf0\xdd\x0d\xee\x48\xb2\xfb\xe0\xe2\xc8\x57\xec\x5e\x7f\x43\xde\x47\x6d\x82\xf7\x94\x72\xc4\x53\x63\x70\xe5\xba\x0c\x42\x89\xe5\x17\x7e\xac\xd2\xc0\xe2\x40\x59\xc9\xf5\xad\xfd\xb1\x10\xab\xb5\xa2\x2e\xcf\xc4\xc1\xec\xd1\x8c\x60\xf9\x21\x00\x05\xc1\x42\xed\x70\x77\x44\x6a\x74\x57\xad\xb6\x47\x1f\x18\xd8\xd5\x8c\xae\x65\xb4\x0a\xa5\x4f\xb3\x25\x02\xb6\x3c\x73\xd7\xd5\x44\x16\x5f\xa5\xfc\x5e\xec\x56\x57\x63\x4f\x64\x1f\x14\xab\xc8\xde\x7a\xa9\xa7\x40\xc2\x03\xbf\x4f\x17\xeb\x41\x3f\x3e\xa5\xba\xd4\xcd\x29\xdb\x5c\x53\xc1\x4b\xcd\x3c\x53\x3e\x60\xe2\xc4\x5f\x81\xe8\xe9\x26\xfe\xaa\xbc\xd5\x16\x48\x39\x3a\x2f\x3b\x37\x24\x49\x22\x43\x7b\x2f\xc9\x86\xc7\x96\x13\x9e\xf0\xeb\xd0\xd5\x9d\xa5\x29\xb4\x4d\x40\x9c\x34\xd1\xcf\xcf\xe7\x2f\x25\xca\x7f\xc9\x72\x23\x66\x49\x9b\x12\xd2\xb6\xf1\xbc\x0a\x20\xb5\xf0\xfe\x80\x9d\xad\xfc\xca\xd5\x04\x71\xac\x60\x30\x3f\x79\x04\x1f\x37\x53\x23\x73\x7a\x97\xd9\xb8\x8a\xc3\xac\x25\x82\x60\x03\x3e\x30\xe4\x9d\x73\x88\xe7\x1c\xe2\x31\xe5\x10\xcf\x59\xb9\x11\xb3\x72\xf1\x19\x29\xe7\xbf\xfa\x2c\x75\xce\xde\x9d\xb3\x77\xfa\xbb\x7d\xb6\xd5\xf4\xb9\x87\x9a\x70\xf3\xda\x43\xd8\x7f\xb0\x79\x8a\xac\x76\x1d\xa3\x0a\x48\x7b\xd3\xdc\x02\xe1\xf2\x76\xee\x0a\xd7\xbf\xb3\x28\x5d\x3f\x0e\x0c\x62\xf2\x81\x5b\x3e\x3c\x61\x16\xc6\x30\x43\x8e\xfc\x60\x98\x3c\x0a\x26\x5b\xea\x6d\x4d\x5e\x46\x6e\xe9\x6d\xaf\x3c\xe3\x78\x68\x70\xc4\xdb\x32\x1d\x63\x1c\x95\x5b\x92\x4a\x0c\x3c\xd3\x7c\xa5\xf4\x06\xa2\xd8\x84\x31\x59\xe7\x75\xdf\x8a\xc9\xd2\xcf\xee\x48\xc8\x11\x51\x5d\x5b\x07\x86\xcd\xb6\xcb\x78\xb5\x2c\x93\x61\x02\x40\xbe\xee\xcd\x00\xd4\x9c\xa7\xce\xeb\x5a\x99\xa4\x1a\x9a\x38\xdc\x89\x75\x9b\x3c\x96\xad\x77\x52\x15\x1f\x4f\x1d\x36\x88\x11\x3c\xe1\x90\x41\xce\x53\x13\x21\x2c\x73\x0d\xae\x9f\x37\xf5\x8d\xb0\x30\x3b\xdf\x57\x21\xb0\x2d\xb8\xfb\xe8\x4a\x53\x5e\x12\x12\x42\xda\x96\xb9\x88\xa8\xdb\xa7\x6a\x6f\x5f\xc1\xb9\x52\x24\xcd\x2c\x4e\x7d\x9a\xb3\x7e\xba\xb6\xbe\x3e\xe8\x45\xc3\x34\xdb\x49\x7c\xe2\x5f\xbf\xbe\x19\x1e\x85\x5f\x5e\xb0\x1b\x8e\xc1\x12\x0f\x5e\x75\x2f\x97\xda\x5d\x88\x4f\x0a\x93\x7b\xf6\x05\x98\xe2\xf2\x6a\xaf\x7b\xef\x82\x8f\xef\x89\x7b\xf2\xbb\xaf\x3d\x59\x48\x9d\xe7\xae\x1b\xbe\x62\x57\x09\x5b\x3a\xc8\xa4\xbf\xff\xf8\xa1\xba\x7e\xab\x83\xc9\xe7\xeb\xdb\x62\x4d\x74\xb7\x41\x1a\xb0\x53\x57\xe0\x7e\x6b\x2b\x03\xd1\xec\x6f\x94\x42\xd1\xe4\xfb\x67\xcc\xef\x21\xa0\x1d\x7c\xc7\x2c\x8e\x08\xee\x94\x3e\x5f\x88\x5b\x7e\xde\x62\x85\x5f\x52\xc3\xbc\x04\x48\x17\x7b\x2f\x0a\x39\xb0\xa7\x50\xd7\x8a\x41\x39\xf3\xd5\x40\x35\xff\xb5\xbd\xeb\x92\x55\x3b\x82\x1a\xad\x50\x4a\x1b\x99\x14\x22\xe4\x14\xf7\xa1\x73\x9f\x04\xfc\xfe\xf0\xb0\xf8\x0c\x5c\xb5\xff\x2b\x3c\x8b\xf9\x6c\xc3\x79\xf0\x3b\x20\x17\x98\x9d\x1f\x2b\xe6\x4d\xc6\x1b\x75\x98\xc8\x92\xe2\x46\x8e\x59\xc4\xb1\x77\x21\x78\xc3\xd9\xc5\x1d\xe1\xdf\xd9\x32\x87\x27\x9e\x84\xd3\xe9\x70\x65\x92\x37\x2f\x91\xd6\x3f\x9f\x23\x2f\xe2\x4a\xbe\xd6\xc5\x27\x6e\xc5\xc3\x64\x8d\xdd\x75\x1b\xa4\x36\x1c\xc5\x54\x03\x3e\x9b\xb5\xc4\xaa\x2a\x9b\x90\xbb\x13\x2c\xc5\x46\x63\x04\xe5\xe1\x7a\x91\x0c\xca\xe0\x68\xee\x24\xbf\xd3\x90\x5f\x79\x18\xb5\x94\xa4\x18\x3a\x82\xd2\x62\x15\x03\x6c\x44\x10\xaf\xdd\x70\x28\x17\x82\xde\x89\xd9\xc4\x12\xdc\x2d\x4d\x5a\x82\x6d\x50\x70\x15\xf1\xcd\x0d\x0e\x1d\xba\x05\xa6\x70\x67\xb2\xcf\x96\x10\xd6\xf6\x9c\xd2\x47\x2d\x5b\x76\xac\x06\x88\x53\x75\xbc\x1a\xff\xa5\x38\x61\x87\x4e\x88\xef\x88\xb0\xcd\xc8\xe9\x7b\x8c\x10\xab\x1f\x79\x86\xca\x31\x4d\x8f\x97\xe4\x0c\xb3\x88\x11\xec\xd6\x87\xca\xd7\x09\xf3\x12\x3e\x98\xe8\xc8\x59\x33\x0e\x4f\x33\x54\xed\x6f\xa6\x55\x8f\xe2\xb6\xac\xf6\x35\xff\xfe\xb5\xa9\x92\x3b\xff\x66\x2e\xd0\x97\x72\xae\xb5\x66\x7e\x69\x5c\x4e\xce\xcc\x76\xb9\x74\xbf\x4d\xca\x4b\x96\x9c\x06\x56\xa3\xdf\x74\xb1\xf7\x31\xbf\x47\x64\x7d\x32\xdd\xd8\xeb\x74\x0d\xd2\x85\xbd\xd4\xbf\xbb\x60\x98\x66\x88\xb1\x18\x71\xc7\xc1\x57\x16\x19\xb7\xc5\x05\x86\xa8\xbf\x95\x35\x4a\x49\x66\x2e\x3d\xb3\x36\x31\x02\x3e\x7a\xdd\xcf\xac\x89\x4c\x12\xba\xef\x11\xc7\x74\x1f\x68\x60\xb2\x87\x59\x07\x7c\x0f\xac\xa1\xdf\x13\x27\x33\x8a\x89\x4f\x38\xa3\x51\xe3\xae\xb1\xcd\x91\xbf\x13\xe0\x89\xdf\xfb\x72\x3d\x36\x67\xb2\x68\x75\x99\x1d\xe9\x4c\xba\xba\x44\x91\xfb\x11\x79\x22\xd7\xc8\xee\xc8\x7a\xb8\x1b\x00\xf6\x45\x47\xc2\x9f\x51\xf4\xff\xd2\x78\x89\xea\xcd\x9c\x6e\xd5\x95\x37\x5c\xf0\xc3\x5c\x68\x12\xbe\xda\xe6\x19\xa4\xce\xa2\x6e\xa8\xc2\x64\x8e\x1e\xa1\x5a\x1d\x57\xcf\xc4\xc9\xfb\xd6\xd5\x0a\xd5\xba\xa9\x29\xdb\xea\xb4\x1a\x4d\xbf\x33\xf3\x4c\x2b\x71\xcf\xd3\x71\xed\x72\xb2\x2c\xde\x48\x6d\x80\x18\xe6\x19\xd4\xc2\x5c\x7f\x2b\xf5\x69\xd2\xb2\xd4\xf9\x80\x3d\xbc\x60\x7a\x1c\x6f\xb5\xd7\x19\x35\xb1\x97\x51\x48\xf4\x74\x9d\x8c\x2a\x6f\x4d\x35\x57\xee\x62\x3c\x61\x82\x3c\xfc\x37\xb0\x31\x72\xdd\xf5\x05\x2c\xbb\x2a\xd0\xa7\xa9\xab\x64\x55\x8e\xdc\xd4\x35\xd8\xa4\x2f\xa2\xd9\x6d\x98\xdf\xd2\x40\xc8\x09\x99\x79\xf1\xf8\x81\xbd\x85\xa7\xee\x08\xc6\x5d\x30\x59\x77\x75\x88\xe7\x8d\x54\x77\x58\x51\xf1\x43\x3f\x13\x9a\x01\x7a\xd4\xd7\x12\xf5\x4b\x4e\x01\x83\x27\x60\x0c\xdc\x9b\x48\xcc\xb9\x14\x8f\x1a\x45\x9e\x28\xff\x5b\x13\x9a\xff\x59\xa4\x71\xa2\x6c\x9f\x32\xf7\xa6\xb3\x39\x0a\xe8\xa2\x33\x88\x3c\xce\x99\x90\xab\x8f\x8d\x91\x72\x98\xde\x8b\x49\x1b\x49\xb6\xed\xd1\xbf\x03\x62\x7c\x05\x88\x0f\xfb\xc8\xf8\x79\xeb\xef\xec\xe1\xe9\x94\x9b\x10\x1b\xdf\x09\x6b\xc0\x37\xd4\x09\x31\x50\xd5\x7b\xdc\xe1\x78\x0b\xa6\xca\x59\x45\x66\x3e\x43\x61\xfc\x8a\xb4\xdb\x17\x8e\xba\xad\xbb\xd0\xb3\x3f\x08\x7d\x21\x9f\x29\xed\x39\x8d\x01\xef\xaa\xfd\x3f\x42\x65\x6f\xd6\x72\x87\x10\xed\x7e\x0a\x15\xd8\x46\x68\x4d\xed\x61\x52\xf7\xa4\x9d\xcb\x8c\xa3\x06\x3b\xa7\xba\xe1\x33\x29\xfd\x2a\x76\x0e\x7b\x96\x97\xa1\x74\x86\xb1\x9a\xd3\x1a\xd8\xae\x6c\x60\xe9\x7a\x98\x7e\x8a\x4b\x7c\x83\xb8\x62\xd9\xc6\x95\x1c\xa6\x0e\x76\x33\xdf\x95\xcf\x61\x41\x54\xcc\xbe\x06\x35\xbe\x70\x74\x6f\x5f\x03\x96\x24\x68\xfb\x4b\xa9\xcc\x3b\xc9\x5a\x89\xe7\x8b\x6f\xcf\x8e\x3c\x95\x81\x19\x91\x9f\x8b\x9c\xda\x46\xd6\xcb\x9e\xbf\x72\x60\x04\x79\x8a\x76\x56\x01\x75\xaf\xef\x6e\xee\xdb\x7e\x33\xd1\x1d\xfd\xb3\x0c\xa3\x5b\xec\x2a\x7b\x73\x71\x84\x6d\x6f\xbe\x3d\x20\x2c\x97\x7f\x44\xc2\xc4\x41\x54\x75\xed\x37\x91\x9b\x6a\x2f\xef\xd5\x48\xa4\x7c\x20\x18\xf9\x2c\x89\x3c\x8f\x3a\x28\x7f\xc0\x60\xe2\x0c\x93\x83\x02\xe4\xa4\x07\x8c\xa9\xa7\xee\x79\xea\xaf\xb8\xf8\x23\x0b\xc9\xc9\x1f\xfe\xb1\xb0\x00\x79\x3e\xc2\x6d\x7a\x50\x26\xe0\xea\x0e\x58\x76\xbf\xbc\xe7\xfd\xec\xa4\x1b\xab\xb4\x27\x8f\x0b\x77\xe4\x89\x1a\x9b\xe6\x5d\xc8\xc1\x8f\x47\xb6\xc4\x4a\xb2\xcb\xa0\x61\xf6\x24\xaf\x15\x15\xb5\xf7\x7c\x25\x54\xa4\xb3\xdc\x91\x1f\x21\x54\xa6\x18\x28\xb8\x55\x25\xb8\x69\x87\x98\xb3\xc1\x1c\x1c\x1e\x31\x39\x17\x56\x94\x72\x85\xed\xcd\xfb\x26\xde\x47\x84\x63\xbf\xf5\x61\x82\x67\x60\x04\xbc\xd6\x2f\xa2\x15\x2c\x18\x7d\xdd\x75\x7d\xe4\x01\x6f\xfb\x24\x5d\xef\x0a\x9c\x13\xef\x44\xc4\x00\x62\xa6\xc8\xbf\x09\xef\x94\x1d\x13\xc2\x78\x98\xfa\x1e\x41\xe5\x92\x60\x8e\x49\x65\x5c\xce\xd3\x3a\x57\x8a\x99\xd5\xbc\x6d\xb0\x40\xc2\xb8\x26\x95\xf3\xaa\x9c\x35\x3d\x33\x59\x6f\x12\xd3\x93\x4c\xdc\x13\x65\xa1\x95\x43\x2b\x3e\x35\xc2\xaf\x25\x6f\xd8\x03\xb7\x96\x13\xd3\x3e\x9e\xef\xd0\x61\x47\xbd\x60\xf6\xb4\x42\xb8\x8d\x72\x60\xeb\x70\x6e\x03\xd2\xd0\xa1\xdd\x86\x20\x34\x0f\xab\xd2\xf6\x01\xa7\x2d\xc5\x98\xc4\xe1\x44\x99\x80\x1b\x5b\x9e\x89\x5c\xfa\x08\xb5\x23\xf6\x1b\x37\x59\x39\xc7\x7d\x07\x8d\xfb\x4a\xe5\x30\x71\x70\x4e\xae\x48\xa7\x1b\xad\x53\xf3\xbc\xcf\xe2\x91\x87\x42\x90\xe3\x40\x18\x8a\xee\xba\x86\x25\xf0\x82\x9d\xe6\x11\x14\x51\x2f\xe4\xaa\x1f\xe5\x1e\xb4\xef\x53\xe1\x7a\xf5\x10\x39\x5a\x81\x57\x69\x06\xc7\x29\x43\x6b\xc1\xd1\x30\x54\x5e\x5c\xc8\xba\x0b\xb9\x6d\x3f\x7f\xeb\x55\x9d\xd5\x66\xc2\x87\x13\xf2\xf1\xc6\x11\x3a\xf6\x8f\xc3\xae\x24\x68\x6f\x88\xd4\x0c\xf4\x1e\xb6\x87\x52\xaf\xa3\x13\x7f\xec\xb3\xfb\x74\xdc\x5e\x12\x9f\x0c\x71\xed\xbb\x00\x64\x89\xec\x9e\x77\xc9\x77\xb4\x41\xf6\xd9\x1b\x07\xde\x16\xd1\x4b\x78\x2b\x1c\x47\xec\x7c\x14\x0d\x18\x96\x9c\x32\xa3\x0d\xf2\xea\xd7\xb2\x31\xbe\xa2\xf0\x62\x8e\xbf\x23\x06\xa2\x91\x82\x11\xe0\x6c\x90\x14\x5a\xf6\x82\x81\x11\x34\x31\x48\x71\xdd\x74\xcf\x7b\x07\x04\x9b\x27\xb3\xb0\x29\x04\x9b\x4f\xcb\x16\x62\xe2\xe7\xf2\x8d\x20\xc6\x23\x5a\x20\x0a\xf3\xd2\xb7\xe3\xfd\x7c\xe6\x84\xd8\x08\x2b\xe5\x05\x61\x11\xd8\x31\xb2\xf8\x9f\xae\x1b\xe3\xf3\xae\x2d\x46\x70\xd4\x3d\x73\x62\x98\x71\x1b\x13\x33\x80\xcd\xce\x27\x6f\xf3\xd9\xda\x81\x6a\x1b\x12\x13\x98\xed\x3d\x4c\x04\xf4\xac\x8d\x84\x11\xd4\x8e\x6e\x1b\xd9\x93\x61\x69\xac\x4d\xfb\x02\xa2\xec\x62\x7a\x76\x6f\xd6\x04\x50\xdb\x85\x72\x71\x40\x16\x7e\xbc\xf1\x1d\xd7\x3a\x94\xb8\x19\xe6\xf7\xa0\xe9\xea\x75\xda\x5a\x62\xc6\xed\xfa\x05\x82\x34\x17\x52\x2e\xc6\x33\xeb\xfe\x59\x29\xe5\x13\x5e\x5f\x8d\x53\xf7\x10\x2f\xf2\x96\x9e\xaa\xc1\x86\x72\x4a\xec\xd5\x72\x21\x19\x5f\xa7\x31\xa0\x8c\xbf\x50\x66\xb1\x32\x17\x95\x91\x05\xc4\xbf\x22\x1a\x3f\x04\x6f\x00\xea\xcf\x64\x48\x1d\x12\x5b\x19\x15\x3a\xdd\x7f\xbc\x51\x6b\x63\xe8\x20\x0f\xee\xbe\x9b\xc0\x5b\x26\x43\x5a\x60\xea\x9c\xf6\xd2\x8f\xa8\x91\x32\x2e\x93\x41\xdf\x5b\xf6\x9c\xae\x53\x64\x18\x6c\x20\x73\x0e\x8c\x94\x37\x1d\x88\x19\x8f\x90\xd7\x54\x19\xcb\x43\x8a\xea\xf0\xd9\x16\xee\x52\x67\x11\xfb\xbe\x5e\xd6\xb9\x32\x8c\x2e\x33\xb9\x3a\x79\xab\xf8\x2b\xdd\xb3\x02\x75\x4f\x2c\x42\x4d\xdd\x1e\xf1\x68\xea\x0e\x1f\x7d\xa6\xae\xf6\x91\x80\xba\xfb\xad\xc8\xfe\x05\x78\xbd\xe1\xe0\x96\x10\x19\xae\x22\xdb\xe2\xb6\x65\x07\x16\x6f\xda\x3a\x5e\x81\x23\x89\xd9\x97\x83\x6b\xc3\xc6\xe9\xf2\xa4\xa2\xa1\x4f\xc1\x69\x40\x3d\xba\xde\xfd\x21\x2d\x05\xac\x47\xd4\x4b\x1f\xeb\x2f\xfc\x2b\xc2\xf1\x59\xdf\x46\xd1\xb7\x73\x82\x68\xfa\x04\x11\x75\x6f\xbe\x2d\xaf\xf3\x4a\x2a\xc9\xe3\xcf\xf1\xc5\x59\xc3\x75\x48\x83\x1e\xb1\xdf\x12\x4a\xc9\xc1\x42\x36\x43\x08\xa2\xb8\x03\xc6\xb8\x1a\x27\x41\x60\x90\x3e\x72\x9a\x73\xdf\x2d\x9a\xd3\xf5\x69\x74\xb6\xa0\xee\xd4\xb1\x4c\xea\x9e\x72\xf8\x32\xe5\xa7\xbe\x7b\x72\x9f\x3d\xaa\xf2\x59\x9a\x00\xc8\x73\x25\x0f\x5a\xeb\xbc\xfa\xb9\xfe\x3a\x5f\x36\x9f\x52\xa9\xbb\xd0\x9f\x13\x62\x35\x5e\x6d\x9f\x67\x5f\x5f\x6f\x10\x69\x7d\x78\x84\x45\xe4\xca\x08\x70\x3c\xe0\x1b\x25\xf7\x94\x72\xc5\x4b\xbf\xe2\x8b\x1f\x21\x30\x4d\x88\x21\x7c\xc1\x24\x7a\x2d\x45\x29\xb4\xcf\x79\xb7\x95\x91\x31\x2c\x47\xf4\x02\x5f\x30\xfa\x64\x18\x74\x5d\x56\x47\x0a\x58\x51\x10\x78\x71\xce\x13\x79\x31\x87\xaa\xcb\x4d\x83\xb2\x86\x55\xdc\x85\x0e\xf7\xec\x56\xed\x32\x1e\x2b\xed\xb7\x8d\x89\x4b\x5f\x42\x0b\xfe\xfd\x4a\x46\xd6\x54\x2f\x67\xa7\xbe\x05\x53\xc5\xf9\x39\xde\xc2\x0d\x20\xd7\xc3\x04\x96\x20\x96\x86\x2e\xeb\x90\x45\xe0\xa8\x1c\x2e\x42\x11\xa7\x71\xf0\x6b\x09\x6c\x8b\x1d\xb8\x72\xe2\x66\xf7\x0f\xf4\x19\x14\xdd\xf4\xf2\xea\xc6\x9e\xb5\xc1\x63\x3f\xcb\xe5\x92\xf0\xda\xb8\xb4\xba\xe2\x48\x24\x40\x5a\x8c\x02\x10\x51\x5a\x9f\x72\xee\x0b\x26\xcf\xa1\x9c\x65\xd0\x78\x4a\xcd\xf2\xd5\x86\x06\x9c\xb1\x99\xb8\xc9\x9a\x9d\x5a\x56\x82\x17\xcd\x52\x8d\x11\xc5\x81\x19\x9a\x77\x8b\x6b\x39\xf7\xc5\x8f\xdf\x40\x04\x11\x9f\xd5\x1f\x2c\xee\x6e\xd4\x3f\xaa\x9b\x0c\x65\x6f\xb0\x25\x2d\x35\x6d\x7b\xf2\x28\x9a\x2e\x8e\x28\x57\x81\xcf\xf5\x91\x2c\xe4\xd6\x47\x9b\x49\xed\x92\xa0\x7d\x47\x7d\xba\x05\xb6\x01\xe4\xee\x21\x55\x18\x30\x00\x3f\xde\x4d\xda\xc2\xf4\x0c\x53\x96\x5a\x7a\xad\xc7\x9c\x93\xcf\xdb\xc3\xc6\xac\xec\xd3\x59\xbb\xc9\x55\xcf\x50\x7a\x80\x8f\xdf\xac\x6b\xf7\xaf\x38\xf6\xbb\x82\xdc\xc9\xc9\xbf\xe5\xdd\xb9\x1e\x0f\xed\x49\x7c\xcb\xb4\x31\x53\xb1\x2d\x2a\xe6\x2c\x7f\xd2\x82\x1a\xff\x3d\xb5\x25\x57\xe1\xa7\x3f\x6f\xbe\x29\x3a\x41\x6d\x10\x83\x05\xa3\x22\xf5\x2f\x6b\x58\x5f\xfe\x34\x5a\xb9\xd4\x47\x98\x74\xbd\xab\xf7\x99\xa1\x38\xef\x88\xa9\x6b\xe6\x5d\x70\xea\xc5\x97\x11\x6c\x0f\xc1\x0f\xf9\xf8\xb6\x30\xd7\x32\x10\x6a\x78\x4d\x89\x58\xfd\xf6\x57\x02\x15\xd0\xb4\xba\xb5\xf9\x28\x10\xb6\x29\xac\x07\xd4\xe6\xb3\x97\x0d\x90\x1f\x24\x44\x1c\x87\x4f\x58\x6c\xf7\xb3\x47\x19\x80\x74\x06\x1f\x05\xdd\xe6\xaf\x3a\x81\xd1\x63\x81\x7d\xde\x09\x1c\xd3\x4e\xcf\x19\x88\xcd\xe0\x0f\xd8\x25\xfe\x70\xed\xa4\x97\xed\x33\x06\xc7\xbc\x71\xfa\xf9\x54\xc2\x73\xe3\x5f\xec\x4b\xe8\x4e\x68\xe9\x7b\x97\x4e\xd1\x7b\x50\xea\x5e\x8e\x3d\x63\xe2\x6f\x29\x3b\x6a\x4f\x86\x47\x5b\x90\x92\xd0\xd8\xfc\x81\xfb\xad\xcd\x7f\x50\x27\xff\x82\x2c\x86\x25\xff\xc5\x5a\xff\xee\x16\xe3\xfa\xc2\x7f\xd1\x30\xde\x45\x2d\x22\xb7\x6c\xb0\x86\x35\xfa\x47\x62\x75\x45\xe9\x21\x65\x22\xb9\xcd\x8b\xa2\x05\x71\x03\xf7\x9e\x2e\xb3\x4d\x3f\x7e\x97\x0d\x99\x3e\x8c\x9a\x23\x7b\xd2\xe1\xd4\x0a\x7f\xcd\xc5\x22\x8f\x0d\x1d\x54\x5a\x5d\x7b\x55\xe7\x9d\x72\x1b\xf4\xa8\x3b\x0f\x65\x6f\xf2\x68\x9d\x6d\x28\xa7\x0e\xf5\x34\x1f\xdc\x29\x0d\x78\xd4\xa7\xa0\x51\x74\x64\x52\xac\xa1\xf3\x54\xb1\x4e\x39\x47\xfe\xa5\x2e\xe2\x8a\xb6\x6a\x8a\x0c\x6f\x76\xf5\xb6\x4f\xbb\x9c\x97\x38\x8f\xab\x25\xb9\x1a\x71\xe9\xc8\x79\x19\x19\x6d\x3a\xd3\xb7\xcf\x87\x7c\x8a\xe8\x09\x61\x2f\x62\xf0\xb0\x61\x10\x6e\xa8\xe7\x6a\x6a\xe3\x10\x2f\x18\xc5\x1f\x23\xef\x06\x3c\xb4\x53\x1c\xd1\x54\x6b\xa1\xed\x58\xa7\x18\x13\x46\x71\x65\xb9\x29\xa1\xc3\x3c\xb3\x34\x9f\x71\xec\x03\x8d\xb8\x09\xce\x6f\xda\x6a\x21\x7e\x05\xb7\x7d\xe1\xa6\xef\x1d\x7c\xd5\x7f\x34\xa4\x74\x27\xcc\xf2\xf4\x95\xa2\x26\x3d\x0a\x6b\x52\x27\xab\x5f\x6c\xd0\xb6\xce\x72\x54\x86\x36\x89\xc1\x1a\x87\x5c\xf1\xda\x1f\x07\x82\x14\xd1\x8f\x28\x54\xbc\x80\xb9\xcd\x6b\x02\xbb\xde\x07\x4c\x27\xce\x87\x68\xda\x00\x75\x21\xa6\x89\xa5\xc6\xca\x76\x11\xcf\xb0\x8b\xff\x57\xf6\x9b\x4f\x09\xe6\xd4\xb4\x9e\x20\xa0\xd2\x3d\x6b\xaa\x47\x9e\x14\xa2\xaa\xc9\x23\x27\x2d\xe3\x8d\xbe\x38\xce\x42\xd0\x0c\xed\x8f\x2c\x09\x08\x3c\xec\xc4\x61\x37\x71\x86\x66\xd4\x93\xbe\xdb\x77\xcc\x95\x9e\x52\x12\xed\x6b\x3f\xe5\xe0\x86\xae\x06\x95\xcb\x45\xf3\xa4\x20\x1d\x7c\xee\x15\x6f\xb1\x12\x26\x3e\xff\xca\xa5\x7e\xba\x27\x61\x35\xcf\xfb\x68\xba\xe2\x74\x8c\x89\xc8\x3d\x19\xba\xcc\x2c\x99\x40\xf7\xf3\x70\x90\xf4\xe2\x38\x11\x24\x13\x0e\xaa\xee\xe5\x6f\x11\x8e\xbb\x25\xde\x9b\xb1\xa5\x67\x24\xbe\xc3\x9e\x8d\x1c\x9b\x7f\x8a\x3c\x6f\x17\xd7\x6b\x83\x6b\x48\x37\x5d\xc5\xb5\xa4\xee\x67\x20\x59\x6e\x4b\xb3\x22\x4c\x68\xaa\xe1\x64\x46\xaa\xda\x7c\x78\x3b\x19\xab\x6d\x2c\x93\x53\x4e\x47\xd3\xb0\x3c\xad\xa1\x7e\xb0\x1d\x6f\x71\x48\xd9\x70\x09\xf3\xec\x8f\x3a\x87\x89\xf4\x4b\x43\x9a\xff\x8c\x28\x47\x4d\x5a\x8f\xdb\x45\x2a\x91\xd6\xc7\x35\x2a\x83\x19\xde\x25\x2a\xf3\x5f\x7b\x83\x28\x0d\x9a\x7c\x37\x2f\x23\x7c\xca\xbb\x78\x9d\xc7\x36\xc2\x91\xef\xda\x1b\xc4\xf6\x51\x65\x13\x3a\x34\x00\x83\x9b\x3e\xa5\x1b\x9a\xe5\x81\x19\xa4\x31\x6a\xf6\x65\x8b\xed\x50\xd8\x17\x85\x30\xfd\xb4\x86\x7c\xab\xf4\x5f\x6a\x79\xec\x70\x62\xd6\xb1\xe4\x81\xd1\xf0\x50\xd9\x57\xab\x0f\x6f\x32\x0e\xb6\xa0\x88\x99\x50\x0f\x4c\x0e\x75\x6d\xa1\x0f\x2d\x4c\x5b\x2f\x4b\x9b\x84\x9c\xd6\x88\xc3\x0b\x52\x95\xda\x51\x9e\xc4\x6a\x6f\xd4\xf5\x55\xd3\xc4\xec\xc2\xd0\xbb\x8d\xab\x8c\x5d\xc5\x3c\xc9\x15\x6e\xe5\x05\xed\xf4\xf7\x85\x2a\xe8\x15\xaa\x5b\xe9\x76\x75\x08\x2b\xef\x3e\x19\x37\x73\x80\x65\x0e\x3c\x1a\xc9\xf6\xa4\x24\xaa\x0a\x00\xbe\x2b\xb1\xd6\xb6\xdd\x11\x1e\x88\x88\xe7\x70\xb3\x5d\x60\xc8\xf7\x1b\xba\x21\x37\x28\x6a\x7d\xa8\x23\x76\x1d\xbe\xb5\x5e\xbb\xeb\xf3\x8c\x47\x01\xde\xfc\x31\x8f\x65\xe3\x4e\x50\x6d\x17\x10\xba\x1c\xd7\xca\x17\x5f\xf4\x09\xe6\x75\xf9\xaa\x11\x49\xf4\xe1\x7f\xff\x3d\x73\x71\xe8\x30\x1c\xd7\x73\x09\xbe\x66\x87\xfd\xb8\x09\x74\xf8\x81\xd3\x0f\xa5\x0f\xe0\xe3\x4e\x8e\xed\xec\x4b\xfd\x4f\x6f\x2a\x27\x36\x31\xc4\xc6\xc7\x8a\xcc\xbd\x57\xed\xf1\xf9\x09\x3e\xee\x5c\x32\xd7\x88\x1a\x61\xdf\x8f\xb8\xea\x29\x8b\xa9\x0f\xa0\x31\xf4\x9b\x0e\x1a\xbb\x69\x52\xab\xc8\x30\x47\x97\x54\x78\x9a\xe7\x95\xe4\xeb\x5b\xb2\x55\xed\x38\xca\xcb\x1f\xc9\xed\x60\xe4\xf5\x78\x65\x24\x99\xfc\x0f\xd8\xa9\xed\xa3\xea\x39\x20\x5b\xb4\xea\x8f\xfe\x3c\x9a\x60\x3a\xf1\x61\x3b\x95\xe4\xe9\x9e\xb2\x4b\x5c\x35\x52\xd7\x52\x31\x41\x43\x1a\xf6\xec\xfe\x03\x76\x0f\x34\xee\xf3\x25\xe1\xf8\xf8\xcb\xc0\xfc\x11\xf1\xf6\xb6\xfd\x46\x93\x0f\x5d\x30\x32\x92\x14\xda\x98\x9d\xf9\x66\xfd\x7a\xfa\x76\xde\xaf\x16\x8f\x0a\xbd\x2c\x18\xde\x62\x0f\xd6\x70\x2b\x3a\x5e\xe5\x51\xf0\x26\x4a\xa2\x01\xe3\x0a\x7b\x58\xf3\x98\x5b\x14\x7f\x97\xc7\x25\xd7\x99\x92\x09\x15\x0e\x73\xc0\xa8\xf3\x55\x79\x45\x27\xf3\xec\xc5\x65\x6c\xd1\x30\xb2\xe1\x1e\xd7\xaf\x65\x9f\x6f\x7a\xcb\x6e\x7a\xef\xfb\x06\x75\x7a\xd3\xf6\xb4\xe2\xf4\x29\x51\xf6\x11\xfa\x0c\xc0\xd0\xb1\xf9\x8c\xdb\xda\x3b\x53\xfd\xa6\x9c\x91\x8c\xcc\x2f\xa2\x4f\x77\xf9\x76\x72\x15\xea\x41\xd0\xa4\x17\x89\x87\xd6\xb5\x4c\x79\xac\x54\x6e\x72\xe7\xb4\x82\xf2\x29\x3b\xa9\x0d\x2e\x5b\xc9\x27\x5e\xca\x6d\x9e\x2b\x8a\x5c\x9c\xf9\x7f\x0d\x9e\xc1\x6b\x80\x93\x74\xbb\xd9\x15\xd6\x40\xeb\xfd\xaa\xc0\xe0\xe9\xaa\xbc\xdf\xc3\x3e\xd4\xed\xf4\xf5\xcc\x42\xc1\x16\x94\x49\x45\x11\x2c\xb2\x7b\x1f\x66\x6f\x81\x51\x37\x07\xa9\x53\x81\x3f\xc8\xc5\x95\xf9\x8c\x23\xb6\x06\x9e\x4d\x6c\x24\xaf\x88\x63\xef\x02\x13\x1e\x72\x76\x71\x47\xf8\x77\xb6\x6c\xb9\x0e\x63\xa6\xe6\x8a\x96\x35\xc9\xcb\xa2\xf0\x85\x22\xf7\x23\xf2\x10\x71\x80\x7d\x4b\xd9\xa6\x68\x82\xe2\x24\x7d\xa6\x15\x77\x2a\xf3\x5f\x7b\x3c\x44\x5b\xbe\x88\x8d\x38\xf5\xb1\x53\x79\x1b\x77\x61\x58\x28\x9c\x0d\x54\x46\x6b\xb3\x0f\x1e\x98\xe8\xc2\xe3\xb4\x74\x37\xd8\x00\xf2\xf8\xe6\x7a\x03\xce\xf3\x37\x33\xe5\xc2\xc1\x27\xe4\x63\x0f\xc3\xe0\x7c\x49\x21\xef\x5a\xd0\xf6\x4a\xd2\xbd\x5b\x74\x7e\x92\x9c\x9e\xef\x11\x59\x83\x71\x4d\x36\xe3\xbd\xcc\x62\xcc\x51\xd3\x8b\xfe\x92\xcb\x61\xfd\x2e\xf6\xa7\x00\x75\xdf\x67\x89\x56\x1e\x0e\x37\xdf\x28\x8f\x6b\x18\xaf\xca\xaf\x0a\xcb\xce\xf6\x43\x54\x21\x86\x49\x96\xa5\xdc\xf9\xb3\x31\xa8\xf6\x8d\x79\x13\xa5\xa5\x14\x40\xb5\xaf\xa9\xa1\x82\xe8\xc6\x8c\xdb\x4d\xd9\xa0\x3d\x05\x5a\x76\x4c\xed\x32\xc6\x86\x4a\x66\x89\x91\x7e\x7a\x68\x56\x0b\x59\x5e\xc2\x66\x47\xa7\xd2\xd2\x57\x1d\x3e\x55\x02\x51\xa8\x58\x4d\x30\x1e\x06\x92\x36\x1a\xd0\x0e\x1c\xa5\x63\x32\xad\xd3\xc5\xa8\xa3\x15\xfa\x70\x97\x3f\xad\xf2\xc8\x92\x53\x5d\x6b\x1e\xb7\xfc\x73\xdf\xf8\x68\xc6\x99\x43\xe2\x87\xea\xd8\x3e\x19\x53\x92\x2e\x83\x83\x34\x54\x2d\x3b\x68\xe9\xe1\x3b\xf9\x5a\xd3\x51\xab\xdf\xb0\x6c\x16\x8c\xd1\x90\xb7\x5e\xb9\xde\xbb\xaf\xf9\x80\xb0\x2c\x64\x04\x4f\x4f\xe0\xc8\x51\x57\xa5\xc5\x38\xf6\xe1\xca\x75\xc1\x1d\xe6\xb6\x8b\xa6\xd8\x9e\xe3\x4e\x3e\x29\xba\xba\x34\x17\xbd\x91\x86\x20\xbc\xb5\xea\xa0\xe8\xe3\x64\x76\x78\xee\xd9\x06\x38\x6f\xc9\x94\xfa\x2e\x71\xc1\x7d\x6b\xed\x84\x8a\xba\xde\x85\x11\x89\x84\x52\x30\x8f\x76\xe8\xcb\x6f\xd2\xc7\x05\x2c\x31\x65\x7d\xab\x58\x3a\xd9\x65\x5b\xc4\xa2\x6c\x8d\x35\x69\x37\x7c\x1f\xbd\x2e\x9f\xe1\x45\xf7\xb2\x79\x6b\x0f\x7c\x59\x5f\x2e\x8d\x37\xdd\x13\x04\xe6\x3a\x2d\xbe\xb4\xf8\xaa\x7c\x48\x54\x16\x17\xfa\xac\xbc\x99\x6d\xfa\x5c\x79\x5d\xb7\xc5\xf0\xf4\x63\x4d\xcc\x95\xaf\x8c\xbf\xb3\x97\xe3\xea\xd0\x6c\x1f\x6d\x1b\xea\xa9\xb6\x06\x9c\xd8\x8b\xfd\x8a\x02\x23\x50\xd9\xa0\x06\x34\xe3\x77\xda\xea\x10\x5c\xfa\x42\x5e\x10\x73\xaf\x16\x77\x26\x90\x6e\x8a\x61\x75\x88\xe0\x07\x7c\x77\x83\x8d\x78\x75\x9b\x8e\x69\xc0\xca\x1a\xa1\x59\x35\xd5\x3d\x9c\x97\xe8\x8e\xf6\xfd\x39\xcc\xef\x21\xa0\x46\x30\x93\x21\xc3\xbe\x64\x77\x78\xef\xd7\xd5\xa1\xa8\x63\xcf\xbd\x9f\x93\x0b\xa4\x0f\x95\xf7\x7d\x45\xb7\x31\xcb\x51\x3e\x1a\x17\x64\xfd\x61\x8c\x80\x49\x9b\xca\xec\xf9\x09\xba\xe1\x1e\x9e\x6b\x40\xca\x6b\x5f\xcd\xca\xf4\x14\xcf\xd6\x59\xbe\x48\x57\x87\x36\xe6\x43\x73\x8d\x03\xb8\x91\x13\x75\x03\xf2\x9a\x14\x37\xfe\xfb\x42\x9e\x7e\xd4\x75\xed\xd2\x78\x40\x09\x96\x11\x6e\x5f\xe5\xa5\x18\x71\x9d\x85\x12\xb3\xe4\x57\x46\x03\xb4\xae\x17\x76\x69\x24\xcf\x3a\x82\x3d\xd1\x4a\x39\x6f\xfa\x9b\x38\x45\x69\x33\xa6\xa0\xc4\x88\x2f\xdf\x6a\x4f\x6e\x56\xd9\x53\xcc\x63\xd7\x19\x4d\xfb\x5c\xd6\xe8\x0c\x25\x0b\x4d\xf7\xf0\xff\xaa\x3d\xa7\xfa\xfb\x6e\x55\x78\xb6\x86\xa2\x0e\x45\x5a\xee\x63\x57\x85\x51\x2f\x31\xd0\x16\x44\xbb\x91\x30\x09\x76\xe6\x37\x73\x44\x56\xed\xee\xa6\xfb\x9b\x8e\x60\xe5\x42\xab\x7e\xa1\xf4\xad\xe6\x42\x50\x3d\xbf\xd6\xa0\x35\x68\x7e\xd0\xe3\x51\xb5\x21\xba\x05\xd6\xa0\xea\x12\xdc\x5a\x82\xd8\x20\x7b\xed\x87\xe8\x9a\x81\x0b\x84\x63\xe4\x65\x59\xf0\xe6\xa5\xb3\xc6\x67\x4a\x79\xe6\x55\xa0\x76\x05\xba\xe2\x8a\x0b\xdd\x8a\x50\xcb\xf6\x1f\x2b\xe0\xe8\x1f\x17\xb7\xc4\x0d\xa8\x34\x8c\x83\xca\xb9\xc4\xfe\x49\xe3\x30\x29\xc2\xaf\xe6\xc9\xba\x94\x40\x8d\xef\x75\x01\xa7\xeb\x81\x8d\xd6\xb7\x1f\x92\x82\x89\xfe\x99\x94\x2c\x1a\xd4\x27\xb5\x5a\x53\xd6\x42\x00\x8f\xb6\x52\xbd\xae\x70\xbb\xbe\x43\x21\x57\x99\x4e\x61\xdb\x6a\xc3\xb4\xd2\x8f\xf9\x9b\x00\xf2\x0f\xac\x35\x70\xe0\x02\x9c\xc1\x5a\xc1\xda\x51\xb3\xf4\xe4\x45\xc6\x89\x4c\x95\x96\xbf\xa3\xf4\x0b\x52\xe8\x66\xb1\xe1\x96\x55\x6f\x55\xf5\x31\x69\x1d\xab\x79\x59\x47\x87\x8a\x59\x90\x2c\x5f\x98\x0f\x49\x86\xbc\x90\x49\xcf\x2a\xb6\x02\x6f\x41\x09\xa6\x45\x55\x5b\x55\xa9\xaa\x75\x6d\x31\x85\x8d\xe2\xb6\x0e\xc5\x9c\xa8\xee\xb0\x6b\x79\x9c\x5a\x1d\xa2\xa6\x04\x25\xd5\x89\x72\x29\xc2\x16\x08\x0f\xe3\x00\xe3\x56\x9a\xea\x42\x8e\xf2\x70\xd5\x75\xcd\x14\x02\x06\x0e\xe2\xe0\x5e\x67\xe7\x3b\x0d\x4b\x59\x8c\xfa\x84\x59\x18\x77\xfa\x0f\x39\xf2\x83\x61\x12\xa5\x05\xf4\x2f\x68\x44\xe0\x85\x43\xae\x1d\xde\x15\xdc\x2f\x85\x88\xc5\x3f\xfb\x77\x43\xfc\x8a\x1d\x46\x33\xf4\x26\xb5\xa9\x84\x72\xe3\xe6\x8b\x0c\xd6\x88\xb9\xe9\xa6\xdf\xc3\x51\x62\xe0\x21\xc3\xe0\x99\x14\x8a\xd8\x17\x30\x59\x57\x9b\x93\x4a\xb0\x4e\xbf\xbb\x23\x21\x47\xaa\xba\xf0\x10\x98\xe6\xd5\xb2\xda\x8a\x5c\x26\x03\xb5\xef\xac\xe7\xc2\x2c\x2b\x51\x4f\xa3\x93\xa2\xd4\xb0\x38\xe2\xcf\x5d\x35\xd0\x35\x72\x26\xda\x16\x6a\xb3\x9e\xdc\x36\xd0\x26\x11\x9d\xca\x74\xb9\x92\x49\xe2\x3a\xfa\x86\x5b\xe4\x53\xbf\xa7\x4d\x07\x07\xb5\x5c\x8d\x77\x9a\x92\xab\x37\x8d\xf9\x3a\x8e\x30\x39\xc9\xa9\x9b\x70\xde\xee\xce\xdb\xdd\x79\xbb\x3b\x9c\xed\xae\xb4\x2c\x8f\x6c\xcf\xeb\xf0\xac\x4b\x84\x4d\xbd\xfb\x95\xa6\x7e\xef\x5b\xa0\xbe\x90\xde\xc5\x66\xf8\xca\x81\x08\xde\x14\xb4\x8b\xc7\x5e\xee\xc8\x9a\x41\x18\x66\xc1\xfc\x2a\xfd\x2b\xe4\x3c\x03\xd1\xb2\x33\x12\xf0\x29\xe8\x8f\x29\x10\xf5\x95\xc7\xe4\x07\xbd\xd7\xf9\x33\x94\x7a\x51\x7b\x1f\x79\xf0\x33\xab\x20\xad\x92\x2c\x30\x31\x5c\x7a\xdd\x8c\xed\xaa\x01\x4d\x26\x35\x27\x29\x9d\xe3\x34\xda\x0e\xa8\xe9\x33\xef\x40\xd0\x02\x6b\x98\x66\x04\xc5\x04\x85\x1d\xca\xa4\xa1\x65\x85\x3a\x97\x8b\x24\xb4\x1e\x1a\x3b\x5d\xea\x3a\xd0\x22\xc1\xd9\xf2\x2e\x70\xe5\x0e\xeb\x60\xb5\xf8\x76\x4a\x3e\xd5\x46\xaa\x5e\x65\x27\xb7\x9d\xaa\x75\x58\x7f\x37\x55\xb2\x4b\xd8\x58\x83\x1b\x1f\xe2\xad\xb2\xfe\xd6\xb6\x30\xec\xf6\x8a\x26\xbf\x57\x3c\xf4\x56\x88\x93\xbf\x74\x3c\x82\x1e\x79\x30\x94\xfa\xc6\xf2\x90\xa8\x30\xf7\x86\x9a\xe1\xe1\xcb\xd2\xf8\x22\x40\xa7\xa1\x96\x34\x7b\x9c\xec\x52\x60\x3b\xa9\x52\xdd\x36\xbc\x3d\xda\xa7\x2f\xd6\x93\x47\x5f\x9c\xe4\xe4\x76\xb1\xfd\x07\xf2\x82\x0d\x8a\xab\x6b\x5f\x6e\x70\x28\x4e\x6b\x11\x0e\x37\xc0\xbe\x02\xdf\x50\xc9\x6e\xd2\xa7\x1d\xa6\x26\x1e\xe2\xa5\x47\xff\x44\xde\x2e\xe8\x20\xd2\xdc\x43\xe9\x02\x38\x8c\x9b\x52\x9e\x45\x70\x41\x9c\x16\x58\xe3\x00\x55\x4c\x5b\xb3\xf9\x09\x5a\x0d\xa3\xdf\x81\xfb\x89\xbf\xfd\xd4\x63\x2d\x4c\xe4\xc3\x74\xad\xc8\x53\x73\x64\x4c\xb5\x5c\xe2\xdd\xd8\x69\xba\xdc\x55\x70\xe5\xf6\xd7\x5a\x6a\x32\x7b\xfe\x36\x4f\xae\xf7\x61\xb2\x5e\x30\x70\xc0\xcd\x2e\x77\x69\x55\xa6\x60\xca\x30\xdf\x7d\x81\x2d\x78\x49\x49\x66\x54\xdc\xf7\xb4\x42\x73\xa1\x04\x59\x8d\x38\x1a\xfb\x33\xf2\xd9\xe2\xf2\x44\xe1\xd0\x84\xbf\x30\xdf\x2c\xa3\x58\x69\xc2\x01\x8a\x30\x5a\x58\xd3\x6f\x17\x1c\xb6\xc3\x83\xbe\x05\x1e\xa5\xcd\x83\x95\x15\x8c\x2f\x19\xa6\xa2\xd2\xbe\x9e\x6e\x51\x03\x2f\x9d\xfc\x0b\xf6\xc5\x75\x9e\x30\xa0\x24\x94\x9c\x4a\xfe\x8a\x20\xd2\x8c\xca\x4b\xc1\xff\x99\x8c\xaf\x2a\xcc\x21\xf5\x1c\xcf\x09\x9c\xa5\xa8\x36\x3b\x8c\xab\xf9\x06\xee\xa2\xd5\x62\xd4\x76\xb3\x30\x8c\x98\xc8\x88\x11\x27\x62\x62\xe5\xef\x96\x1b\xc4\x40\xf7\x5d\x31\xaf\x2e\x29\x2b\x81\x54\xe5\x6d\xa5\xaf\xa2\x6f\x6a\x1a\x71\x29\xec\x8d\x44\x71\x8b\xcf\x7e\xdc\x7f\x19\xb6\xac\x75\x0b\x6c\x35\x24\xc4\x7a\x69\x76\x0c\x7e\xde\x20\xc1\x66\x81\xa9\x4c\x72\x1b\xbf\xee\x87\xd9\x0d\xe4\x82\xb2\xaa\x41\x64\xc3\x62\x36\x14\x5a\x61\x89\x9b\xfd\x30\xca\xec\x6f\xff\x9d\x32\xc7\xc9\x4a\x5b\x4c\xec\xc9\xa9\x9c\x16\xd5\x44\x0f\x74\x7a\x6c\x99\x60\xca\xd3\x64\x8b\x70\xed\x7d\x6e\x35\xd0\xf3\x69\x53\x97\x53\xfb\x3c\x7d\xb6\x28\xc5\xfb\x3c\x8d\x76\x88\x69\x8c\x95\x62\xfe\x8c\xc1\x50\xee\x77\x87\xe9\x93\xbf\x87\x07\x6e\x3f\xf7\x0f\x5c\x1d\xa5\x3b\x84\x07\x81\x32\x6a\x67\x29\xde\x9a\xce\x79\xa7\xbd\x1f\xf5\xb4\xa9\x63\x91\x0f\xe7\xf4\x29\x3d\xa1\x49\x1e\xb0\x24\xee\x12\xff\xad\x1b\x48\x11\xa7\x2a\xf8\x02\x64\xcd\x37\xb1\xe4\x4c\x86\x69\x3f\x9c\x6c\x4c\xaa\xce\xa9\x25\x6b\xfa\x33\xf0\x35\xbc\xa4\xcd\x45\xfc\x32\x99\xfc\x9a\x57\xfe\x2a\xca\xb0\x13\x67\x7e\xfb\x51\x1e\xbf\x0a\x59\x94\x09\xb1\x31\xb3\xd5\x2b\xc7\xa6\xb1\x96\xce\x37\x6b\xea\x5b\x41\xf2\xe9\xdc\x7e\x5b\x50\x62\xb8\xce\x1a\x52\x59\x59\xa6\x4a\xa4\xa9\xcd\x9b\x08\x1b\x0f\x43\xd8\x1d\xaa\xa4\x6c\x2f\xbd\x28\x6a\x05\x55\xdc\xc7\xcd\x61\xc9\xfa\x6a\xf5\xd8\x94\xd2\xbe\x5c\x6d\x9b\x52\x2a\x82\x59\xcc\xcc\x59\x93\x57\xcd\x87\x11\x12\x6a\x67\x02\x6f\xcd\x0d\xac\x4c\xe2\x64\x2e\x49\x92\xc7\xdd\x7b\x9e\xb4\x40\xe3\x74\xd3\xa4\x75\x1a\xfb\x9e\x73\x1b\xf0\x0e\x22\x49\x2a\x2f\x8c\x69\xc7\xfc\x7c\x68\x55\x30\x66\x2f\x67\xd4\xc6\x5a\x3c\x27\x48\x35\xcb\xbf\x34\xd6\xfb\x38\xe9\xd1\x76\x2b\x7e\x28\xd9\xd1\x04\xcb\x89\x92\xa3\xe9\x64\x07\x9f\x1b\x55\x58\xf1\x71\x0e\xab\x6a\xc3\x7b\x00\x67\xd3\x04\xb9\xfd\x24\x46\x93\xb9\xc7\xca\x8b\x26\xd0\x4f\x2f\x2d\x5a\xe2\xda\x31\x65\x45\x65\xc2\xb6\x51\xd5\x73\x4e\xd4\x80\x59\xfb\x48\x89\xb6\x89\x69\xaf\x19\xd1\x04\xb1\x03\x4a\x88\x26\x08\xed\x3b\x1f\xda\xe5\x20\x9c\xee\xa9\x70\xbc\x6c\x68\x27\xfc\x83\x4c\x86\xea\xfb\xd7\xe7\x54\x68\xef\x65\xb5\xc7\x53\xe6\x39\x11\xda\x27\x11\x3a\xc4\x2a\xd9\x43\x1a\x54\xcb\xe4\x0d\x97\x05\xd5\xf5\x52\x8f\x3a\x09\xaa\x69\xe6\xc7\x3c\x56\x1e\x49\x0a\xb4\xe5\x30\x76\x62\x19\x50\xa5\x97\x79\x4e\x80\x9e\x76\x02\x34\xf5\xe6\x8f\x25\xff\x59\x3d\x7c\x0c\x95\xfe\x94\x84\x93\xc6\xcc\x7e\xb6\xf1\xdc\x3a\xf9\x99\x00\x3d\xe5\xdc\x67\x93\xc2\x11\xdd\x10\x02\xfc\x85\xb2\x67\x4c\xd6\x17\xdb\xca\x45\xe9\xde\xfd\x3e\xaa\x90\xf7\xd9\xea\x43\x49\xe3\xa0\x5d\x3e\xda\x39\x39\x40\x74\x5d\xa7\x05\x48\x8d\xe9\x8b\xf8\x29\x2b\x89\x8b\x83\x5d\xa6\x78\x17\xda\x81\x80\xf7\x78\xac\x2e\x86\x6c\x86\xe4\x29\xb5\x28\x91\x92\x66\x1e\xaf\x90\x83\x19\x26\x2c\x51\x82\x5d\x3f\x69\xc9\xfb\x93\x34\x4e\x53\xad\x2b\x7b\xb2\xae\x24\xf6\x7c\x4c\xc6\xe7\xa6\xe8\xcd\x42\x5b\xe3\xae\x0c\x27\xac\xb2\x31\x7d\x89\xde\x8e\xae\x6e\x09\x2f\x6d\x74\x2e\x1e\x39\x51\xa8\xa6\x45\x0d\x4e\x2d\x2c\xa3\x29\x32\x9d\x9e\xa2\x1d\xca\x25\x3b\x7b\xb7\x35\x1a\x0c\x10\x43\x3e\x70\x60\xe1\x40\xd6\xc4\x66\xed\xef\x53\xe1\xde\xa1\xae\x59\xab\xd9\x48\xfd\x85\x34\xbc\x49\x2b\xb5\xaa\x6d\x4b\xfa\x87\x52\xcd\xa7\xb0\x6b\xb3\x56\xa7\x4b\x9e\x28\xe8\x7d\x7e\x68\x6d\x90\xe4\xc2\x13\x8a\x3c\xfe\x71\xb8\x23\xc4\x48\x2d\x92\xd4\xda\x64\x95\x8b\xe4\xde\x00\xd3\xcb\x5a\x27\xe9\x9d\x21\xcc\xa5\xb7\xff\xbe\x4a\x4a\x16\xf4\x6e\xa9\xa4\x25\xb1\x3e\x4d\x97\xaa\xa8\x7f\x4b\xfe\x95\x04\x1a\x4f\xd1\x5f\xac\x10\x38\xb6\xc3\x58\xe5\xa6\xd1\x9e\x50\x19\x7a\xdb\xb2\x3f\x98\x3f\xfc\xd2\x32\x91\xf4\xe5\x17\xf1\x6f\x3a\xd8\x0c\x00\xac\x39\x83\xbd\x8e\xb6\xee\x9d\x4f\x8c\xfa\xe3\x22\x3e\x9f\x82\xff\xf6\xdc\xd9\x8b\xeb\x57\xd5\xfa\x77\xe4\x00\x36\x59\x6f\xbf\xe4\x63\x75\x6b\x48\x0e\x07\x79\x80\xcc\x70\x57\x4e\x23\x6b\xe5\x9c\x40\xfe\x86\x66\x3f\x6e\xa3\x15\x78\x39\xa8\x78\x41\xb8\xa3\x40\xb6\x5f\x05\xf2\x27\xd3\x82\x41\xbb\xc0\xf6\x79\x1e\xad\x6b\x87\x6a\xa0\x0e\x79\x04\x72\x88\x25\x7a\xdb\xda\x4d\x13\x93\x41\x27\xeb\xe8\xdd\x39\x96\xf2\x08\xc8\x62\x7a\x71\xb4\x0e\x7b\x84\x8b\xcb\xf8\x19\x9d\x34\x24\xc7\x17\x83\x34\x5e\xe4\xaf\x80\x0d\x91\x5d\xae\x20\x35\x7c\xcf\x74\x09\xf8\x83\x49\xa4\x8c\xdb\x33\x5d\x83\xb1\x83\xf4\x4c\x57\x73\xf8\x04\xbd\xf5\x9e\x3d\xd3\x5b\x60\xed\x29\x35\x21\xaf\xf6\xea\x5e\x35\xef\xb3\x75\xba\x9a\x2f\xa7\x9a\xce\x68\x12\xb9\xc7\x9c\x86\xa9\xb2\xee\x2d\xbb\xd1\xc4\xe2\x9c\xe2\xe8\x27\xc5\xe3\x4b\x76\xec\xe3\x65\x05\x35\x02\xe7\xb4\x87\x8d\xea\x4d\x91\x00\xe9\xf1\xc0\x42\x87\x6b\x32\xba\xdf\x3c\x45\xf6\x60\x94\x07\x16\xd4\x33\xd8\x3c\xb0\xd0\xe9\xd5\x1d\x4c\x22\xe0\xe0\x1e\x58\x20\xd4\x4d\x9e\xce\xdb\x02\xdb\x00\x72\x65\x41\x11\xf7\x13\x7e\x05\xb7\xed\x7d\x7e\x53\x1b\x75\x91\x39\xab\x17\x7f\x46\x88\x70\xcc\x77\xf2\x47\xfd\x75\x30\xbf\x8f\x08\xc7\x3e\xd8\xf9\x7f\xa2\x14\x5c\xb5\x79\x4d\xea\x1b\xd2\x12\xff\x3b\x17\x4f\x5d\x66\x42\x07\x9c\x0d\xb8\x91\xa7\x79\x91\x39\x83\xb0\x2c\x46\xd5\xf7\x88\x8c\x33\x7d\x77\x09\x31\x53\x7d\x7f\xa8\x88\xac\x2b\x20\x2a\x11\xf3\x54\x9b\xb9\x64\xea\xd3\xdb\xc6\xbb\x04\xa4\x15\xb5\x6e\xea\x93\xe4\x06\xb0\x5b\x89\x2f\xab\x6c\x89\xca\xdc\x15\x46\x80\x53\x0f\x92\xbb\x1d\x66\xf2\xcc\xfd\xcb\x7c\xfc\x18\xf9\xe8\x84\x15\x69\xf7\xa7\xa3\x35\xab\x59\x9f\xbd\x3e\xc6\xf5\x30\x0f\xd7\x4a\xfa\xf2\x73\x75\x79\x8d\xc5\x60\x27\xb6\x81\x8a\x4e\xa8\x6a\xcc\xa7\xb5\x87\x32\xd5\x38\x5b\x45\x0b\xb1\xc9\xcf\x08\x16\x9e\x40\xdd\xd8\xc4\x4e\x7f\x3c\xcf\xef\x2d\xfe\x8d\x95\xcb\x90\xb5\x03\x54\x3b\x0e\xb5\x99\x1f\x4d\xcc\xcd\x79\xff\xc8\x18\x92\x1c\x14\x8e\x76\xfb\x48\x2f\x29\xbe\x57\xd7\xbc\x26\x3d\xdb\xc5\x96\xde\x3e\x3b\x38\x27\x5d\x11\xc5\x51\x89\x7e\xda\xed\xa9\x39\xff\x79\x77\xd2\x93\xd9\xd9\xfa\x5e\x24\x25\x01\x39\x47\xae\x3c\x8f\xbe\x80\x7b\xbd\xbc\xbb\x61\x78\x0b\x4c\x3b\x41\x6f\x51\x86\x2c\x9f\xfa\x93\x07\xaf\x3f\xa9\x17\xf9\x92\x30\xa8\x9b\xe3\xd4\x3e\x7b\xfa\x9d\xd5\xfc\xbf\xd3\x90\xcb\x6b\x00\x44\x6e\x7a\xc1\xe0\x09\xbf\xaa\x1a\xa3\xb8\xdf\x89\xb7\x93\x5d\x11\x37\x13\xc2\xed\x16\x3b\x56\x7d\x82\x5c\xf0\x80\xc3\xf7\x20\xd7\x35\xfb\xf5\x7c\x53\x01\x35\xed\x0e\xd4\x33\xd3\x17\x64\xa5\xa6\xc9\x2f\xb3\x9c\x9d\x3a\xf6\xa1\x26\x8a\x4f\xcb\xe4\xe6\x35\x67\x88\xc3\x7a\x57\x62\x6d\x55\x30\x0c\x91\xb5\x61\xcc\xb9\x36\xd3\xdd\xcd\xbd\x80\x21\x33\xdd\x2c\xcd\x09\x98\x05\x3a\x6b\xf0\x63\xbd\xa6\x8c\x27\xb3\x34\x08\xf0\xd1\xab\x66\x9b\x07\x1f\x13\xdd\x2a\x9c\xf2\x9a\x14\xc3\xe6\xf1\x34\x66\xcb\xf2\xee\x46\x1f\xe3\xff\xf9\x6f\x5d\x8c\xff\xe7\xbf\xc7\xc3\x78\x41\xdd\x1b\x1c\xb2\x28\xd6\x95\x8f\x91\xbb\x06\x7e\x1a\x91\x84\x6e\x3a\xcd\xeb\x53\x34\x60\x0e\x53\xa7\x52\xb7\x0a\x32\x29\x59\x18\x08\x09\x98\x89\xbc\xbf\x6e\x44\x4e\xce\x0d\xd4\x90\xa1\xb6\x23\xa8\xa9\xcc\x32\xb3\xf3\x83\xa0\x2d\xc2\x1e\x5a\x79\x30\x64\xf1\xac\x8f\xc9\xd5\x18\x70\xc3\x3d\x54\x22\x6b\x2f\xeb\x66\x81\x45\xdc\x49\x95\xff\x0e\xc8\xe3\x9b\x9d\xe6\x9e\xe4\x42\x28\x14\xcd\x70\x50\x82\x11\xb8\x0b\xea\x86\x43\x86\x15\x9a\x0d\xf5\x1a\xac\x72\x73\x6e\x84\xa9\xdf\xa9\x89\x34\xbc\x06\xe0\x94\x70\xd6\x18\x42\x57\x71\x93\x36\xf7\x33\x10\x28\x3a\x44\x99\xee\x86\x12\x8c\xe7\x75\x61\x35\x04\x51\xc3\xd7\x78\x1f\x5d\x82\x13\x31\xcc\x77\xa7\x74\x17\xab\x8b\xca\x41\xaa\xdd\x24\x96\xb2\xc6\x4b\x3b\x1b\x59\x05\xb2\xb7\x9d\xae\x46\xcb\x3b\xd8\xe7\x24\x8c\x1f\x42\x82\xf2\x3d\x0e\x89\x05\xbe\x60\x78\x8b\x3d\x58\xc3\x6d\xe8\x20\x2f\xb7\x1b\xcd\xee\x67\xa8\x16\x33\xe8\x25\xdc\x46\x00\x42\x22\xdb\x6c\x42\x14\xa0\x15\xf6\x70\x86\xb5\x7e\xfd\x08\xaa\x87\x1a\x86\x40\xb9\x80\xd6\x32\x65\x16\x5d\x18\x62\xc2\x0c\x56\xcb\x74\x0b\x46\x9d\xaf\x34\x22\xdc\xf8\xce\x47\x0e\xe2\x07\x09\xd1\x13\x2c\x77\xa1\x53\x2f\x79\xea\x84\x90\x5e\x1e\xbf\x72\x7b\x88\x2a\x83\x61\xa4\x91\x4f\x94\xad\xb0\xeb\x02\xb1\x42\xfb\x29\xfc\xac\xdb\xaa\x4d\x2f\x5a\x20\x62\xfb\x34\xe4\x77\x8b\x6b\x39\xba\xe2\xc7\xf4\x82\x90\xfa\x83\xc5\xdd\x4d\xcb\x8f\xc6\x17\x30\x5b\xa3\x03\x12\xa6\x04\x19\xef\x5d\x39\x16\x59\xf4\xeb\x9e\x52\xfe\x09\x7b\x10\xee\x42\x0e\xbe\xea\xdb\xc4\x5e\xde\x30\x1a\xd8\x6b\x06\x8b\xc8\x95\xb5\xa4\xee\xf3\xc1\x12\x61\xc5\x90\x7f\x68\x76\xba\x93\x01\x16\x63\xe5\x70\x2b\xd9\x21\x73\xd0\x45\x26\xb5\x09\x3d\x84\x2f\x98\x44\xaf\x16\x80\x97\xb7\xf1\x48\x19\xcc\x28\x08\x3c\xf0\x81\x70\xe4\x15\x9d\x35\x4d\xc1\x37\x80\x48\x66\xda\x4a\xec\xb0\xd9\xb5\xb4\x8c\xfe\xb2\xfc\xa4\x14\x14\x2b\xdc\xcc\x1f\x55\xeb\xcc\xa1\x84\x03\xab\x59\x69\x0f\x6c\x08\x94\xe9\xee\xe9\xd0\xa7\x5c\x40\x72\x1f\x08\xdc\xf2\x18\x51\x70\x6a\xb7\x7d\xd5\xa1\x74\x93\xa7\x9c\xde\x8c\x64\xc5\xd2\x6e\x0a\x54\xce\xf3\xdc\xaa\xe8\xe7\x14\xf2\xb4\xd5\xf2\xb6\x32\x72\x00\xf9\x75\x1b\x92\x63\x88\xcb\xb3\x15\x72\x04\x7f\xae\xd6\x6b\x06\xeb\xa4\x79\xb9\xb4\xe8\x3f\xed\x3b\x7c\x4f\xbd\x3c\x11\x69\x44\x89\xc9\xcd\x60\xa3\xea\xf3\x8c\x82\xeb\x02\x41\xc9\xfa\x69\x92\xd7\xc5\x79\x15\x67\xde\xe6\x07\x75\xd0\x37\xbf\x5e\x90\x11\xd6\xf2\x42\x4a\xdf\x03\x7f\x3c\x05\x8a\xf8\x86\x32\xfc\x77\xcc\xba\x46\xbe\xbc\x2c\xae\x8e\xf2\x56\x89\x84\x3f\x62\xe2\x4a\x13\xe5\x07\x25\x1a\xea\xc1\x3d\x3c\x69\x80\xcc\x49\xbc\x4f\x87\xd8\x3e\x40\x93\xc1\x51\x3d\x3a\xd3\xb0\x7b\xe9\x7c\x8f\x53\xca\x3b\x13\x9e\xb5\xd8\x27\x8a\xf4\xb4\xe8\xdd\xa9\xc5\x78\xac\x04\xa8\x53\x9a\x2e\xe1\xe1\xfe\xa4\xf7\xce\xc5\x66\x22\xaf\xa1\x1f\x36\x78\x9b\xf7\x78\xb2\xad\xf4\xfa\x80\x85\xd7\x6b\xf3\x70\x81\xc5\xcb\x04\xf2\xa7\x07\x1e\xf5\x9c\x17\x85\xd7\x72\xf6\x33\x7a\xeb\xbf\x89\x83\x71\xf6\x2c\x8e\xd1\xb3\xb0\x70\x29\xf6\xe7\x4b\xbc\x73\x27\xc2\xd2\x7b\xd8\x83\xdb\xf0\x5e\xfd\x05\x53\x47\xe1\xbe\x30\x45\x72\x2f\xc1\xcc\x5a\xea\x15\xf4\xe6\xb0\xe7\xd9\x5b\x2b\x3a\x25\xbe\x75\x13\x36\x2e\xca\x46\x6f\xfd\x98\x53\x91\x5e\xd9\x39\xfa\xb8\x4d\x4a\xc7\x48\xd1\x1b\x05\x97\x4e\x25\x86\x93\x92\x77\xa8\x91\x1c\xc5\x95\x3c\xa5\xe4\x4f\xd4\xf7\xca\xee\x1e\x0e\xe3\x81\x65\x77\xf5\x8e\x2f\xc2\x63\xa9\x0e\xd3\x6e\xfc\x6a\xad\x3c\xc7\x7c\xe4\x5e\x9b\xa9\x58\xf7\x2d\xcf\x73\x14\xc8\x4a\x82\xe7\x88\xd0\x94\x11\xa1\xd2\x9e\x71\xa2\x71\xa1\x83\xf0\x5d\x6c\x9d\x96\xb3\xb7\x72\xec\xde\x4a\x4f\x37\x65\xdf\xfe\xc9\x39\x8e\xd4\xd7\x23\xd9\x9b\x2b\x72\x8e\x2c\xd9\x09\xeb\xb8\xa2\x4c\x35\xc3\x37\xd8\x26\xb1\x9f\x68\x53\x5a\xea\x7d\xec\xc1\xa6\x84\x8c\x91\x62\x4d\x72\x1e\x9d\x4a\xa8\x29\xbb\xb2\x71\x98\x91\x26\xf9\x7d\x13\x95\xd4\x4f\xd4\x73\x4b\xeb\x4a\x87\x71\xdc\xaa\xef\xc7\x1f\x51\x94\xc9\x4a\x15\xa6\x75\x04\x94\x0a\x79\x0e\x31\xc9\x1d\x3a\x33\x99\xee\x59\x98\xe7\xf8\x92\x85\xf8\xce\xd1\xa5\x29\xa3\x4b\xc5\x4e\x71\xa2\xc1\xa5\x43\xf0\x56\xec\xdc\x94\xb3\x7f\x72\xdc\xfe\x49\x2f\xc7\x64\xcf\x1e\xc9\x39\xa8\xd4\xcf\x07\xd9\x97\xf3\x71\x8e\x28\xd9\x48\xea\xb8\xe2\x49\x55\x7b\x77\x9c\xa5\x4b\x45\x17\xd6\xb8\x2e\x98\x61\xca\x30\xdf\xd9\xf5\xa7\x75\x21\x74\x18\x0e\xb8\xea\xf7\xb5\x47\x57\xc8\xbb\x49\x6e\x3c\xca\x6f\x7e\x4f\xba\x5d\x06\x0c\xc0\x8f\xd1\x2d\xda\xc7\x34\x26\xde\x66\x8f\x1f\x9a\xf6\xb3\x4b\x06\xf6\x5c\x71\x25\xf1\xd4\x57\x5a\x55\x56\x1d\xa5\x81\x2d\x62\x9e\xc8\x3a\xb6\x29\xda\xa9\xd9\x49\x5d\xa9\xe9\x14\x75\x56\xf8\x96\x25\x68\xcf\xcb\xf4\x24\x96\xa9\x22\xcf\xd2\x29\xf2\x7d\x2c\x59\xa9\xea\x9d\x17\xae\x91\x2c\xd3\x23\xf0\x79\xf5\x9e\xc4\xea\x95\xfb\xb4\x5d\x02\xdf\xc7\xda\x95\xe9\xdd\x79\xe9\x76\x09\x92\x53\x86\xd6\x71\xa7\x8a\x96\x6e\xe7\xc7\xd8\x60\x50\x46\xd9\x68\x4f\xfd\x64\x93\x35\xc2\xd3\x39\x4f\xbb\x1c\x20\x09\xb6\x53\x2d\x22\xc9\xd4\xa7\xb7\x70\xba\x04\xa4\xe5\xa5\xaa\x54\xaa\x29\x24\xce\x91\xb3\xb9\xcf\xa9\x90\xb6\x67\x4b\xba\x28\xb5\x6c\x18\x01\x75\xef\xc8\x13\xfd\x4e\xe2\xe6\x75\xad\x5d\xc4\xc2\x7b\x08\xa2\x95\x87\xc3\x8d\xfc\xb3\x14\x75\xd1\x67\xcc\xc1\x7c\x27\xff\x88\xd3\x67\x20\x02\x69\x08\xb9\xb5\x0e\x3d\x94\x80\xd8\xbc\xd4\x90\x35\xc1\xfa\x82\x9f\xc0\xd9\x39\x1e\x7c\xa5\xae\x51\x4a\xa3\x65\x8e\x10\xba\x5f\xb2\xa9\x0a\xf9\x1b\x75\xe1\x14\xed\xa1\xa0\x6b\x1f\xd6\x30\xe6\xa7\xd1\x32\x13\x23\x94\xfb\x92\xe7\x51\x07\x71\xcd\x56\xd1\x65\xc0\x49\x8b\x4a\x01\xfb\x3e\xcf\x9a\xb5\xc6\xa5\xa8\x0b\x77\x37\xd2\x9f\x38\x0d\xa8\x47\xd7\xbb\x3f\x60\xd7\x27\x8d\x16\x4f\x9d\x4f\xf4\x68\xaa\xa4\x7b\xd9\x2c\x62\x69\xbe\xa3\xad\x22\x67\xb4\xb1\x06\xcb\xb7\x09\xd7\xa2\x53\xac\x72\x75\x74\xd8\xc1\x00\x71\x67\xf3\xc1\x07\xb6\x86\x0f\xcf\xb0\x9b\xfd\x96\x69\x9c\xe4\xab\x30\x6d\x28\x36\xfb\x6d\x16\x0f\x50\x3c\x2d\x13\x1a\xa8\xe9\x32\xdd\x80\x14\xc7\x41\xd1\xe7\x2d\x59\x93\xb7\xaf\x01\x22\x61\x57\x9b\xdd\x87\x64\xd1\x61\xb0\x7d\x4c\x28\x59\xb3\x59\x5d\xdf\x03\x30\x5f\xa6\xc8\x87\x64\xe8\x7d\xe1\x04\x94\x5a\xbc\xe9\x67\xf8\xab\xcf\xf1\xdb\x3f\xe1\x14\x30\xba\xc5\x82\x19\x8a\xe7\xd9\x18\x38\x1e\xc2\x7e\xdb\x11\x38\x16\x71\x9a\xeb\xfa\x9a\xee\xad\xed\x61\xfe\xf2\xa4\x23\xad\xec\x8a\x6e\xea\x2f\xed\xf2\xb0\xe9\xcd\x6f\x05\xe9\xf7\x62\x83\x1b\x2c\xd7\x97\x56\xc5\x2f\x6d\x4a\x2a\x72\x31\x10\x47\xbe\xf9\xc3\x6b\x80\x93\x27\x03\x96\xe0\x50\xe2\x86\x36\x2f\x07\xe4\x33\xe8\xdb\xcc\xc4\x20\x5e\xc5\x67\x09\x1f\x08\x3f\x39\x47\xb4\x4e\xa0\xf9\xc3\x39\x6d\xc0\x8a\x17\x73\xa6\x72\x70\x1b\x02\xd3\x57\xcf\xfa\xd0\xe9\x0d\x4a\x03\xf9\xf7\x62\x54\xa4\xac\xb7\x97\xdc\x32\x3e\x4e\x34\x65\x87\x89\x87\x09\x24\x5f\x2f\x35\xd7\x4a\xe6\xaf\x2c\x04\x2e\x21\x07\xc2\x4b\xe3\xc5\x8e\x5c\xfb\xbb\xba\xab\xae\xa5\xc9\x69\x0b\x6f\x28\xbc\x00\x71\x82\xf9\xa6\x3a\x47\x85\x39\x73\xfa\xac\xec\x04\x48\xc3\xc2\x66\x58\xe5\xd3\x94\x90\xb1\xb7\xba\xaa\x27\x7a\x92\xe9\x6e\x19\xd3\x7a\x53\xa8\x39\x43\x32\xf2\x6d\x9e\x02\x52\x85\x89\x50\x8e\xc8\xd7\xd2\x1a\xb3\x77\xe2\x5c\x18\x04\x6d\x39\xef\x5d\x63\x3e\xe7\x88\x54\x99\xeb\x43\x18\xa2\xb5\x5c\x87\x38\xf6\x61\x88\x07\x89\x4c\x57\x45\x35\x5a\xd0\x40\xd9\xc9\x82\x74\x3a\xe9\x1d\xbd\x89\xd3\x74\xe4\xa9\xfb\x01\x0a\x32\xfb\x78\x03\x2a\x90\x07\xe7\x13\xa8\x32\xaa\x5d\x94\x4c\xee\x1f\xa8\x74\xf1\xec\x25\xf4\x96\xe5\x31\x7b\x0c\x6d\x6b\x77\xcf\x7e\x43\x3b\xb7\xc7\xf1\x1e\xda\xed\xce\x70\x3e\x44\x65\x9e\x63\xf3\x24\x64\xc8\xf7\xf6\x27\x24\x40\x0f\xdb\xab\x48\xef\x6f\x9d\x6a\xd6\xbd\x46\xde\xc1\xa4\xde\xbb\xea\x20\x6a\x78\x4f\xbe\xcf\xd6\xd5\xe2\x9c\x89\x37\x96\xd9\x39\x27\x2f\x63\xcf\xde\x13\xf3\x16\xa6\xf1\x14\xd3\xef\x15\xe2\x0e\x24\x07\xaf\xbd\xc0\x46\xc9\xc6\x27\xd0\x4f\x26\x25\x5f\x61\xd6\xfe\xf6\x8f\x73\x72\xde\x5c\xb5\xc7\x48\xd3\xcb\xd6\xce\x41\xe7\xea\xd3\x1b\x5f\xe7\x84\xfd\x39\x61\x7f\x7c\x09\x7b\xbd\xe5\xbe\xd7\xd4\x7d\x13\x85\x73\xfe\xde\x44\x78\xc7\x97\xc9\x2f\x3b\x38\xa7\x1b\xc6\x97\x53\xd9\x27\x8a\xaf\x80\x78\x70\x41\x7c\x3d\xbd\xdd\x7b\x08\x5f\xa1\x86\xe7\x08\x7e\x4f\x41\x1e\x73\xfc\xbe\x65\xd1\xee\x39\x7c\xdf\xca\xeb\x71\xa2\xf7\xad\xf6\x66\xb8\xe0\x7d\x79\x9a\x63\x8b\xdd\x4b\x70\xef\x1d\xba\x6f\xc2\x3c\x86\xc8\xfd\xa4\x45\x01\xf0\xca\x21\x3e\x64\x85\x1f\x04\xe6\xc0\xb6\xc0\x0a\x3a\x2a\x1f\xc4\x95\xe1\x51\xc8\xa9\x9f\xe1\x75\x2d\x30\x25\x37\x39\x57\x24\x27\xdc\x8e\xfb\xb6\x19\xf6\x92\x9f\xfe\x15\x52\xb2\x40\x7c\x63\xd6\xe7\x23\x48\x6f\x2b\x6a\xb1\x26\xfb\x4b\xd7\xc9\x23\x3d\x24\xc7\x1f\x95\x10\x7b\x1c\x93\xb3\x64\x5b\x6c\xd6\x55\x9e\x16\x07\x73\x09\xfd\x2f\xb0\xda\x50\xfa\xac\xa1\xb6\x86\xf8\xfd\x4a\x00\x97\x10\x6b\xf8\x49\x19\x5e\x23\xf2\xa5\x4d\xd7\x8e\xd0\xc9\x1d\x88\x17\xc6\xae\xf0\x50\xf3\x8e\xe7\x30\x57\x71\x68\x44\xe5\x54\x4a\xd1\x5e\x5d\x39\x10\xd9\xd7\x94\xb8\x0a\x1d\xf4\x50\xc8\x1f\x18\x22\x61\xfc\xfb\xc3\x40\x1b\xc8\xbc\x75\x9b\x62\x80\x42\x85\xce\x17\x0a\xa1\xd8\xb9\xbb\x2c\x5f\x6a\xf2\x52\x38\x93\x2c\xec\x69\x4e\x2f\x03\x21\x7b\x72\x67\x1c\xbb\x75\xa7\x51\xd9\x3c\x10\xc3\xf3\xf6\xa5\x35\x77\x48\xec\x3c\x94\x61\xd3\x1e\xa5\x4a\x59\x89\x44\xe5\x1f\xaa\x1f\x03\x2f\x62\xc8\x93\xfe\x14\x6e\x28\xe3\x16\x3d\x56\x43\x4c\xd6\x91\x87\x98\x46\x18\x34\x99\x3c\xc5\x7d\x92\x25\x29\x3f\xb5\x39\x15\x1f\x65\xcc\xf5\x56\x76\x3a\xe6\x99\xaa\xaa\x9a\xbc\x4d\xb7\xf8\x13\x31\xc7\x4e\x27\xc4\x40\x7e\x90\x67\x42\x5f\xc8\x27\x0c\x9e\x1b\x2a\x72\xf3\x0e\x0d\xe4\x66\x3c\xa5\x70\x1f\x56\xec\x67\xc1\xdc\xd6\xbc\xe5\x3a\x6d\xf6\x97\xf0\x39\x23\xa6\x84\xfa\x34\xda\xa8\x3a\x36\x3b\x0e\x04\x1c\xdc\x6f\x7b\xd2\x02\x27\x73\x0b\xf6\x21\xc2\xc2\x27\x91\x19\x17\x4e\x19\xb8\x3f\x65\x0a\xd6\xab\xb2\x62\x60\xf5\x6b\x4a\xb4\x14\x86\xc0\x84\x03\x4b\x0e\x9c\xd3\xf1\xb7\x71\xc0\x95\x30\xd7\x85\x80\x81\xd8\x7f\x14\x11\x96\xec\x77\x4c\xc9\x2f\xc4\x48\xda\x06\x59\xff\x44\x2b\x5a\xf3\xf8\x68\x6c\x4a\x7f\x22\x0f\xbb\x28\xa3\x31\x1e\xec\xb6\x56\x17\x29\x7e\x8c\x56\x95\x6e\xe1\x63\xa2\xbc\x2c\xcf\xa5\x38\xb2\xa7\x84\x14\x58\x8f\x68\xa1\x4a\xf8\x2c\x1d\x24\xeb\x44\xee\x95\x9f\x7c\x51\xc6\x36\xc4\xf1\xe9\x1e\x02\x0f\x3b\x28\x54\x7f\x14\x1b\xc1\x8e\xcf\x24\xe7\xb2\xca\x08\x29\x98\x89\x38\x54\x3f\x99\x8c\x39\x99\x64\xb3\x08\x33\x09\x4d\xa4\xa1\x89\x46\x4c\x76\x46\x6f\x72\x7a\x4c\x53\x5e\x32\x1e\x0d\x46\xd3\x00\xc8\xd5\xe2\xee\xe7\x7f\x2d\x47\xb2\x63\xff\x5c\x7e\xff\x96\xc0\x16\xb1\xea\xa1\x09\xbd\x7d\xe5\xc0\x08\xf2\x6e\xa8\x13\xf9\x40\x38\xb2\x8b\x73\x46\xcc\x33\x4e\xa6\x58\xf0\x61\xf6\xdb\xbf\xfb\x82\x28\xb3\xb2\x41\x66\x22\x39\x09\x7d\xff\x51\xec\x52\x8d\xdf\x8a\x5d\xfc\x6e\x9c\x3d\xbb\x86\xf6\x77\xf6\x91\x52\xaf\x3a\xf3\x42\x42\xc6\xf8\xd3\x7b\xde\xf7\xa7\x11\x7d\x95\x86\xe6\x4b\xca\x96\xc8\x6e\xdf\x28\xb8\x45\x33\xcd\xe1\xe7\x4e\x27\xc8\x80\xb5\xe5\xae\xa6\x22\xbc\x9c\x0b\x0b\x80\xb8\x40\x1c\x0c\xfb\xc3\xec\x3b\x5b\xc6\x2b\xf1\x2a\x93\x87\x04\xcd\x76\xdb\x05\x24\xf2\x47\x56\x21\x99\xde\xc0\x2b\xf2\x03\x0f\xc6\x9b\x10\x5e\xc5\xeb\x7e\x78\x0b\x5f\xd1\x2b\xf6\x13\x1a\x9b\x1e\x6d\xf1\x15\x26\x6d\x5f\xe5\xdb\xc4\x08\x2c\x92\x6f\x42\xed\xe9\x33\xec\xb6\x07\x4a\x47\x56\xba\x5c\xdd\x7c\xf4\x9a\x9b\xfd\xce\x82\xa8\xf8\xf3\x2f\x40\xd6\x7c\xa3\xff\x7d\x75\x31\xe9\x8d\xc9\x24\x99\x7f\xed\xd2\x68\xe5\x41\xf1\x39\x89\xfc\x55\xfa\x35\x26\x46\x04\x60\x62\x46\x00\x26\xe6\x04\x14\xaa\xa8\x43\x40\xe4\x71\x1c\x78\xf0\xfd\x49\x73\x00\xa1\x7c\x12\x83\x49\x22\xcf\xcb\x8a\xfa\x9b\x4b\x8a\x12\xd8\xf7\xd6\x15\x20\x2e\x16\x9e\x3c\x06\x9c\xfc\xb6\x50\x06\x2c\xf6\xbb\xf7\x04\x07\x8a\x17\x2b\x5d\x93\xd2\x8f\x8e\x73\xcc\x3d\x30\x49\x63\xcd\x67\x11\xc1\x7f\x45\x70\x57\x9b\xa2\xa4\x5f\x95\xac\x08\xf8\x2b\x70\x5d\x70\x3f\x64\xa7\x26\x8d\x31\x98\xf0\x0f\x94\x7d\x48\xa7\xec\xfe\x3e\xbe\xfb\xe4\xa3\x40\x54\xde\x1b\x66\x07\x54\x77\xa8\x24\x43\x2b\x9f\x8a\xd9\xf4\xbe\xcc\x42\xd8\x1f\xa2\x24\x86\xfd\xe1\x49\x19\xc4\x1e\xfc\xd4\x22\xd9\x38\x06\x3d\xc7\xa4\x1e\xf9\xc0\x30\xcb\x6e\x55\x3f\xd0\x4b\x60\x5b\xec\x88\xe7\x7b\x80\x65\x15\xc6\xd5\xf5\x6b\xf5\xac\x4d\x6c\xa3\xe4\xd1\xa3\x80\x32\x6e\xd3\x30\xbe\x98\x4e\xeb\x7d\x1c\xcb\x12\x13\x0f\x03\xe1\xd7\x94\x3c\xe1\xb5\x2c\xd3\xf7\x31\x22\xae\x57\xed\x77\xbf\xda\xf1\xd2\x4e\x56\xd0\x19\x26\x9c\x1d\xde\xd4\x35\x44\x36\xcd\x19\xbf\x59\x84\xd3\xe4\x4f\x8d\x7b\xe3\x54\x01\x95\x27\x49\x52\x1f\x29\x42\xf7\xb0\xc5\xf0\x62\x99\x6a\x28\xab\x9a\x12\xe4\x90\x2a\x97\x5e\xa1\x32\x2c\x6a\x13\xa6\x40\x19\x98\xed\x51\xf1\xb6\xcf\xa2\xb6\x9c\xa6\xf1\xd9\xdb\xa2\xbb\x03\xa8\xd1\x5c\xab\x3e\xee\x7a\xcc\x55\x92\x90\x2d\x5d\x2a\x53\x94\xcc\xc9\xb8\xfe\xae\xeb\xe6\xda\x19\x32\x72\xf1\x5c\xc7\xe4\x07\x5e\x41\x27\xbd\xb7\x30\x24\x03\xce\xb5\x74\xa3\xaf\xf8\x43\x2c\xa8\x6b\xc7\xf8\x5c\x55\xa7\xaa\xaa\x1b\x7d\x3d\x9e\xeb\xeb\xc6\xab\xaf\xd3\xd8\x87\x0e\xa0\x04\x46\xcb\x27\x96\x70\x78\xc4\x72\xc0\x0e\x3f\x72\x0f\x35\x81\x3a\x8b\x68\xa8\xc2\xc0\x51\x6b\x6a\x64\x84\x54\x0b\x6b\xe2\xf7\xe5\x4a\xe9\xfd\xd1\xa7\xaf\x96\x22\x6d\x5b\x36\xa9\xf1\x4b\x26\xdb\xe5\xdc\xaf\x6e\x72\x42\xd3\xb2\x97\x8a\x49\xcd\x35\x32\x45\xd9\xa4\xae\x0f\x7a\xb0\xb5\x93\x7a\x7a\x78\xc4\xbb\xc7\x91\x56\x51\x76\xdb\xaf\xc3\x2c\xa5\xec\x36\xfb\x7b\xa8\xa7\xec\x40\xea\x5c\x54\xa9\xc7\xa6\xe1\x2b\x2b\x3b\xb5\x65\xa2\xf2\x4a\x2d\x05\x99\x36\x94\x33\x72\xa1\x65\x87\x7d\x99\xbc\xda\x32\xc1\x67\xd4\x92\xcb\x64\x8a\xa3\xa9\xbb\x2c\x38\xd2\x2f\x0b\x28\xe7\xec\x71\x54\x60\x4a\x71\x9f\xb8\x0c\xb3\x0b\x87\x71\x6b\x31\x15\xeb\x62\xf2\x82\x4c\x6d\x3c\xc6\xaa\xca\x2c\x10\xd8\x57\x69\x66\x17\x0b\xf6\x5c\x9f\xa9\xd0\xd3\xc3\x2e\xd2\xac\x4a\x75\xaa\x4a\xcd\xea\xac\x47\x53\xae\xd9\xb6\x81\x1d\x50\xcd\xa6\x42\x11\xcf\x85\x9b\xe7\xc2\x4d\x2d\x73\xba\xd7\xea\x4d\xed\x7d\xee\x30\x4b\x38\xf5\xf7\xa8\xe0\x90\x91\x3b\x17\x73\x9e\x8b\x39\x75\x77\x94\xe1\x0f\x47\x43\x94\x75\x6a\x78\x63\x03\xc0\x3f\x17\x78\x76\xd4\x45\x1d\x66\x95\xa7\x42\x78\x96\x61\x85\x46\xf1\xc1\x45\x66\x9e\x2e\xfe\x8c\x10\xe1\xd5\x97\x3a\x0a\xea\xb4\xca\x17\xae\x16\x77\x9f\xb3\x14\xe8\x30\xd5\x5d\x2d\xc5\x88\x82\x13\x45\x4e\xa4\x5f\xdd\x45\x8c\x75\x0a\xe9\x13\x65\x37\x38\x74\xe8\x16\xd8\x2e\x93\x2b\xb0\x2b\xd7\x65\x10\x86\x1f\x77\xa9\xc6\xdc\xdd\xdc\x1b\xe5\x2f\x5a\x26\x5f\xaa\x26\x90\x99\x6d\x9b\x64\xa3\x25\xe1\x3a\x8f\x47\xa8\x7b\xae\x98\x95\xa6\x14\x65\x28\xb9\x0e\xb5\x37\x72\xd2\xd0\x42\xab\x8a\xa3\x18\xa1\xa1\xb8\x9b\xd3\xa2\x5f\x93\x22\xcb\xd9\x8e\xc0\x5d\x8d\x56\x59\xed\x74\xdd\x97\x9c\x9a\x61\x8a\x74\xd4\xc5\x13\xe6\xd6\x21\xdf\x40\x54\x29\xaf\x9e\xf5\x3b\xea\x4e\xc0\x49\x42\x2a\xd5\xb0\xdf\x51\xb8\x51\x95\x0c\xac\x0c\xa7\x56\x57\x20\x28\x72\x64\x65\x54\x2b\x1c\x49\x19\x9a\x61\xf1\x68\xb6\x5f\x48\xb5\xc0\x7e\xa9\x59\xed\x0a\x95\x94\xcf\x30\xeb\x34\xd7\x67\xad\x2a\x8a\x0c\xeb\x32\x2a\x43\xae\xd1\x0a\x5f\xfb\x2c\xd3\x72\xc5\xc0\x30\x3b\xf2\x11\x6c\x87\x66\x57\x3c\x72\x10\xad\xb4\x0d\x29\xdd\x9f\xc5\x8c\x96\x92\x3d\xb0\x7a\x65\xba\x4a\x32\xf2\x9f\x81\x00\xcb\x73\x64\x1a\x91\xa8\xe9\x0a\x9d\xe7\x32\xc6\xe4\x08\x14\xe4\x59\x5b\xc3\x1b\xf0\x80\x43\xe9\x3d\x1c\xa3\xe5\xe6\xb2\xdd\x7d\x44\x4c\x37\x4c\xe4\xc0\x02\x18\xa6\xae\xc9\x73\x1f\x2d\x6b\x9b\xb2\x60\x83\xc8\x4d\x9a\x90\xe0\x8a\x28\x47\xc0\xa0\x5a\x2d\x65\xaf\x5c\x8b\x0a\xa8\x34\xd0\x84\xd6\xb1\x0a\x29\x9f\xed\x79\x1b\x6a\x29\x56\x45\x56\x5f\x8c\xf3\x62\x08\x72\x7d\x1c\x8a\x9f\x1a\x45\xd3\x23\x83\x48\x0b\xad\x65\x70\x18\xac\x71\x7c\x71\x67\x18\xb4\xac\xc1\x49\x50\x6c\x2d\x33\xd7\x47\xcd\x1a\x8c\x14\xa5\x81\xf8\xd5\x03\x90\x0c\xad\x20\xb4\x42\x22\x08\xc7\x9f\x52\x8c\xbd\xac\x8e\x8d\xf8\x06\x08\xc7\x4e\x5f\x26\xda\xc3\x91\x10\x14\xf1\x0d\x65\xf8\xef\x01\x70\xb2\x03\x23\x45\x89\x8a\xd2\x23\x4c\xd6\x76\x98\x18\x8d\xbe\xec\x8f\xc0\x65\x53\xd6\x2b\xf1\xfa\x9e\x05\xf6\x06\xe3\x9a\x78\xeb\x0f\xbe\xcc\x1e\xa3\x2f\x8d\x76\x80\x71\xfc\x24\xb4\x0a\x7a\x98\x1d\x6b\x28\x4d\x72\x1c\x4a\x99\x8b\x49\x4f\xcd\xb4\x86\xd2\x44\xc8\xcd\xc2\x3b\x46\x70\x24\xac\xb6\x03\xd4\x44\x08\xb6\x40\x78\x0f\x61\x59\x8c\x97\x20\x91\xef\x32\xb6\x10\x9e\x3c\xfa\xe2\x50\xc2\x19\xf5\x2e\x8a\x88\x6e\x4f\x1e\xf7\x07\xda\x44\x14\xfb\x68\x0d\x41\xec\x58\xf5\x45\x2f\x2e\xe4\x26\x68\x40\x82\x09\xf0\x17\xca\x9e\x31\x59\xdb\x2b\x84\x25\x8c\x26\xa7\x08\x75\xa1\x07\x1a\xa6\xa3\x65\xec\x30\x85\xd1\x24\x22\x91\xb4\xed\x68\xb6\x42\xce\xc5\x40\xdb\x6b\x4f\x58\x12\xf6\xf4\x84\xd8\x24\x57\x54\x2e\xba\x91\xd7\x4b\xfd\x2c\x61\x48\xc8\xb3\x84\x24\x21\x4b\xf1\x5c\x9c\x3e\x4d\x16\x00\x64\x04\x59\x80\x91\xde\x33\x6d\x39\x4e\x26\x37\xdb\x7e\xfe\xa3\xa3\xec\xdc\x26\x1f\xd2\x38\xd0\x77\x46\x2f\xb5\x03\xb6\xb5\x88\x62\x36\xce\x3a\x12\xf1\xa5\x7c\x15\xa1\x89\xb8\x2f\x9c\xac\xdb\xd7\x80\x41\x38\x64\x26\xa9\x32\xeb\x7d\x42\x9e\xea\xfd\xc5\x18\x85\x78\x40\xaf\x17\x80\xbb\x02\x01\x45\xa9\x42\xe9\x95\xff\x7e\xc4\x34\xb8\x19\xbf\x5f\x5d\x47\xb5\xf3\xbd\x6b\xf1\x5f\xcd\xe7\xae\xe7\x33\x31\x1d\x4a\x45\xd9\xd4\x32\xe4\x45\xd0\x27\xfc\x99\xa0\x92\xcf\x61\xaf\x75\xd9\xd5\x75\x59\x3f\x15\x8e\x49\xa4\x6a\x75\xe0\x23\x4c\x30\x59\x8b\xb2\x9a\x6b\xd9\x73\x72\xca\xd0\x61\x7a\x0f\xa1\x65\x09\x86\xe0\x3d\x7d\xc1\xe4\xb9\x7f\x96\xbc\xa0\xf3\x2b\x22\x68\x0d\x6e\x62\x6a\x6e\x09\x67\x3b\xe3\x60\x5f\x52\x07\xf3\xa0\x2a\xa2\x79\x2a\x59\x31\xfb\xf5\x98\xdb\xc2\x78\xbd\x09\x9c\xe5\x0a\x94\x48\x5e\x85\xea\x34\x8f\x0f\xb6\xb1\x1b\x3b\x8c\x66\x51\xec\xa2\xf8\x10\x71\xf8\x10\xe3\x36\xb7\x2c\x55\x28\x35\x7c\x69\x8a\x8f\x10\x9a\x54\xca\xf6\x7c\xa0\x5c\x14\x01\x73\x50\x67\x0b\x1d\x06\x28\x0b\x46\x87\x1c\xf9\xc1\x30\xa1\x7a\x57\x6c\xa9\x98\x92\xcf\xb6\x01\xe2\x0c\xc0\xc0\x78\x3d\x61\x82\x3c\xfc\x37\x30\x13\x7b\xa5\x6d\x26\xd7\x49\xf2\x41\xfd\x4a\xeb\xda\x34\x3b\xe1\xf5\xde\xa1\xb2\xa5\xe7\x16\x77\xee\x07\xd8\x6a\x25\x26\x48\xb2\xc9\x5a\x16\x73\xd1\x17\x02\x2c\x2f\x33\x1a\x0a\xe5\xef\x15\xa8\x7a\x42\x2e\x6f\x98\x11\x76\xf5\x35\xa1\xe7\xd6\x30\x8f\x67\x1b\x70\xcb\xa8\x11\x6f\xba\x5d\xac\x3c\xea\x3c\xc7\x30\x6e\xd2\x75\x29\xcf\xcb\xa4\xc1\x02\x0f\x98\xfc\x77\xf3\x32\x0a\x25\x1f\x2a\x0f\xe2\x16\xd8\xe7\x95\x05\x69\x0d\x82\x18\x6f\xed\x50\x2c\x84\x7c\xed\x3d\xf9\x45\x3d\x4b\x55\xe5\xb9\x8e\x8e\x0c\xac\x06\xea\xc4\xb6\xaa\x7d\x62\xfa\x5b\x7b\x0e\xbe\x5b\x42\x25\x68\xf5\xb1\xd6\xd2\x51\x36\x73\x68\xd7\x65\x87\xba\xa0\xd9\x3f\xd0\x05\x8e\xb0\xd7\xb7\x98\x20\x46\xf3\x26\x05\xd5\xde\xc0\x49\x9d\xdf\x1e\xba\xb9\x93\x5d\xe2\x7b\xb0\xbc\xe7\x32\x4b\x89\x5b\x56\x1f\x24\xe3\xaf\x51\x14\x4a\x8c\x59\xec\xbd\x1a\x73\x58\xc9\x8f\xb7\x7e\x0a\x7a\x53\x28\x51\xbd\x4a\x2d\x0a\x07\xdb\xdd\xca\x0c\x19\xb9\xb2\x8d\x01\x67\xbb\xab\x27\x0e\x4c\xe1\xd2\x29\x56\xd3\xc0\xb6\x6c\x04\x8f\xfc\x57\x1c\x97\xd8\x4a\x4f\xd9\x29\x76\xa6\x42\x62\x11\x11\x28\x5d\xdc\xa3\x97\xdb\x2c\xc8\x6f\x5c\x40\x92\xce\x3d\x58\x01\x50\x89\x4e\xab\x7a\x81\xd1\xc6\xdb\x16\x1b\x18\x22\x64\x07\xcb\xb4\xcc\x40\x13\x29\x3b\x18\xe6\x05\x06\xda\xe8\x0c\xc7\x9d\x20\xd4\x18\x68\x33\xc6\x7a\x32\xe3\x8a\x02\x4d\xb4\x2d\x81\x98\xd6\x12\xe8\x63\x63\x01\x43\x33\x89\xaf\x8d\x83\xfe\xd0\xcb\x9e\x53\x77\x17\x0f\xe8\x21\xad\x3b\xa8\xb3\x6c\x40\x89\xa8\x69\xcd\x80\x1e\xde\x76\x20\x0c\xab\x05\x34\x51\xb1\x02\x61\x52\x27\xa0\x04\x62\x54\x24\x60\x80\x8a\x22\xc3\xaf\xc7\x0f\xd3\xc1\x7a\xb5\x01\x06\xc3\xf5\x72\xf8\x26\x4c\xed\x09\xd1\xac\x24\xc0\x04\x31\x8d\x7a\x00\x13\x70\x2d\x89\x7c\x3d\xd9\xdb\x00\xd0\x2c\x03\xd0\x44\xc0\x68\xa8\x6e\x01\x80\x01\xee\xf5\xec\xbf\xc1\x50\x8d\xe4\xba\x1e\x13\xfa\x00\xb2\x4b\xfa\x1b\x50\xd9\x92\x63\xd7\xa3\xce\x06\x80\x59\xae\xdf\x84\x1a\x45\x82\x5d\x93\x14\xd3\xd1\x06\x29\xfe\x2e\x22\xda\x02\x0f\xd2\x23\x9c\x49\x50\x30\xe2\xd8\xbb\xc0\x84\x87\x9c\x5d\xdc\x11\x9e\xdd\xaa\xae\x1f\x9b\x4b\xd7\xee\x0d\x0e\xb0\x29\x31\x17\x77\xe4\x89\x36\x4f\xad\xab\x08\x7b\xee\x0d\xe2\x8a\xe4\x0f\xf5\x03\xec\x29\xb2\x72\x6b\xcc\xaf\xa9\xef\x63\xae\xfa\xf5\x81\x41\xdc\xea\x0d\x54\x1f\xb4\xde\xbc\xa2\x6d\xbf\xfa\xe8\x5f\x8a\x64\xb3\x8f\x89\xe2\x97\xc0\x43\x5c\xb0\xb3\xfb\x54\x9d\x40\xcf\x60\x55\x50\x2d\x93\x5d\x23\x72\x5e\x62\x66\x19\xff\x12\x1b\x4b\x48\xb4\x44\x35\xc5\x89\xfd\x03\x5a\xaf\x19\xac\x11\xa7\xd5\x6b\xd7\x95\xb3\x57\x72\x7f\x6a\x59\x5c\xea\x3e\xee\xe7\x2a\xec\xe8\x36\x7b\x95\xc2\x72\x0e\x55\xc3\x42\xe3\xe6\xf6\xad\x67\xe7\x62\xc2\xb6\xc0\xa7\x1d\x09\xe7\xe7\x23\x86\x5a\x68\x13\xbc\x12\x61\x87\xd8\x29\x3e\x06\xa1\xb9\x5e\xba\x2e\xa1\xf6\x30\x2d\x3d\x3a\x6e\xa8\x3b\xd8\x94\x8a\xbd\xd4\x91\xf7\xf8\x97\x45\xfa\xa2\xd5\x57\x49\x43\x2c\x75\x28\x1d\x93\x10\x9c\x88\xc1\xf2\x19\x07\x0f\x5f\x96\x3f\x81\xe1\xa7\x9d\xe2\x9e\xb9\x5e\x4f\x10\x13\x0e\xca\x1a\x81\x68\x74\xa7\x5f\x98\x3c\xde\x25\x2b\x50\xac\xf3\xaa\x09\x79\x70\x63\xa0\xca\x31\x5a\xf4\x6a\xef\x6b\xd7\x3b\x0a\x16\x6a\x7d\x98\x52\x33\xf9\xd8\xa2\xa3\x3e\x0a\xba\xab\x1e\x52\x63\xab\x55\xf6\xf0\x36\x0c\xf7\xc7\x6b\x12\x64\xd0\x0b\x68\x00\x42\x92\xae\x39\xef\xd4\x83\xab\x13\x3f\x9a\x1b\xd7\x98\x68\xcf\xbe\x5c\xf3\x40\xd9\x83\x98\xb3\x57\x37\xe8\x0a\x3c\x2c\xd7\xae\x8e\xdd\xd9\xbf\x1b\x6d\x1d\x9d\x3d\xbd\x1e\x1a\x7a\xea\xee\x9e\x62\x07\x99\xd8\xe7\x53\x9b\xfe\xf7\xe2\xf8\x8d\xdd\x22\xd2\xde\xfb\x7b\x7b\xfb\x3f\xff\x7f\x00\x0b\x0c\x65\xaa\xc9\xda\x03\x00"),
+		},
+	}
+	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/v1.20.json"].(os.FileInfo),
+	}
+
+	return fs
+}()
+
+type vfsgen۰FS map[string]interface{}
+
+func (fs vfsgen۰FS) Open(path string) (http.File, error) {
+	path = pathpkg.Clean("/" + path)
+	f, ok := fs[path]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+
+	switch f := f.(type) {
+	case *vfsgen۰CompressedFileInfo:
+		gr, err := gzip.NewReader(bytes.NewReader(f.compressedContent))
+		if err != nil {
+			// This should never happen because we generate the gzip bytes such that they are always valid.
+			panic("unexpected error reading own gzip compressed bytes: " + err.Error())
+		}
+		return &vfsgen۰CompressedFile{
+			vfsgen۰CompressedFileInfo: f,
+			gr:                        gr,
+		}, nil
+	case *vfsgen۰DirInfo:
+		return &vfsgen۰Dir{
+			vfsgen۰DirInfo: f,
+		}, nil
+	default:
+		// This should never happen because we generate only the above types.
+		panic(fmt.Sprintf("unexpected type %T", f))
+	}
+}
+
+// vfsgen۰CompressedFileInfo is a static definition of a gzip compressed file.
+type vfsgen۰CompressedFileInfo struct {
+	name              string
+	modTime           time.Time
+	compressedContent []byte
+	uncompressedSize  int64
+}
+
+func (f *vfsgen۰CompressedFileInfo) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, fmt.Errorf("cannot Readdir from file %s", f.name)
+}
+func (f *vfsgen۰CompressedFileInfo) Stat() (os.FileInfo, error) { return f, nil }
+
+func (f *vfsgen۰CompressedFileInfo) GzipBytes() []byte {
+	return f.compressedContent
+}
+
+func (f *vfsgen۰CompressedFileInfo) Name() string       { return f.name }
+func (f *vfsgen۰CompressedFileInfo) Size() int64        { return f.uncompressedSize }
+func (f *vfsgen۰CompressedFileInfo) Mode() os.FileMode  { return 0444 }
+func (f *vfsgen۰CompressedFileInfo) ModTime() time.Time { return f.modTime }
+func (f *vfsgen۰CompressedFileInfo) IsDir() bool        { return false }
+func (f *vfsgen۰CompressedFileInfo) Sys() interface{}   { return nil }
+
+// vfsgen۰CompressedFile is an opened compressedFile instance.
+type vfsgen۰CompressedFile struct {
+	*vfsgen۰CompressedFileInfo
+	gr      *gzip.Reader
+	grPos   int64 // Actual gr uncompressed position.
+	seekPos int64 // Seek uncompressed position.
+}
+
+func (f *vfsgen۰CompressedFile) Read(p []byte) (n int, err error) {
+	if f.grPos > f.seekPos {
+		// Rewind to beginning.
+		err = f.gr.Reset(bytes.NewReader(f.compressedContent))
+		if err != nil {
+			return 0, err
+		}
+		f.grPos = 0
+	}
+	if f.grPos < f.seekPos {
+		// Fast-forward.
+		_, err = io.CopyN(ioutil.Discard, f.gr, f.seekPos-f.grPos)
+		if err != nil {
+			return 0, err
+		}
+		f.grPos = f.seekPos
+	}
+	n, err = f.gr.Read(p)
+	f.grPos += int64(n)
+	f.seekPos = f.grPos
+	return n, err
+}
+func (f *vfsgen۰CompressedFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		f.seekPos = 0 + offset
+	case io.SeekCurrent:
+		f.seekPos += offset
+	case io.SeekEnd:
+		f.seekPos = f.uncompressedSize + offset
+	default:
+		panic(fmt.Errorf("invalid whence value: %v", whence))
+	}
+	return f.seekPos, nil
+}
+func (f *vfsgen۰CompressedFile) Close() error {
+	return f.gr.Close()
+}
+
+// vfsgen۰DirInfo is a static definition of a directory.
+type vfsgen۰DirInfo struct {
+	name    string
+	modTime time.Time
+	entries []os.FileInfo
+}
+
+func (d *vfsgen۰DirInfo) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("cannot Read from directory %s", d.name)
+}
+func (d *vfsgen۰DirInfo) Close() error               { return nil }
+func (d *vfsgen۰DirInfo) Stat() (os.FileInfo, error) { return d, nil }
+
+func (d *vfsgen۰DirInfo) Name() string       { return d.name }
+func (d *vfsgen۰DirInfo) Size() int64        { return 0 }
+func (d *vfsgen۰DirInfo) Mode() os.FileMode  { return 0755 | os.ModeDir }
+func (d *vfsgen۰DirInfo) ModTime() time.Time { return d.modTime }
+func (d *vfsgen۰DirInfo) IsDir() bool        { return true }
+func (d *vfsgen۰DirInfo) Sys() interface{}   { return nil }
+
+// vfsgen۰Dir is an opened dir instance.
+type vfsgen۰Dir struct {
+	*vfsgen۰DirInfo
+	pos int // Position within entries for Seek and Readdir.
+}
+
+func (d *vfsgen۰Dir) Seek(offset int64, whence int) (int64, error) {
+	if offset == 0 && whence == io.SeekStart {
+		d.pos = 0
+		return 0, nil
+	}
+	return 0, fmt.Errorf("unsupported Seek in directory %s", d.name)
+}
+
+func (d *vfsgen۰Dir) Readdir(count int) ([]os.FileInfo, error) {
+	if d.pos >= len(d.entries) && count > 0 {
+		return nil, io.EOF
+	}
+	if count <= 0 || count > len(d.entries)-d.pos {
+		count = len(d.entries) - d.pos
+	}
+	e := d.entries[d.pos : d.pos+count]
+	d.pos += count
+	return e, nil
+}
@@ -0,0 +1,44 @@
+// +build ignore
+
+// strip_swagger reads the Kubernetes swagger.json on stdin and writes its
+// definitions, without descriptions, on stdout.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+)
+
+func strip(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		// Only drop documentation, not properties named "description".
+		if _, ok := v["description"].(string); ok {
+			delete(v, "description")
+		}
+		for k, value := range v {
+			v[k] = strip(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = strip(value)
+		}
+	}
+	return v
+}
+
+func main() {
+	var swagger struct {
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	if err := json.NewDecoder(os.Stdin).Decode(&swagger); err != nil {
+		log.Fatalln(err)
+	}
+	for name, definition := range swagger.Definitions {
+		swagger.Definitions[name] = strip(definition)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(&swagger); err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"encoding/json"
+)
+
+// Schema is the subset of OpenAPI needed to validate Kubernetes objects. It
+// covers the OpenAPI v2 definitions of the Kubernetes API as well as the
+// OpenAPI v3 schemas of CustomResourceDefinitions.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *SchemaOrBool      `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+
+	IntOrString           bool `json:"x-kubernetes-int-or-string,omitempty"`
+	PreserveUnknownFields bool `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+	EmbeddedResource      bool `json:"x-kubernetes-embedded-resource,omitempty"`
+
+	GroupVersionKinds []GroupVersionKind `json:"x-kubernetes-group-version-kind,omitempty"`
+}
+
+// GroupVersionKind identifies the type of object a definition describes.
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// SchemaOrBool is the value of additionalProperties: either a schema the
+// additional properties need to validate, or whether they are allowed.
+type SchemaOrBool struct {
+	Allows bool
+	Schema *Schema
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SchemaOrBool) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.Allows); err == nil {
+		return nil
+	}
+	s.Allows = true
+	s.Schema = &Schema{}
+	return json.Unmarshal(data, s.Schema)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *SchemaOrBool) MarshalJSON() ([]byte, error) {
+	if s.Schema != nil {
+		return json.Marshal(s.Schema)
+	}
+	return json.Marshal(s.Allows)
+}
@@ -0,0 +1,451 @@
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema/openapi"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// FieldError is a schema violation.
+type FieldError struct {
+	// Field is the path to the invalid field, eg.
+	// "spec.template.spec.containers[0].image".
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Validator validates Kubernetes objects against the OpenAPI schemas of the
+// Kubernetes API and of CustomResourceDefinitions.
+type Validator struct {
+	// KubernetesVersion is the version of the Kubernetes API schemas, eg.
+	// "v1.20".
+	KubernetesVersion string
+
+	definitions map[string]*Schema
+	kinds       map[GroupVersionKind]*Schema
+	// groups are the API groups served by Kubernetes. Objects of these groups
+	// need a schema to be valid.
+	groups map[string]bool
+}
+
+var specVersion = regexp.MustCompile(`^v1\.(\d+)\.json$`)
+
+// bundledVersions returns the minor versions of the bundled Kubernetes API
+// schemas, in ascending order.
+func bundledVersions() ([]int, error) {
+	root, err := openapi.Specs.Open("/")
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	files, err := root.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+	var minors []int
+	for _, f := range files {
+		m := specVersion.FindStringSubmatch(f.Name())
+		if m == nil {
+			continue
+		}
+		minor, _ := strconv.Atoi(m[1])
+		minors = append(minors, minor)
+	}
+	sort.Ints(minors)
+	return minors, nil
+}
+
+var versionMinor = regexp.MustCompile(`^v?1\.(\d+)`)
+
+// New creates a validator for the Kubernetes API of the given version, eg.
+// "1.19.7". It uses the closest bundled schemas, the most recent ones if
+// kubernetesVersion is empty, and warns when they are the schemas of another
+// minor version.
+func New(kubernetesVersion string) (*Validator, error) {
+	minors, err := bundledVersions()
+	if err != nil {
+		return nil, err
+	}
+	if len(minors) == 0 {
+		return nil, errors.New("no bundled Kubernetes API schemas")
+	}
+	minor := minors[len(minors)-1]
+	if kubernetesVersion != "" {
+		m := versionMinor.FindStringSubmatch(kubernetesVersion)
+		if m == nil {
+			return nil, fmt.Errorf("invalid Kubernetes version %q", kubernetesVersion)
+		}
+		wanted, _ := strconv.Atoi(m[1])
+		for _, candidate := range minors {
+			if abs(candidate-wanted) < abs(minor-wanted) {
+				minor = candidate
+			}
+		}
+		if minor != wanted {
+			log.Warnf("no Kubernetes v1.%d API schemas bundled, validating against the v1.%d ones", wanted, minor)
+		}
+	}
+
+	v := &Validator{
+		KubernetesVersion: fmt.Sprintf("v1.%d", minor),
+		definitions:       make(map[string]*Schema),
+		kinds:             make(map[GroupVersionKind]*Schema),
+		groups:            make(map[string]bool),
+	}
+	f, err := openapi.Specs.Open(fmt.Sprintf("/%s.json", v.KubernetesVersion))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var spec struct {
+		Definitions map[string]*Schema `json:"definitions"`
+	}
+	if err := json.NewDecoder(f).Decode(&spec); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s API schemas", v.KubernetesVersion)
+	}
+	v.definitions = spec.Definitions
+	for _, definition := range v.definitions {
+		for _, gvk := range definition.GroupVersionKinds {
+			v.kinds[gvk] = definition
+			v.groups[gvk.Group] = true
+		}
+	}
+	return v, nil
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// Copy returns a copy of the validator, CRDs added to the copy aren't added to
+// v.
+func (v *Validator) Copy() *Validator {
+	c := *v
+	c.kinds = make(map[GroupVersionKind]*Schema, len(v.kinds))
+	for gvk, s := range v.kinds {
+		c.kinds[gvk] = s
+	}
+	return &c
+}
+
+// IsCRD returns whether o is a CustomResourceDefinition.
+func IsCRD(o map[string]interface{}) bool {
+	kind, _ := o["kind"].(string)
+	apiVersion, _ := o["apiVersion"].(string)
+	return kind == "CustomResourceDefinition" && strings.HasPrefix(apiVersion, "apiextensions.k8s.io/")
+}
+
+// AddCRD makes the validator validate the custom resources defined by crd.
+func (v *Validator) AddCRD(crd map[string]interface{}) error {
+	data, err := json.Marshal(crd)
+	if err != nil {
+		return err
+	}
+	var def struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Spec struct {
+			Group string `json:"group"`
+			Names struct {
+				Kind string `json:"kind"`
+			} `json:"names"`
+			// apiextensions.k8s.io/v1beta1
+			Version    string `json:"version"`
+			Validation *struct {
+				OpenAPIV3Schema *Schema `json:"openAPIV3Schema"`
+			} `json:"validation"`
+			// apiextensions.k8s.io/v1 and v1beta1
+			Versions []struct {
+				Name   string `json:"name"`
+				Schema *struct {
+					OpenAPIV3Schema *Schema `json:"openAPIV3Schema"`
+				} `json:"schema"`
+			} `json:"versions"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &def); err != nil {
+		return errors.Wrapf(err, "invalid CustomResourceDefinition %s", def.Metadata.Name)
+	}
+
+	var common *Schema
+	if def.Spec.Validation != nil {
+		common = def.Spec.Validation.OpenAPIV3Schema
+	}
+	versions := def.Spec.Versions
+	if len(versions) == 0 && def.Spec.Version != "" {
+		v.kinds[GroupVersionKind{Group: def.Spec.Group, Version: def.Spec.Version, Kind: def.Spec.Names.Kind}] = orAny(common)
+	}
+	for _, version := range versions {
+		s := common
+		if version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+			s = version.Schema.OpenAPIV3Schema
+		}
+		v.kinds[GroupVersionKind{Group: def.Spec.Group, Version: version.Name, Kind: def.Spec.Names.Kind}] = orAny(s)
+	}
+	return nil
+}
+
+// AddCRDs adds the CustomResourceDefinitions found in a YAML or JSON
+// manifest.
+func (v *Validator) AddCRDs(manifest []byte) error {
+	reader := kyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var o map[string]interface{}
+		if err := yaml.Unmarshal(doc, &o); err != nil {
+			return err
+		}
+		if !IsCRD(o) {
+			continue
+		}
+		if err := v.AddCRD(o); err != nil {
+			return err
+		}
+	}
+}
+
+// anySchema validates any value.
+var anySchema = &Schema{PreserveUnknownFields: true}
+
+func orAny(s *Schema) *Schema {
+	if s == nil {
+		return anySchema
+	}
+	return s
+}
+
+func parseAPIVersion(apiVersion string) (group, version string) {
+	parts := strings.SplitN(apiVersion, "/", 2)
+	if len(parts) == 1 {
+		return "", parts[0]
+	}
+	return parts[0], parts[1]
+}
+
+// Validate validates a Kubernetes object. Objects of API groups not served by
+// Kubernetes and without a CRD aren't validated, their definition being
+// unknown.
+func (v *Validator) Validate(o map[string]interface{}) []FieldError {
+	apiVersion, _ := o["apiVersion"].(string)
+	kind, _ := o["kind"].(string)
+	var errs []FieldError
+	if apiVersion == "" {
+		errs = append(errs, FieldError{Field: "apiVersion", Message: "required value"})
+	}
+	if kind == "" {
+		errs = append(errs, FieldError{Field: "kind", Message: "required value"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	group, version := parseAPIVersion(apiVersion)
+	s, ok := v.kinds[GroupVersionKind{Group: group, Version: version, Kind: kind}]
+	if !ok {
+		if v.groups[group] {
+			return []FieldError{{
+				Field:   "apiVersion",
+				Message: fmt.Sprintf("%s %s isn't served by Kubernetes %s", apiVersion, kind, v.KubernetesVersion),
+			}}
+		}
+		return nil
+	}
+	return v.validate("", o, s, true)
+}
+
+func (v *Validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = v.definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+	}
+	return s
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func typeName(value interface{}) string {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func isType(value interface{}, t string) bool {
+	actual := typeName(value)
+	return actual == t || (t == "number" && actual == "integer")
+}
+
+// quantity is the definition of resource quantities, which can be numbers
+// despite being defined as strings.
+const quantity = "io.k8s.apimachinery.pkg.api.resource.Quantity"
+
+func (v *Validator) validate(path string, value interface{}, s *Schema, root bool) []FieldError {
+	if s != nil && s.Ref == "#/definitions/"+quantity {
+		if isType(value, "string") || isType(value, "number") {
+			return nil
+		}
+	}
+	s = v.resolve(s)
+	if s == nil || value == nil {
+		return nil
+	}
+
+	var errs []FieldError
+	for _, sub := range s.AllOf {
+		errs = append(errs, v.validate(path, value, sub, root)...)
+	}
+	for _, alternatives := range [][]*Schema{s.AnyOf, s.OneOf} {
+		if len(alternatives) == 0 {
+			continue
+		}
+		valid := false
+		for _, sub := range alternatives {
+			if len(v.validate(path, value, sub, root)) == 0 {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			errs = append(errs, FieldError{Field: path, Message: "doesn't match any of the allowed schemas"})
+		}
+	}
+
+	if s.IntOrString || s.Format == "int-or-string" {
+		if !isType(value, "string") && !isType(value, "integer") {
+			errs = append(errs, FieldError{Field: path, Message: fmt.Sprintf("expected integer or string, got %s", typeName(value))})
+		}
+		return errs
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			if allowed == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, FieldError{Field: path, Message: fmt.Sprintf("unsupported value %v, expected one of %v", value, s.Enum)})
+		}
+	}
+
+	t := s.Type
+	if t == "" && len(s.Properties) > 0 {
+		t = "object"
+	}
+	switch t {
+	case "":
+		return errs
+	case "object":
+		return append(errs, v.validateObject(path, value, s, root)...)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return append(errs, FieldError{Field: path, Message: fmt.Sprintf("expected array, got %s", typeName(value))})
+		}
+		for i, item := range items {
+			errs = append(errs, v.validate(fmt.Sprintf("%s[%d]", path, i), item, s.Items, false)...)
+		}
+		return errs
+	default:
+		if !isType(value, t) {
+			errs = append(errs, FieldError{Field: path, Message: fmt.Sprintf("expected %s, got %s", t, typeName(value))})
+		}
+		return errs
+	}
+}
+
+func (v *Validator) validateObject(path string, value interface{}, s *Schema, root bool) []FieldError {
+	o, ok := value.(map[string]interface{})
+	if !ok {
+		return []FieldError{{Field: path, Message: fmt.Sprintf("expected object, got %s", typeName(value))}}
+	}
+
+	var errs []FieldError
+	for _, name := range s.Required {
+		if _, ok := o[name]; !ok {
+			errs = append(errs, FieldError{Field: join(path, name), Message: "required value"})
+		}
+	}
+
+	// Free-form objects, eg. ConfigMap data.
+	freeForm := len(s.Properties) == 0 && s.AdditionalProperties == nil
+
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		field := join(path, k)
+		if property, ok := s.Properties[k]; ok {
+			errs = append(errs, v.validate(field, o[k], property, false)...)
+			continue
+		}
+		if (root || s.EmbeddedResource) && (k == "apiVersion" || k == "kind" || k == "metadata") {
+			continue
+		}
+		if additional := s.AdditionalProperties; additional != nil {
+			if additional.Schema != nil {
+				errs = append(errs, v.validate(field, o[k], additional.Schema, false)...)
+				continue
+			}
+			if additional.Allows {
+				continue
+			}
+		}
+		if freeForm || s.PreserveUnknownFields {
+			continue
+		}
+		errs = append(errs, FieldError{Field: field, Message: "unknown field"})
+	}
+	return errs
+}
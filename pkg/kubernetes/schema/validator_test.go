@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const deployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    name: app
+spec:
+  replicas: "two"
+  selector:
+    matchLabels:
+      name: app
+  template:
+    metadata:
+      labels:
+        name: app
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+        imagePullPolicy: Always
+        ports:
+        - containerPort: 8080
+        resources:
+          requests:
+            cpu: 1
+            memory: 64Mi
+        livenessProbe:
+          httpGet:
+            port: http
+        unknown: true
+      - image: sidecar:v1
+`
+
+const crd = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+  versions:
+  - name: v1
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [size]
+            properties:
+              size:
+                type: integer
+              port:
+                x-kubernetes-int-or-string: true
+`
+
+func parse(t *testing.T, s string) map[string]interface{} {
+	var o map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal([]byte(s), &o))
+	return o
+}
+
+func TestNew(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+	for _, version := range []string{"", "1.16.1", "v1.20.2", "1.25"} {
+		hook.Reset()
+		v, err := New(version)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.20", v.KubernetesVersion)
+		// Validating against the schemas of another version is reported.
+		if version == "1.16.1" || version == "1.25" {
+			if assert.Equal(t, 1, len(hook.Entries)) {
+				assert.Equal(t, log.WarnLevel, hook.LastEntry().Level)
+				assert.Contains(t, hook.LastEntry().Message, "validating against the v1.20 ones")
+			}
+		} else {
+			assert.Empty(t, hook.Entries)
+		}
+	}
+	_, err := New("latest")
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	v, err := New("")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []FieldError{
+		{Field: "spec.replicas", Message: "expected integer, got string"},
+		{Field: "spec.template.spec.containers[0].unknown", Message: "unknown field"},
+		{Field: "spec.template.spec.containers[1].name", Message: "required value"},
+	}, v.Validate(parse(t, deployment)))
+
+	assert.Equal(t, []FieldError{
+		{Field: "apiVersion", Message: "extensions/v1beta1 Deployment isn't served by Kubernetes v1.20"},
+	}, v.Validate(parse(t, "apiVersion: extensions/v1beta1\nkind: Deployment\n")))
+
+	assert.Empty(t, v.Validate(parse(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: c}\ndata: {a: b}\n")))
+
+	// Custom resources are only validated once their CRD is known.
+	widget := parse(t, "apiVersion: example.com/v1\nkind: Widget\nspec: {size: big, port: 80, color: red}\n")
+	assert.Empty(t, v.Validate(widget))
+	withCRD := v.Copy()
+	assert.NoError(t, withCRD.AddCRDs([]byte(crd)))
+	assert.Equal(t, []FieldError{
+		{Field: "spec.color", Message: "unknown field"},
+		{Field: "spec.size", Message: "expected integer, got string"},
+	}, withCRD.Validate(widget))
+	assert.Empty(t, v.Validate(widget))
+}
@@ -7,8 +7,13 @@ import (
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/plan"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/plan/resource"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/utilities/object"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	wksresource "github.com/weaveworks/wksctl/pkg/plan/resource"
 )
 
+// addonsNamespace is the namespace BuildAddonPlan applies addon objects to.
+const addonsNamespace = "addons"
+
 // BuildConfigMapPlan creates a plan to handle config maps
 func BuildConfigMapPlan(manifests map[string][]byte, namespace string) plan.Resource {
 	b := plan.NewBuilder()
@@ -23,16 +28,17 @@ func BuildConfigMapPlan(manifests map[string][]byte, namespace string) plan.Reso
 	return &p
 }
 
-// BuildAddonPlan creates a plan containing all the addons from the cluster manifest
-func BuildAddonPlan(clusterManifestPath string, addons map[string][][]byte) plan.Resource {
+// BuildAddonPlan creates a plan containing all the addons from the cluster manifest.
+// Once all the manifests of an addon are applied, the plan waits on the
+// readiness checks of the addon.
+func BuildAddonPlan(clusterManifestPath string, addonManifests map[string][][]byte) plan.Resource {
 	b := plan.NewBuilder()
-	for name, manifests := range addons {
+	for name, manifests := range addonManifests {
 		var previous *string
 		for i, m := range manifests {
 			resFile := fmt.Sprintf("%s-%02d", name, i)
 			resName := "install:addon:" + resFile
-			manRsc := &resource.KubectlApply{Manifest: m, Filename: object.String(resFile + ".yaml"), Namespace: object.String("addons")}
-
+			manRsc := &resource.KubectlApply{Manifest: m, Filename: object.String(resFile + ".yaml"), Namespace: object.String(addonsNamespace)}
 			if previous != nil {
 				b.AddResource(resName, manRsc, plan.DependOn(*previous))
 			} else {
@@ -40,6 +46,18 @@ func BuildAddonPlan(clusterManifestPath string, addons map[string][][]byte) plan
 			}
 			previous = &resName
 		}
+
+		addon, err := addons.Get(name)
+		if err != nil {
+			// Not a known addon, there is nothing to wait on.
+			continue
+		}
+		checks := addon.ReadinessChecks(nil)
+		for i := range checks {
+			// The addon objects have all been moved to the addons namespace.
+			checks[i].Namespace = addonsNamespace
+		}
+		addReadinessWaits(b, name, checks, previous)
 	}
 	p, err := b.Plan()
 	if err != nil {
@@ -47,3 +65,42 @@ func BuildAddonPlan(clusterManifestPath string, addons map[string][][]byte) plan
 	}
 	return &p
 }
+
+// BuildReadinessPlan creates a plan waiting on the readiness checks of an
+// addon, one after the other.
+func BuildReadinessPlan(name string, checks []addons.ReadinessCheck) plan.Resource {
+	b := plan.NewBuilder()
+	addReadinessWaits(b, name, checks, nil)
+	p, err := b.Plan()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	return &p
+}
+
+// addReadinessWaits adds a KubectlWait resource per readiness check to b,
+// chained after the resource named previous, if any.
+func addReadinessWaits(b *plan.Builder, name string, checks []addons.ReadinessCheck, previous *string) {
+	for i, check := range checks {
+		resName := waitName(name, i)
+		waitRsc := &wksresource.KubectlWait{
+			WaitNamespace: check.Namespace,
+			WaitType:      check.Kind,
+			WaitSelector:  check.Selector,
+			WaitCondition: check.Condition,
+			WaitTimeout:   check.Timeout,
+		}
+		if previous != nil {
+			b.AddResource(resName, waitRsc, plan.DependOn(*previous))
+		} else {
+			b.AddResource(resName, waitRsc)
+		}
+		previous = &resName
+	}
+}
+
+// waitName is the name of the resource waiting on the i-th readiness check of
+// an addon.
+func waitName(name string, i int) string {
+	return fmt.Sprintf("wait:addon:%s-%02d", name, i)
+}
@@ -0,0 +1,53 @@
+package recipe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/plan"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	wksresource "github.com/weaveworks/wksctl/pkg/plan/resource"
+)
+
+func TestBuildAddonPlanWaitsOnReadiness(t *testing.T) {
+	p := BuildAddonPlan("cluster.yaml", map[string][][]byte{
+		"flux":   {[]byte("apiVersion: v1\nkind: Namespace\n"), []byte("apiVersion: v1\nkind: ServiceAccount\n")},
+		"custom": {[]byte("apiVersion: v1\nkind: ConfigMap\n")},
+	}).(*plan.Plan)
+
+	assert.NotNil(t, p.GetResource("install:addon:flux-00"))
+	assert.NotNil(t, p.GetResource("install:addon:flux-01"))
+	assert.NotNil(t, p.GetResource("install:addon:custom-00"))
+
+	flux, err := addons.Get("flux")
+	assert.NoError(t, err)
+	for i, check := range flux.Readiness {
+		wait, ok := p.GetResource(waitName("flux", i)).(*wksresource.KubectlWait)
+		if !assert.True(t, ok) {
+			continue
+		}
+		assert.Equal(t, addonsNamespace, wait.WaitNamespace)
+		assert.Equal(t, check.Kind, wait.WaitType)
+		assert.Equal(t, check.Selector, wait.WaitSelector)
+		assert.Equal(t, check.Condition, wait.WaitCondition)
+		assert.Equal(t, check.Timeout, wait.WaitTimeout)
+	}
+	// Unknown addons have nothing to wait on.
+	assert.Nil(t, p.GetResource(waitName("custom", 0)))
+}
+
+func TestBuildReadinessPlan(t *testing.T) {
+	p := BuildReadinessPlan("cni", []addons.ReadinessCheck{
+		{Kind: "pods", Namespace: "kube-system", Selector: "name=weave-net", Condition: "condition=Ready", Timeout: "5m"},
+	}).(*plan.Plan)
+
+	wait, ok := p.GetResource(waitName("cni", 0)).(*wksresource.KubectlWait)
+	assert.True(t, ok)
+	assert.Equal(t, &wksresource.KubectlWait{
+		WaitNamespace: "kube-system",
+		WaitType:      "pods",
+		WaitSelector:  "name=weave-net",
+		WaitCondition: "condition=Ready",
+		WaitTimeout:   "5m",
+	}, wait)
+}
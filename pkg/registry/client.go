@@ -0,0 +1,414 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dockerHubHost    = "docker.io"
+	dockerHubAPIHost = "registry-1.docker.io"
+)
+
+// Manifest media types understood by the Client.
+const (
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+)
+
+var manifestMediaTypes = []string{
+	MediaTypeOCIIndex,
+	MediaTypeDockerManifestList,
+	MediaTypeOCIManifest,
+	MediaTypeDockerManifest,
+}
+
+// Reference locates an image in a registry.
+type Reference struct {
+	// Host is the registry host and port, eg. "docker.io" or "localhost:5000".
+	Host string
+	// Repository is the image path in the registry, eg. "library/alpine".
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an image reference as docker would, eg. "alpine"
+// refers to "docker.io/library/alpine:latest".
+func ParseReference(image string) (Reference, error) {
+	parsed, err := NewImage(image)
+	if err != nil {
+		return Reference{}, err
+	}
+	normalized := parsed.Normalize()
+	return Reference{
+		Host:       normalized.Registry,
+		Repository: normalized.Repository(),
+		Tag:        normalized.Tag,
+		Digest:     normalized.Digest,
+	}, nil
+}
+
+// reference returns the manifest reference, the digest if known.
+func (r Reference) reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// pushReference returns the reference manifests are pushed to, the tag if
+// any so that it points to the manifest.
+func (r Reference) pushReference() string {
+	if r.Tag != "" {
+		return r.Tag
+	}
+	return r.Digest
+}
+
+func (r Reference) String() string {
+	s := r.Host + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// Client talks to container registries using the OCI distribution API.
+type Client struct {
+	HTTP *http.Client
+	// Credentials are used to authenticate against registries.
+	Credentials *DockerConfig
+	// Insecure registries are accessed over plain HTTP. Registries on the
+	// loopback interface always are.
+	Insecure []string
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewClient creates a Client using the credentials of the docker client
+// configuration.
+func NewClient() (*Client, error) {
+	config, err := LoadDockerConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		HTTP:        http.DefaultClient,
+		Credentials: config,
+	}, nil
+}
+
+func (c *Client) scheme(host string) string {
+	for _, insecure := range c.Insecure {
+		if insecure == host {
+			return "http"
+		}
+	}
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	if hostname == "localhost" {
+		return "http"
+	}
+	if ip := net.ParseIP(hostname); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+	return "https"
+}
+
+func (c *Client) url(host, path string) string {
+	if host == dockerHubHost {
+		host = dockerHubAPIHost
+	}
+	return fmt.Sprintf("%s://%s/v2/%s", c.scheme(host), host, path)
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTP != nil {
+		return c.HTTP
+	}
+	return http.DefaultClient
+}
+
+// do sends an authenticated request to a registry. body, if not nil, is
+// called to get a fresh request body for each attempt.
+func (c *Client) do(ctx context.Context, method, host, repository, path string, header http.Header, body func() io.Reader) (*http.Response, error) {
+	return c.doURL(ctx, method, host, repository, c.url(host, repository+"/"+path), header, body)
+}
+
+// doURL is do for a full URL of the repository API, eg. an upload location.
+func (c *Client) doURL(ctx context.Context, method, host, repository, rawURL string, header http.Header, body func() io.Reader) (*http.Response, error) {
+	scope := "repository:" + repository + ":pull"
+	if method != http.MethodGet && method != http.MethodHead {
+		scope += ",push"
+	}
+	key := host + " " + scope
+
+	for attempt := 0; ; attempt++ {
+		var r io.Reader
+		if body != nil {
+			r = body()
+		}
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, r)
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := r.(*os.File); ok {
+			// Blobs are uploaded from files, in one go.
+			if fi, err := f.Stat(); err == nil {
+				req.ContentLength = fi.Size()
+			}
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		c.mu.Lock()
+		auth := c.tokens[key]
+		c.mu.Unlock()
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		auth, err = c.authenticate(ctx, host, scope, challenge)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.tokens == nil {
+			c.tokens = make(map[string]string)
+		}
+		c.tokens[key] = auth
+		c.mu.Unlock()
+	}
+}
+
+// parseChallenge parses a WWW-Authenticate header, eg:
+//
+//	Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (scheme string, params map[string]string) {
+	params = make(map[string]string)
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	scheme = strings.ToLower(parts[0])
+	if len(parts) == 1 {
+		return scheme, params
+	}
+	for _, param := range strings.Split(parts[1], ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return scheme, params
+}
+
+// authenticate answers an authentication challenge, returning the value of
+// the Authorization header to use.
+func (c *Client) authenticate(ctx context.Context, host, scope, challenge string) (string, error) {
+	username, password, hasCredentials := c.Credentials.Credentials(host)
+	scheme, params := parseChallenge(challenge)
+
+	switch scheme {
+	case "basic":
+		if !hasCredentials {
+			return "", fmt.Errorf("no credentials for registry %s", host)
+		}
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(username, password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+		u, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", fmt.Errorf("invalid authentication challenge from registry %s: %q", host, challenge)
+		}
+		q := u.Query()
+		if params["service"] != "" {
+			q.Set("service", params["service"])
+		}
+		q.Set("scope", scope)
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if hasCredentials {
+			req.SetBasicAuth(username, password)
+		}
+		resp, err := c.httpClient().Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to authenticate against registry %s: %s", host, resp.Status)
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return "", errors.Wrapf(err, "invalid token from registry %s", host)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		return "Bearer " + token.Token, nil
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge from registry %s: %q", host, challenge)
+	}
+}
+
+// checkResponse turns unexpected HTTP responses into errors.
+func checkResponse(resp *http.Response, what string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if len(msg) > 0 {
+		return fmt.Errorf("%s: %s: %s", what, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return fmt.Errorf("%s: %s", what, resp.Status)
+}
+
+func acceptManifests() http.Header {
+	return http.Header{"Accept": []string{strings.Join(manifestMediaTypes, ", ")}}
+}
+
+// Digest resolves an image reference to the digest of its manifest, eg.
+// "sha256:…".
+func (c *Client) Digest(ctx context.Context, image string) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	what := fmt.Sprintf("failed to resolve %s", image)
+
+	resp, err := c.do(ctx, http.MethodHead, ref.Host, ref.Repository, "manifests/"+ref.reference(), acceptManifests(), nil)
+	if err != nil {
+		return "", errors.Wrap(err, what)
+	}
+	resp.Body.Close()
+	if err := checkResponse(resp, what); err != nil {
+		return "", err
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// Not all registries return the digest, compute it from the manifest.
+	_, data, err := c.GetManifest(ctx, image)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data)), nil
+}
+
+// GetManifest retrieves the manifest of an image and its media type.
+func (c *Client) GetManifest(ctx context.Context, image string) (string, []byte, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", nil, err
+	}
+	what := fmt.Sprintf("failed to get manifest of %s", image)
+
+	resp, err := c.do(ctx, http.MethodGet, ref.Host, ref.Repository, "manifests/"+ref.reference(), acceptManifests(), nil)
+	if err != nil {
+		return "", nil, errors.Wrap(err, what)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, what); err != nil {
+		return "", nil, err
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, errors.Wrap(err, what)
+	}
+	return resp.Header.Get("Content-Type"), data, nil
+}
+
+// nextLink returns the URL of the next page of a paginated response, "" if it
+// is the last page.
+func (c *Client) nextLink(host string, resp *http.Response) (string, error) {
+	link := resp.Header.Get("Link")
+	if link == "" {
+		return "", nil
+	}
+	i, j := strings.Index(link, "<"), strings.Index(link, ">")
+	if i < 0 || j < i || !strings.Contains(link[j:], `rel="next"`) {
+		return "", fmt.Errorf("invalid Link header from registry %s: %q", host, link)
+	}
+	base, err := url.Parse(c.url(host, ""))
+	if err != nil {
+		return "", err
+	}
+	next, err := base.Parse(link[i+1 : j])
+	if err != nil {
+		return "", err
+	}
+	return next.String(), nil
+}
+
+// Tags lists the tags of a repository, eg. "k8s.gcr.io/kube-apiserver".
+func (c *Client) Tags(ctx context.Context, repository string) ([]string, error) {
+	ref, err := ParseReference(repository)
+	if err != nil {
+		return nil, err
+	}
+	what := fmt.Sprintf("failed to list the tags of %s", repository)
+
+	var tags []string
+	next := c.url(ref.Host, ref.Repository+"/tags/list")
+	for next != "" {
+		resp, err := c.doURL(ctx, http.MethodGet, ref.Host, ref.Repository, next, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, what)
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = checkResponse(resp, what)
+		if err == nil {
+			err = errors.Wrap(json.NewDecoder(resp.Body).Decode(&page), what)
+		}
+		if err == nil {
+			next, err = c.nextLink(ref.Host, resp)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, page.Tags...)
+	}
+	return tags, nil
+}
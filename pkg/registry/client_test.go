@@ -0,0 +1,76 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/registrytest"
+)
+
+func TestParseReference(t *testing.T) {
+	const digest = "sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+	tests := []struct {
+		input    string
+		expected registry.Reference
+	}{
+		{"alpine", registry.Reference{Host: "docker.io", Repository: "library/alpine", Tag: "latest"}},
+		{"fluxcd/flux:1.13.3", registry.Reference{Host: "docker.io", Repository: "fluxcd/flux", Tag: "1.13.3"}},
+		{"localhost:5000/a/b/c:v1", registry.Reference{Host: "localhost:5000", Repository: "a/b/c", Tag: "v1"}},
+		{"quay.io/org/name@" + digest, registry.Reference{Host: "quay.io", Repository: "org/name", Digest: digest}},
+		{"quay.io/org/name:v1@" + digest, registry.Reference{Host: "quay.io", Repository: "org/name", Tag: "v1", Digest: digest}},
+		{"index.docker.io/alpine:3.12", registry.Reference{Host: "docker.io", Repository: "library/alpine", Tag: "3.12"}},
+		{"registry.example.com/team/sub/app@" + digest, registry.Reference{Host: "registry.example.com", Repository: "team/sub/app", Digest: digest}},
+	}
+	for _, test := range tests {
+		ref, err := registry.ParseReference(test.input)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, ref)
+	}
+
+	_, err := registry.ParseReference("quay.io/org/name@sha256:abcd")
+	assert.Error(t, err)
+}
+
+func TestDigest(t *testing.T) {
+	r := registrytest.New()
+	defer r.Close()
+	r.Username, r.Password = "user", "secret"
+	expected := r.PushImage("weaveworks/flux", "1.13.3", "layer")
+
+	client := &registry.Client{}
+	_, err := client.Digest(context.Background(), r.Host()+"/weaveworks/flux:1.13.3")
+	assert.Error(t, err)
+
+	client = &registry.Client{
+		Credentials: &registry.DockerConfig{Auths: map[string]registry.DockerAuth{
+			r.Host(): {Auth: base64.StdEncoding.EncodeToString([]byte("user:secret"))},
+		}},
+	}
+	digest, err := client.Digest(context.Background(), r.Host()+"/weaveworks/flux:1.13.3")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, digest)
+
+	_, err = client.Digest(context.Background(), r.Host()+"/weaveworks/flux:missing")
+	assert.Error(t, err)
+}
+
+func TestTags(t *testing.T) {
+	r := registrytest.New()
+	defer r.Close()
+	r.TagsPageSize = 2
+	for _, tag := range []string{"v1.19.7", "v1.20.2", "v1.18.15", "v1.20.1", "v1.17.17"} {
+		r.PushImage("kube-apiserver", tag, "layer "+tag)
+	}
+
+	client := &registry.Client{}
+	tags, err := client.Tags(context.Background(), r.Host()+"/kube-apiserver")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.17.17", "v1.18.15", "v1.19.7", "v1.20.1", "v1.20.2"}, tags)
+	assert.Equal(t, 3, r.Requests["GET"])
+
+	_, err = client.Tags(context.Background(), r.Host()+"/missing")
+	assert.Error(t, err)
+}
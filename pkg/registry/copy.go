@@ -0,0 +1,360 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// CopyOptions holds the options of Copy and CopyAll.
+type CopyOptions struct {
+	// DryRun checks whether images are up to date in the destination
+	// registry without copying anything.
+	DryRun bool
+	// Workers is the number of images CopyAll copies concurrently. It
+	// defaults to the number of CPUs.
+	Workers int
+}
+
+// CopyRequest is an image copy run by CopyAll.
+type CopyRequest struct {
+	Source      string
+	Destination string
+}
+
+// CopyResult reports the copy of an image.
+type CopyResult struct {
+	Source      string
+	Destination string
+	// Digest is the digest of the source manifest.
+	Digest string
+	// UpToDate is set when the destination already referenced the source
+	// manifest: nothing was copied.
+	UpToDate bool
+	// BlobsCopied and BlobsSkipped count the layers and configurations
+	// uploaded and the ones the destination already had.
+	BlobsCopied  int
+	BlobsSkipped int
+	// Err is set by CopyAll when the copy failed.
+	Err error
+}
+
+// descriptor references a manifest or a blob.
+type descriptor struct {
+	MediaType string   `json:"mediaType"`
+	Digest    string   `json:"digest"`
+	Size      int64    `json:"size"`
+	URLs      []string `json:"urls,omitempty"`
+}
+
+// manifestContent holds the references of image manifests and indexes.
+type manifestContent struct {
+	Manifests []descriptor `json:"manifests"`
+	Config    descriptor   `json:"config"`
+	Layers    []descriptor `json:"layers"`
+}
+
+func digestOf(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+// exists returns whether the manifest or blob at path exists, and its digest.
+func (c *Client) exists(ctx context.Context, ref Reference, path string) (bool, string, error) {
+	var header http.Header
+	if strings.HasPrefix(path, "manifests/") {
+		header = acceptManifests()
+	}
+	resp, err := c.do(ctx, http.MethodHead, ref.Host, ref.Repository, path, header, nil)
+	if err != nil {
+		return false, "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, "", nil
+	}
+	if err := checkResponse(resp, fmt.Sprintf("failed to check %s in %s", path, ref)); err != nil {
+		return false, "", err
+	}
+	return true, resp.Header.Get("Docker-Content-Digest"), nil
+}
+
+// Copy copies an image from a registry to another through the distribution
+// API, without a container runtime. Manifest lists are copied with the
+// manifests of all their platforms and blobs already in the destination
+// repository aren't copied again.
+func (c *Client) Copy(ctx context.Context, src, dst string, options CopyOptions) (CopyResult, error) {
+	result := CopyResult{Source: src, Destination: dst}
+	srcRef, err := ParseReference(src)
+	if err != nil {
+		return result, err
+	}
+	dstRef, err := ParseReference(dst)
+	if err != nil {
+		return result, err
+	}
+
+	mediaType, data, err := c.GetManifest(ctx, srcRef.String())
+	if err != nil {
+		return result, err
+	}
+	result.Digest = digestOf(data)
+	if dstRef.Digest != "" && dstRef.Digest != result.Digest {
+		return result, fmt.Errorf("can't copy %s to %s: the digests differ", src, dst)
+	}
+
+	found, digest, err := c.exists(ctx, dstRef, "manifests/"+dstRef.pushReference())
+	if err != nil {
+		return result, err
+	}
+	result.UpToDate = found && digest == result.Digest
+	if result.UpToDate || options.DryRun {
+		return result, nil
+	}
+
+	err = c.copyManifest(ctx, &registrySource{client: c, ref: srcRef}, dstRef, mediaType, data, &result)
+	return result, errors.Wrapf(err, "failed to copy %s to %s", src, dst)
+}
+
+// imageSource provides the manifests and blobs of the images being pushed.
+type imageSource interface {
+	// manifest returns the media type and content of a manifest.
+	manifest(ctx context.Context, digest string) (string, []byte, error)
+	// openBlob returns a file holding a blob, and a function releasing it.
+	openBlob(ctx context.Context, blob descriptor) (*os.File, func(), error)
+}
+
+// registrySource reads images from a repository of a registry.
+type registrySource struct {
+	client *Client
+	ref    Reference
+}
+
+func (s *registrySource) manifest(ctx context.Context, digest string) (string, []byte, error) {
+	ref := Reference{Host: s.ref.Host, Repository: s.ref.Repository, Digest: digest}
+	return s.client.GetManifest(ctx, ref.String())
+}
+
+func (s *registrySource) openBlob(ctx context.Context, blob descriptor) (*os.File, func(), error) {
+	f, err := s.client.downloadBlob(ctx, s.ref, blob, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() {
+		f.Close()
+		os.Remove(f.Name())
+	}, nil
+}
+
+// copyManifest copies the content of a manifest, then the manifest itself.
+func (c *Client) copyManifest(ctx context.Context, src imageSource, dst Reference, mediaType string, data []byte, result *CopyResult) error {
+	var content manifestContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return errors.Wrapf(err, "invalid manifest %s", digestOf(data))
+	}
+
+	switch mediaType {
+	case MediaTypeOCIIndex, MediaTypeDockerManifestList:
+		for _, m := range content.Manifests {
+			childDst := Reference{Host: dst.Host, Repository: dst.Repository, Digest: m.Digest}
+			found, _, err := c.exists(ctx, childDst, "manifests/"+m.Digest)
+			if err != nil {
+				return err
+			}
+			if found {
+				continue
+			}
+			childType, childData, err := src.manifest(ctx, m.Digest)
+			if err != nil {
+				return err
+			}
+			if err := c.copyManifest(ctx, src, childDst, childType, childData, result); err != nil {
+				return err
+			}
+		}
+	case MediaTypeOCIManifest, MediaTypeDockerManifest:
+		for _, blob := range content.blobs() {
+			copied, err := c.copyBlob(ctx, src, dst, blob)
+			if err != nil {
+				return err
+			}
+			if copied {
+				result.BlobsCopied++
+			} else {
+				result.BlobsSkipped++
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported manifest type %q", mediaType)
+	}
+
+	return c.putManifest(ctx, dst, mediaType, data)
+}
+
+// blobs returns the blobs of an image manifest stored in registries: foreign
+// layers are downloaded from their URLs.
+func (m *manifestContent) blobs() []descriptor {
+	var blobs []descriptor
+	for _, blob := range append([]descriptor{m.Config}, m.Layers...) {
+		if len(blob.URLs) == 0 {
+			blobs = append(blobs, blob)
+		}
+	}
+	return blobs
+}
+
+func (c *Client) putManifest(ctx context.Context, ref Reference, mediaType string, data []byte) error {
+	header := http.Header{"Content-Type": []string{mediaType}}
+	resp, err := c.do(ctx, http.MethodPut, ref.Host, ref.Repository, "manifests/"+ref.pushReference(), header, func() io.Reader {
+		return bytes.NewReader(data)
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp, fmt.Sprintf("failed to push manifest %s", ref))
+}
+
+// copyBlob copies a blob unless the destination already has it. It returns
+// whether the blob was copied.
+func (c *Client) copyBlob(ctx context.Context, src imageSource, dst Reference, blob descriptor) (bool, error) {
+	found, _, err := c.exists(ctx, dst, "blobs/"+blob.Digest)
+	if err != nil || found {
+		return false, err
+	}
+
+	// Blobs of the same registry can be mounted from the source repository.
+	path := "blobs/uploads/"
+	if rs, ok := src.(*registrySource); ok && rs.ref.Host == dst.Host {
+		path += "?" + url.Values{"mount": {blob.Digest}, "from": {rs.ref.Repository}}.Encode()
+	}
+	resp, err := c.do(ctx, http.MethodPost, dst.Host, dst.Repository, path, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusCreated {
+		return true, nil
+	}
+	if err := checkResponse(resp, fmt.Sprintf("failed to start the upload of %s to %s", blob.Digest, dst)); err != nil {
+		return false, err
+	}
+	location, err := c.uploadURL(dst.Host, resp.Header.Get("Location"), blob.Digest)
+	if err != nil {
+		return false, err
+	}
+
+	f, release, err := src.openBlob(ctx, blob)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	header := http.Header{"Content-Type": []string{"application/octet-stream"}}
+	resp, err = c.doURL(ctx, http.MethodPut, dst.Host, dst.Repository, location, header, func() io.Reader {
+		_, _ = f.Seek(0, io.SeekStart)
+		return f
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, fmt.Sprintf("failed to upload %s to %s", blob.Digest, dst)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// uploadURL returns the URL completing the upload of a blob, location being
+// where the registry told the upload should go.
+func (c *Client) uploadURL(host, location, digest string) (string, error) {
+	base, err := url.Parse(c.url(host, ""))
+	if err != nil {
+		return "", err
+	}
+	u, err := base.Parse(location)
+	if err != nil || location == "" {
+		return "", fmt.Errorf("invalid upload location %q from registry %s", location, host)
+	}
+	q := u.Query()
+	q.Set("digest", digest)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// downloadBlob downloads a blob to a temporary file of dir, the default
+// directory for temporary files if empty, checking its digest. The caller
+// removes the file.
+func (c *Client) downloadBlob(ctx context.Context, src Reference, blob descriptor, dir string) (*os.File, error) {
+	what := fmt.Sprintf("failed to download %s from %s", blob.Digest, src)
+	resp, err := c.do(ctx, http.MethodGet, src.Host, src.Repository, "blobs/"+blob.Digest, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, what)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, what); err != nil {
+		return nil, err
+	}
+
+	f, err := ioutil.TempFile(dir, "wksctl-blob")
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), resp.Body)
+	if err == nil && fmt.Sprintf("sha256:%x", h.Sum(nil)) != blob.Digest {
+		err = fmt.Errorf("%s: digest mismatch", what)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+// parallelize calls f with every index of [0, n), running at most workers
+// calls at a time. workers defaults to the number of CPUs.
+func parallelize(n, workers int, f func(i int)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
+
+// CopyAll copies images concurrently and returns the results in the order of
+// the requests.
+func (c *Client) CopyAll(ctx context.Context, requests []CopyRequest, options CopyOptions) []CopyResult {
+	results := make([]CopyResult, len(requests))
+	parallelize(len(requests), options.Workers, func(i int) {
+		r := &requests[i]
+		results[i], results[i].Err = c.Copy(ctx, r.Source, r.Destination, options)
+	})
+	return results
+}
@@ -0,0 +1,173 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/registrytest"
+)
+
+func TestCopy(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+	digest := src.PushImage("weaveworks/flux", "1.13.3", "layer 1", "layer 2")
+
+	client := &registry.Client{}
+	result, err := client.Copy(context.Background(), src.Host()+"/weaveworks/flux:1.13.3", dst.Host()+"/wks/flux:1.13.3", registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, digest, result.Digest)
+	assert.False(t, result.UpToDate)
+	assert.Equal(t, 3, result.BlobsCopied)
+	assert.True(t, dst.HasManifest("wks/flux", "1.13.3"))
+	assert.True(t, dst.HasManifest("wks/flux", digest))
+	assert.True(t, dst.HasBlob("wks/flux", registrytest.Digest([]byte("layer 1"))))
+
+	// Copying again is a no-op.
+	puts := dst.Requests["PUT"]
+	result, err = client.Copy(context.Background(), src.Host()+"/weaveworks/flux:1.13.3", dst.Host()+"/wks/flux:1.13.3", registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.True(t, result.UpToDate)
+	assert.Equal(t, puts, dst.Requests["PUT"])
+
+	// New tags only copy the layers the destination doesn't have.
+	src.PushImage("weaveworks/flux", "1.13.4", "layer 1", "layer 3")
+	result, err = client.Copy(context.Background(), src.Host()+"/weaveworks/flux:1.13.4", dst.Host()+"/wks/flux:1.13.4", registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.BlobsCopied)
+	assert.Equal(t, 1, result.BlobsSkipped)
+
+	_, err = client.Copy(context.Background(), src.Host()+"/weaveworks/flux:missing", dst.Host()+"/wks/flux:missing", registry.CopyOptions{})
+	assert.Error(t, err)
+}
+
+func TestCopyIndex(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+	amd64 := src.PushImage("weaveworks/weave-kube", "", "amd64 layer")
+	arm64 := src.PushImage("weaveworks/weave-kube", "", "arm64 layer")
+	index := src.PushIndex("weaveworks/weave-kube", "2.7.0", map[string]string{
+		"linux/amd64": amd64,
+		"linux/arm64": arm64,
+	})
+
+	client := &registry.Client{}
+	result, err := client.Copy(context.Background(), src.Host()+"/weaveworks/weave-kube:2.7.0", dst.Host()+"/wks/weave-kube:2.7.0", registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, index, result.Digest)
+	assert.True(t, dst.HasManifest("wks/weave-kube", index))
+	assert.True(t, dst.HasManifest("wks/weave-kube", amd64))
+	assert.True(t, dst.HasManifest("wks/weave-kube", arm64))
+	assert.True(t, dst.HasBlob("wks/weave-kube", registrytest.Digest([]byte("arm64 layer"))))
+
+	digest, err := client.Digest(context.Background(), dst.Host()+"/wks/weave-kube:2.7.0")
+	assert.NoError(t, err)
+	assert.Equal(t, index, digest)
+}
+
+func TestCopyTagAndDigest(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+	digest := src.PushImage("team/sub/app", "v1", "layer")
+
+	client := &registry.Client{}
+	result, err := client.Copy(context.Background(), src.Host()+"/team/sub/app:v1@"+digest, dst.Host()+"/wks/app:v1@"+digest, registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.False(t, result.UpToDate)
+	// The destination tag points to the copied manifest.
+	assert.True(t, dst.HasManifest("wks/app", "v1"))
+	assert.True(t, dst.HasManifest("wks/app", digest))
+
+	result, err = client.Copy(context.Background(), src.Host()+"/team/sub/app:v1@"+digest, dst.Host()+"/wks/app:v1@"+digest, registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.True(t, result.UpToDate)
+}
+
+func TestCopySameRegistry(t *testing.T) {
+	r := registrytest.New()
+	defer r.Close()
+	r.PushImage("weaveworks/flux", "1.13.3", "layer")
+
+	client := &registry.Client{}
+	result, err := client.Copy(context.Background(), r.Host()+"/weaveworks/flux:1.13.3", r.Host()+"/wks/flux:1.13.3", registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.BlobsCopied)
+	assert.True(t, r.HasBlob("wks/flux", registrytest.Digest([]byte("layer"))))
+	// Blobs are mounted rather than uploaded.
+	assert.Equal(t, 1, r.Requests["PUT"])
+}
+
+func TestCopyDryRun(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+	src.PushImage("weaveworks/flux", "1.13.3", "layer")
+
+	client := &registry.Client{}
+	result, err := client.Copy(context.Background(), src.Host()+"/weaveworks/flux:1.13.3", dst.Host()+"/wks/flux:1.13.3", registry.CopyOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.False(t, result.UpToDate)
+	assert.False(t, dst.HasManifest("wks/flux", "1.13.3"))
+	assert.Equal(t, 0, dst.Requests["POST"]+dst.Requests["PUT"])
+}
+
+func TestCopyCredentials(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+	dst.Username, dst.Password = "user", "secret"
+	src.PushImage("weaveworks/flux", "1.13.3", "layer")
+
+	client := &registry.Client{}
+	_, err := client.Copy(context.Background(), src.Host()+"/weaveworks/flux:1.13.3", dst.Host()+"/wks/flux:1.13.3", registry.CopyOptions{})
+	assert.Error(t, err)
+
+	client.Credentials = &registry.DockerConfig{Auths: map[string]registry.DockerAuth{
+		dst.Host(): {Auth: base64.StdEncoding.EncodeToString([]byte("user:secret"))},
+	}}
+	_, err = client.Copy(context.Background(), src.Host()+"/weaveworks/flux:1.13.3", dst.Host()+"/wks/flux:1.13.3", registry.CopyOptions{})
+	assert.NoError(t, err)
+	assert.True(t, dst.HasManifest("wks/flux", "1.13.3"))
+}
+
+func TestCopyAll(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+
+	var requests []registry.CopyRequest
+	for i := 0; i < 8; i++ {
+		tag := fmt.Sprintf("v%d", i)
+		src.PushImage("weaveworks/flux", tag, "shared layer", "layer "+tag)
+		requests = append(requests, registry.CopyRequest{
+			Source:      src.Host() + "/weaveworks/flux:" + tag,
+			Destination: dst.Host() + "/wks/flux:" + tag,
+		})
+	}
+	requests = append(requests, registry.CopyRequest{
+		Source:      src.Host() + "/weaveworks/flux:missing",
+		Destination: dst.Host() + "/wks/flux:missing",
+	})
+
+	client := &registry.Client{}
+	results := client.CopyAll(context.Background(), requests, registry.CopyOptions{Workers: 4})
+	assert.Len(t, results, len(requests))
+	for i, result := range results[:8] {
+		assert.NoError(t, result.Err)
+		assert.Equal(t, requests[i].Destination, result.Destination)
+		assert.True(t, dst.HasManifest("wks/flux", fmt.Sprintf("v%d", i)))
+	}
+	assert.Error(t, results[8].Err)
+}
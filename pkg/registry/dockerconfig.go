@@ -0,0 +1,107 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DockerConfig holds the registry credentials of a docker client
+// configuration file.
+type DockerConfig struct {
+	Auths map[string]DockerAuth `json:"auths"`
+}
+
+// DockerAuth are the credentials for a registry.
+type DockerAuth struct {
+	// Auth is the base64 encoded "username:password" string.
+	Auth     string `json:"auth,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// dockerConfigPath returns the location of the docker client configuration,
+// honouring $DOCKER_CONFIG.
+func dockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// LoadDockerConfig reads the docker client configuration of the current user.
+// A missing configuration file results in no credentials.
+func LoadDockerConfig() (*DockerConfig, error) {
+	path := dockerConfigPath()
+	if path == "" {
+		return &DockerConfig{}, nil
+	}
+	return LoadDockerConfigFile(path)
+}
+
+// LoadDockerConfigFile reads a docker client configuration file. A missing
+// file results in no credentials.
+func LoadDockerConfigFile(path string) (*DockerConfig, error) {
+	config := &DockerConfig{}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	return config, nil
+}
+
+// normalizeRegistryHost maps the various ways of naming a registry in a docker
+// configuration, eg. "https://index.docker.io/v1/", to a host name.
+func normalizeRegistryHost(s string) string {
+	s = strings.TrimPrefix(s, "https://")
+	s = strings.TrimPrefix(s, "http://")
+	if i := strings.Index(s, "/"); i >= 0 {
+		s = s[:i]
+	}
+	switch s {
+	case "index.docker.io", "registry-1.docker.io":
+		return dockerHubHost
+	}
+	return s
+}
+
+// Credentials returns the username and password to use for host, if any.
+func (c *DockerConfig) Credentials(host string) (username, password string, ok bool) {
+	if c == nil {
+		return "", "", false
+	}
+	host = normalizeRegistryHost(host)
+	for key, auth := range c.Auths {
+		if normalizeRegistryHost(key) != host {
+			continue
+		}
+		if auth.Username != "" || auth.Password != "" {
+			return auth.Username, auth.Password, true
+		}
+		data, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			continue
+		}
+		parts := strings.SplitN(string(data), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
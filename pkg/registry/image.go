@@ -6,51 +6,100 @@ import (
 )
 
 // Image represents the "coordinates" of a container image
-// i.e.: REGISTRY[:PORT]/USER/NAME[:TAG]
+// i.e.: [REGISTRY[:PORT]/][USER/]NAME[:TAG][@DIGEST]
 // e.g.:
 // - "quay.io/weaveworks/wksctl:latest"
 // - "localhost:5000/test/busybox:v1.2.3"
-// - "golang:1.10"
+// - "public.ecr.aws/eks-distro/kubernetes/pause:v1.18.9-eks-1-18-1"
+// - "golang:1.10@sha256:..."
+//
+// The first path component is the registry when it looks like a host, as
+// docker decides it: it holds a "." or a ":", or is "localhost". Images parsed
+// by NewImage print back to their original string, see Normalize for the
+// references docker pulls.
 //
 // See also:
 // - https://github.com/moby/moby/blob/master/image/spec/v1.2.md#terminology
-// - https://access.redhat.com/documentation/en-us/red_hat_enterprise_linux_atomic_host/7/html/recommended_practices_for_container_development/naming
-// - https://success.docker.com/article/what-is-the-correct-format-to-name-and-tag-dtr-repositories
+// - https://github.com/distribution/distribution/blob/main/reference/reference.go
 //
 type Image struct {
 	Registry string // Host AND port
-	User     string
+	User     string // Path between the registry and the name, possibly nested
 	Name     string
 	Tag      string
+	Digest   string
 }
 
+const (
+	// NameTotalLengthMax is the maximum total number of characters in a repository name.
+	NameTotalLengthMax = 255
+
+	// legacyDockerHubHost is how docker used to name the Docker Hub.
+	legacyDockerHubHost = "index.docker.io"
+	// officialUser is the organization of the Docker Hub official images.
+	officialUser = "library"
+	// defaultTag is pulled when images have neither tag nor digest.
+	defaultTag = "latest"
+)
+
 // NewImage parses the provided string representation of an image to return a registry.Image struct.
+// As for docker, the first component of the path is the registry only if it
+// contains a dot or a port, or is "localhost": "foo/bar/baz" is the Docker Hub
+// image "baz" of the nested user "foo/bar". All the other components but the
+// last make up the user.
 func NewImage(image string) (*Image, error) {
-	if strings.TrimSpace(image) == "" {
+	matches := ReferenceRegexp.FindStringSubmatch(image)
+	if matches == nil {
+		if strings.TrimSpace(image) != "" && ReferenceRegexp.MatchString(strings.ToLower(image)) {
+			return nil, fmt.Errorf("invalid image: '%v': repository name must be lowercase", image)
+		}
 		return nil, fmt.Errorf("invalid image: '%v'", image)
 	}
-	parts := strings.Split(image, "/")
-	registry, user, name, tag := "", "", "", ""
-	switch len(parts) {
-	case 1:
-		name = parts[0]
-	case 2:
-		user, name = parts[0], parts[1]
-	case 3:
-		registry, user, name = parts[0], parts[1], parts[2]
-	default:
-		return nil, fmt.Errorf("invalid image: '%v'", image)
+
+	result := Image{Tag: matches[4], Digest: matches[5]}
+	name := image
+	if result.Digest != "" {
+		name = strings.TrimSuffix(name, "@"+result.Digest)
 	}
-	nameAndTag := strings.Split(name, ":")
-	switch len(nameAndTag) {
-	case 1:
-		break
-	case 2:
-		name, tag = nameAndTag[0], nameAndTag[1]
-	default:
-		return nil, fmt.Errorf("invalid image: '%v'", image)
+	if result.Tag != "" {
+		name = strings.TrimSuffix(name, ":"+result.Tag)
+	}
+	if len(name) > NameTotalLengthMax {
+		return nil, fmt.Errorf("invalid image: '%v': repository name must not be more than %v characters", image, NameTotalLengthMax)
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) > 1 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		result.Registry, parts = parts[0], parts[1:]
+	}
+	result.User = strings.Join(parts[:len(parts)-1], "/")
+	result.Name = parts[len(parts)-1]
+	return &result, nil
+}
+
+// Normalize returns the image docker pulls for this image: images without a
+// registry are on the Docker Hub, official images in its "library"
+// organization, and images without tag nor digest are tagged "latest".
+func (image Image) Normalize() Image {
+	if image.Registry == "" || image.Registry == legacyDockerHubHost {
+		image.Registry = dockerHubHost
+	}
+	if image.Registry == dockerHubHost && image.User == "" {
+		image.User = officialUser
+	}
+	if image.Tag == "" && image.Digest == "" {
+		image.Tag = defaultTag
 	}
-	return &Image{Registry: registry, User: user, Name: name, Tag: tag}, nil
+	return image
+}
+
+// Repository returns the path of the image in its registry, eg.
+// "weaveworks/wksctl".
+func (image Image) Repository() string {
+	if image.User == "" {
+		return image.Name
+	}
+	return image.User + "/" + image.Name
 }
 
 // String returns the string representation of this Image struct.
@@ -60,15 +109,15 @@ func (image Image) String() string {
 		builder.WriteString(image.Registry)
 		builder.WriteString("/")
 	}
-	if image.User != "" {
-		builder.WriteString(image.User)
-		builder.WriteString("/")
-	}
-	builder.WriteString(image.Name)
+	builder.WriteString(image.Repository())
 	if image.Tag != "" {
 		builder.WriteString(":")
 		builder.WriteString(image.Tag)
 	}
+	if image.Digest != "" {
+		builder.WriteString("@")
+		builder.WriteString(image.Digest)
+	}
 	return builder.String()
 }
 
@@ -84,6 +133,60 @@ func (image Image) CommandsToRetagAs(destImage Image) []string {
 	}
 }
 
+// CommandFormat is a command line tool copying container images.
+type CommandFormat string
+
+// Command formats supported by CommandsToCopyAs.
+const (
+	FormatDocker CommandFormat = "docker"
+	FormatPodman CommandFormat = "podman"
+	FormatSkopeo CommandFormat = "skopeo"
+	FormatCrane  CommandFormat = "crane"
+)
+
+// CommandFormats lists the supported command formats.
+var CommandFormats = []CommandFormat{FormatDocker, FormatPodman, FormatSkopeo, FormatCrane}
+
+// CommandsToCopyAs returns the commands of the provided tool copying this
+// (source) container image to the provided (destination) image, see
+// CommandsToRetagAs. skopeo and crane copy images directly between registries,
+// with all the platforms of multi-architecture images. insecure disables TLS
+// verification when pushing to the destination registry.
+func (image Image) CommandsToCopyAs(destImage Image, format CommandFormat, insecure bool) ([]string, error) {
+	switch format {
+	case FormatDocker:
+		// The docker daemon configures insecure registries.
+		return image.CommandsToRetagAs(destImage), nil
+	case FormatPodman:
+		flags := ""
+		if insecure {
+			flags = " --tls-verify=false"
+		}
+		return []string{
+			fmt.Sprintf("podman pull %s", image),
+			fmt.Sprintf("podman push%s %s %s", flags, image, destImage),
+		}, nil
+	case FormatSkopeo:
+		flags := ""
+		if insecure {
+			flags = " --dest-tls-verify=false"
+		}
+		return []string{
+			fmt.Sprintf("skopeo copy --all%s docker://%s docker://%s", flags, image, destImage),
+		}, nil
+	case FormatCrane:
+		flags := ""
+		if insecure {
+			flags = " --insecure"
+		}
+		return []string{
+			fmt.Sprintf("crane copy%s %s %s", flags, image, destImage),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported command format %q", format)
+	}
+}
+
 // ByCoordinate allows you to sort registry.Image arrays.
 type ByCoordinate []Image
 
@@ -102,5 +205,9 @@ func (a ByCoordinate) Less(i, j int) bool {
 	if comparison != 0 {
 		return comparison < 0
 	}
-	return strings.Compare(a[i].Tag, a[j].Tag) < 0
+	comparison = strings.Compare(a[i].Tag, a[j].Tag)
+	if comparison != 0 {
+		return comparison < 0
+	}
+	return strings.Compare(a[i].Digest, a[j].Digest) < 0
 }
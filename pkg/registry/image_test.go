@@ -44,18 +44,33 @@ func TestImageWithUserName(t *testing.T) {
 	assert.Equal(t, "foo/bar", image.String())
 }
 
-func TestImageWithRegistryUserName(t *testing.T) {
+func TestImageWithNestedUserName(t *testing.T) {
+	// "foo" isn't a host: it has no dot nor port.
 	image, err := registry.NewImage("foo/bar/baz")
 	assert.NoError(t, err)
 	assert.Equal(t, &registry.Image{
-		Registry: "foo",
-		User:     "bar",
+		Registry: "",
+		User:     "foo/bar",
 		Name:     "baz",
 		Tag:      "",
 	}, image)
 	assert.Equal(t, "foo/bar/baz", image.String())
 }
 
+func TestImageWithHostNestedUserNameTagDigest(t *testing.T) {
+	image, err := registry.NewImage("registry.example.com/team/sub/app:v1@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	assert.NoError(t, err)
+	assert.Equal(t, &registry.Image{
+		Registry: "registry.example.com",
+		User:     "team/sub",
+		Name:     "app",
+		Tag:      "v1",
+		Digest:   "sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	}, image)
+	assert.Equal(t, "team/sub/app", image.Repository())
+	assert.Equal(t, "registry.example.com/team/sub/app:v1@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", image.String())
+}
+
 func TestImageWithHostUserNameTag(t *testing.T) {
 	image, err := registry.NewImage("quay.io/weaveworks/wks:latest")
 	assert.NoError(t, err)
@@ -101,15 +116,70 @@ func TestInvalidImages(t *testing.T) {
 	assert.Nil(t, image)
 	assert.Equal(t, errors.New("invalid image: '    '"), err)
 
-	image, err = registry.NewImage("a/b/c/d:e")
+	image, err = registry.NewImage("a/b/c@d")
 	assert.Nil(t, image)
-	assert.Equal(t, errors.New("invalid image: 'a/b/c/d:e'"), err)
+	assert.Equal(t, errors.New("invalid image: 'a/b/c@d'"), err)
+
+	image, err = registry.NewImage("quay.io/Org/name")
+	assert.Nil(t, image)
+	assert.Equal(t, errors.New("invalid image: 'quay.io/Org/name': repository name must be lowercase"), err)
 
 	image, err = registry.NewImage("a/b/c:d:e")
 	assert.Nil(t, image)
 	assert.Equal(t, errors.New("invalid image: 'a/b/c:d:e'"), err)
 }
 
+func TestNewImage(t *testing.T) {
+	const digest = "sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+	tests := []struct {
+		input    string
+		expected registry.Image
+	}{
+		{"test_com", registry.Image{Name: "test_com"}},
+		{"test.com:tag", registry.Image{Name: "test.com", Tag: "tag"}},
+		{"test.com:5000", registry.Image{Name: "test.com", Tag: "5000"}},
+		{"host.com/name:tag", registry.Image{Registry: "host.com", Name: "name", Tag: "tag"}},
+		{"host:5000/name", registry.Image{Registry: "host:5000", Name: "name"}},
+		{"host:5000/name:tag", registry.Image{Registry: "host:5000", Name: "name", Tag: "tag"}},
+		{"host:5000/name@" + digest, registry.Image{Registry: "host:5000", Name: "name", Digest: digest}},
+		{"host:5000/name:tag@" + digest, registry.Image{Registry: "host:5000", Name: "name", Tag: "tag", Digest: digest}},
+		{"host:5000/org/name:tag@" + digest, registry.Image{Registry: "host:5000", User: "org", Name: "name", Tag: "tag", Digest: digest}},
+		{"localhost/org/name", registry.Image{Registry: "localhost", User: "org", Name: "name"}},
+		{"host/org/name:tag@" + digest, registry.Image{User: "host/org", Name: "name", Tag: "tag", Digest: digest}},
+		{"org/name:tag@" + digest, registry.Image{User: "org", Name: "name", Tag: "tag", Digest: digest}},
+		{"a/b/c/d:e", registry.Image{User: "a/b/c", Name: "d", Tag: "e"}},
+		{"public.ecr.aws/eks-distro/kubernetes/pause:v1.18.9-eks-1-18-1", registry.Image{Registry: "public.ecr.aws", User: "eks-distro/kubernetes", Name: "pause", Tag: "v1.18.9-eks-1-18-1"}},
+	}
+	for _, test := range tests {
+		image, err := registry.NewImage(test.input)
+		assert.NoError(t, err, test.input)
+		if assert.NotNil(t, image, test.input) {
+			assert.Equal(t, test.expected, *image, test.input)
+			// Images round-trip losslessly.
+			assert.Equal(t, test.input, image.String())
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"alpine", "docker.io/library/alpine:latest"},
+		{"fluxcd/flux:1.13.3", "docker.io/fluxcd/flux:1.13.3"},
+		{"index.docker.io/alpine@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "docker.io/library/alpine@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{"docker.io/a/b/c", "docker.io/a/b/c:latest"},
+		{"quay.io/weaveworks/wks", "quay.io/weaveworks/wks:latest"},
+		{"localhost:5000/busybox:v1", "localhost:5000/busybox:v1"},
+	}
+	for _, test := range tests {
+		image, err := registry.NewImage(test.input)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, image.Normalize().String())
+	}
+}
+
 func TestCommandsToRetagAs(t *testing.T) {
 	source := registry.Image{
 		Registry: "quay.io",
@@ -185,4 +255,50 @@ func TestByCoordinate(t *testing.T) {
 		{Registry: "a", User: "b", Name: "c", Tag: "d"},
 		{Registry: "a", User: "b", Name: "c", Tag: "e"},
 	}).Less(0, 1))
+
+	assert.True(t, registry.ByCoordinate([]registry.Image{
+		{Registry: "a", User: "b", Name: "c", Tag: "d", Digest: "e"},
+		{Registry: "a", User: "b", Name: "c", Tag: "d", Digest: "f"},
+	}).Less(0, 1))
+}
+
+func TestCommandsToCopyAs(t *testing.T) {
+	source := registry.Image{Registry: "quay.io", User: "weaveworks", Name: "wks", Tag: "latest"}
+	dest := registry.Image{Registry: "localhost:1337", User: "mono-repo", Name: "wks", Tag: "latest"}
+
+	tests := []struct {
+		format   registry.CommandFormat
+		insecure bool
+		expected []string
+	}{
+		{registry.FormatDocker, true, source.CommandsToRetagAs(dest)},
+		{registry.FormatPodman, false, []string{
+			"podman pull quay.io/weaveworks/wks:latest",
+			"podman push quay.io/weaveworks/wks:latest localhost:1337/mono-repo/wks:latest",
+		}},
+		{registry.FormatPodman, true, []string{
+			"podman pull quay.io/weaveworks/wks:latest",
+			"podman push --tls-verify=false quay.io/weaveworks/wks:latest localhost:1337/mono-repo/wks:latest",
+		}},
+		{registry.FormatSkopeo, false, []string{
+			"skopeo copy --all docker://quay.io/weaveworks/wks:latest docker://localhost:1337/mono-repo/wks:latest",
+		}},
+		{registry.FormatSkopeo, true, []string{
+			"skopeo copy --all --dest-tls-verify=false docker://quay.io/weaveworks/wks:latest docker://localhost:1337/mono-repo/wks:latest",
+		}},
+		{registry.FormatCrane, false, []string{
+			"crane copy quay.io/weaveworks/wks:latest localhost:1337/mono-repo/wks:latest",
+		}},
+		{registry.FormatCrane, true, []string{
+			"crane copy --insecure quay.io/weaveworks/wks:latest localhost:1337/mono-repo/wks:latest",
+		}},
+	}
+	for _, test := range tests {
+		commands, err := source.CommandsToCopyAs(dest, test.format, test.insecure)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, commands)
+	}
+
+	_, err := source.CommandsToCopyAs(dest, "buildah", false)
+	assert.EqualError(t, err, `unsupported command format "buildah"`)
 }
@@ -0,0 +1,347 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	layoutVersion = "1.0.0"
+	// annotationRefName names the images of a layout index.
+	annotationRefName = "org.opencontainers.image.ref.name"
+)
+
+// Layout is an OCI image layout: a directory holding images, see
+// https://github.com/opencontainers/image-spec/blob/master/image-layout.md.
+// Images are named in the index of the layout by their reference in the
+// registry they were pulled from.
+type Layout struct {
+	Path string
+
+	mu sync.Mutex // serializes updates of the index.
+}
+
+type layoutIndex struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	Manifests     []layoutDescriptor `json:"manifests"`
+}
+
+type layoutDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// OpenLayout opens the image layout of a directory, creating it if needed.
+func OpenLayout(path string) (*Layout, error) {
+	l := &Layout{Path: path}
+	if err := os.MkdirAll(filepath.Join(path, "blobs", "sha256"), 0755); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(l.indexPath()); err == nil {
+		return l, nil
+	}
+	data, err := json.Marshal(struct {
+		ImageLayoutVersion string `json:"imageLayoutVersion"`
+	}{layoutVersion})
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "oci-layout"), data, 0644); err != nil {
+		return nil, err
+	}
+	if err := l.writeIndex(&layoutIndex{SchemaVersion: 2, Manifests: []layoutDescriptor{}}); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Layout) indexPath() string {
+	return filepath.Join(l.Path, "index.json")
+}
+
+func (l *Layout) readIndex() (*layoutIndex, error) {
+	data, err := ioutil.ReadFile(l.indexPath())
+	if err != nil {
+		return nil, err
+	}
+	var index layoutIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrapf(err, "invalid image layout index %s", l.indexPath())
+	}
+	return &index, nil
+}
+
+func (l *Layout) writeIndex(index *layoutIndex) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(l.indexPath(), data, 0644)
+}
+
+// Images returns the names of the images of the layout.
+func (l *Layout) Images() ([]string, error) {
+	index, err := l.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, m := range index.Manifests {
+		if name := m.Annotations[annotationRefName]; name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// image returns the descriptor of an image of the layout.
+func (l *Layout) image(name string) (layoutDescriptor, error) {
+	index, err := l.readIndex()
+	if err != nil {
+		return layoutDescriptor{}, err
+	}
+	for _, m := range index.Manifests {
+		if m.Annotations[annotationRefName] == name {
+			return m, nil
+		}
+	}
+	return layoutDescriptor{}, fmt.Errorf("image %s isn't in layout %s", name, l.Path)
+}
+
+// addImage names a manifest of the layout, replacing any image of that name.
+func (l *Layout) addImage(name string, m layoutDescriptor) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	index, err := l.readIndex()
+	if err != nil {
+		return err
+	}
+	m.Annotations = map[string]string{annotationRefName: name}
+	manifests := []layoutDescriptor{}
+	for _, existing := range index.Manifests {
+		if existing.Annotations[annotationRefName] != name {
+			manifests = append(manifests, existing)
+		}
+	}
+	index.Manifests = append(manifests, m)
+	return l.writeIndex(index)
+}
+
+func (l *Layout) blobPath(digest string) (string, error) {
+	if !strings.HasPrefix(digest, "sha256:") || strings.ContainsAny(digest, `/\`) {
+		return "", fmt.Errorf("unsupported digest %q", digest)
+	}
+	return filepath.Join(l.Path, "blobs", "sha256", strings.TrimPrefix(digest, "sha256:")), nil
+}
+
+func (l *Layout) hasBlob(digest string) bool {
+	path, err := l.blobPath(digest)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+func (l *Layout) readBlob(digest string) ([]byte, error) {
+	path, err := l.blobPath(digest)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(path)
+}
+
+func (l *Layout) writeBlob(data []byte) error {
+	path, err := l.blobPath(digestOf(data))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// layoutSource reads images from a layout.
+type layoutSource struct {
+	layout *Layout
+}
+
+func (s *layoutSource) manifest(ctx context.Context, digest string) (string, []byte, error) {
+	data, err := s.layout.readBlob(digest)
+	if err != nil {
+		return "", nil, err
+	}
+	var m struct {
+		MediaType string          `json:"mediaType"`
+		Manifests json.RawMessage `json:"manifests"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return "", nil, errors.Wrapf(err, "invalid manifest %s", digest)
+	}
+	// The media type of OCI manifests is optional.
+	switch {
+	case m.MediaType != "":
+		return m.MediaType, data, nil
+	case m.Manifests != nil:
+		return MediaTypeOCIIndex, data, nil
+	default:
+		return MediaTypeOCIManifest, data, nil
+	}
+}
+
+func (s *layoutSource) openBlob(ctx context.Context, blob descriptor) (*os.File, func(), error) {
+	path, err := s.layout.blobPath(blob.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
+
+// Pull downloads an image, with all its platforms, to a layout. Blobs the
+// layout already holds aren't downloaded again.
+func (c *Client) Pull(ctx context.Context, image string, layout *Layout) (CopyResult, error) {
+	result := CopyResult{Source: image, Destination: layout.Path}
+	ref, err := ParseReference(image)
+	if err != nil {
+		return result, err
+	}
+	mediaType, data, err := c.GetManifest(ctx, image)
+	if err != nil {
+		return result, err
+	}
+	result.Digest = digestOf(data)
+	if err := c.pullManifest(ctx, ref, layout, mediaType, data, &result); err != nil {
+		return result, errors.Wrapf(err, "failed to pull %s", image)
+	}
+	err = layout.addImage(image, layoutDescriptor{MediaType: mediaType, Digest: result.Digest, Size: int64(len(data))})
+	return result, err
+}
+
+func (c *Client) pullManifest(ctx context.Context, ref Reference, layout *Layout, mediaType string, data []byte, result *CopyResult) error {
+	var content manifestContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return errors.Wrapf(err, "invalid manifest %s", digestOf(data))
+	}
+
+	switch mediaType {
+	case MediaTypeOCIIndex, MediaTypeDockerManifestList:
+		for _, m := range content.Manifests {
+			if layout.hasBlob(m.Digest) {
+				continue
+			}
+			child := Reference{Host: ref.Host, Repository: ref.Repository, Digest: m.Digest}
+			childType, childData, err := c.GetManifest(ctx, child.String())
+			if err != nil {
+				return err
+			}
+			if err := c.pullManifest(ctx, ref, layout, childType, childData, result); err != nil {
+				return err
+			}
+		}
+	case MediaTypeOCIManifest, MediaTypeDockerManifest:
+		for _, blob := range content.blobs() {
+			if layout.hasBlob(blob.Digest) {
+				result.BlobsSkipped++
+				continue
+			}
+			if err := c.pullBlob(ctx, ref, layout, blob); err != nil {
+				return err
+			}
+			result.BlobsCopied++
+		}
+	default:
+		return fmt.Errorf("unsupported manifest type %q", mediaType)
+	}
+
+	return layout.writeBlob(data)
+}
+
+func (c *Client) pullBlob(ctx context.Context, ref Reference, layout *Layout, blob descriptor) error {
+	path, err := layout.blobPath(blob.Digest)
+	if err != nil {
+		return err
+	}
+	f, err := c.downloadBlob(ctx, ref, blob, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	f.Close()
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// Push uploads an image of a layout, with all its platforms, to a registry.
+// Blobs already in the destination repository aren't uploaded again.
+func (c *Client) Push(ctx context.Context, layout *Layout, image, dst string) (CopyResult, error) {
+	result := CopyResult{Source: image, Destination: dst}
+	dstRef, err := ParseReference(dst)
+	if err != nil {
+		return result, err
+	}
+	m, err := layout.image(image)
+	if err != nil {
+		return result, err
+	}
+	result.Digest = m.Digest
+
+	found, digest, err := c.exists(ctx, dstRef, "manifests/"+dstRef.pushReference())
+	if err != nil {
+		return result, err
+	}
+	if result.UpToDate = found && digest == m.Digest; result.UpToDate {
+		return result, nil
+	}
+
+	src := &layoutSource{layout: layout}
+	mediaType, data, err := src.manifest(ctx, m.Digest)
+	if err != nil {
+		return result, err
+	}
+	err = c.copyManifest(ctx, src, dstRef, mediaType, data, &result)
+	return result, errors.Wrapf(err, "failed to push %s to %s", image, dst)
+}
+
+// PullAll pulls images concurrently, at most workers at a time, to a layout
+// and returns the results in the order of the images. workers defaults to the
+// number of CPUs.
+func (c *Client) PullAll(ctx context.Context, images []string, layout *Layout, workers int) []CopyResult {
+	results := make([]CopyResult, len(images))
+	parallelize(len(images), workers, func(i int) {
+		results[i], results[i].Err = c.Pull(ctx, images[i], layout)
+	})
+	return results
+}
+
+// PushRequest is an image push run by PushAll.
+type PushRequest struct {
+	// Image is the name of the image in the layout.
+	Image       string
+	Destination string
+}
+
+// PushAll pushes images of a layout concurrently, at most workers at a time,
+// and returns the results in the order of the requests. workers defaults to
+// the number of CPUs.
+func (c *Client) PushAll(ctx context.Context, layout *Layout, requests []PushRequest, workers int) []CopyResult {
+	results := make([]CopyResult, len(requests))
+	parallelize(len(requests), workers, func(i int) {
+		r := &requests[i]
+		results[i], results[i].Err = c.Push(ctx, layout, r.Image, r.Destination)
+	})
+	return results
+}
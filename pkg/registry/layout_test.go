@@ -0,0 +1,83 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/registrytest"
+)
+
+func TestPullPush(t *testing.T) {
+	src := registrytest.New()
+	defer src.Close()
+	dst := registrytest.New()
+	defer dst.Close()
+	flux := src.PushImage("fluxcd/flux", "1.13.3", "flux layer", "shared layer")
+	amd64 := src.PushImage("weaveworks/weave-kube", "", "amd64 layer", "shared layer")
+	arm64 := src.PushImage("weaveworks/weave-kube", "", "arm64 layer")
+	index := src.PushIndex("weaveworks/weave-kube", "2.7.0", map[string]string{
+		"linux/amd64": amd64,
+		"linux/arm64": arm64,
+	})
+
+	dir, err := ioutil.TempDir("", "wksctl-layout")
+	assert.NoError(t, err)
+	layout, err := registry.OpenLayout(dir)
+	assert.NoError(t, err)
+
+	client := &registry.Client{}
+	fluxImage := src.Host() + "/fluxcd/flux:1.13.3"
+	weaveImage := src.Host() + "/weaveworks/weave-kube:2.7.0"
+	result, err := client.Pull(context.Background(), fluxImage, layout)
+	assert.NoError(t, err)
+	assert.Equal(t, flux, result.Digest)
+	assert.Equal(t, 3, result.BlobsCopied)
+	result, err = client.Pull(context.Background(), weaveImage, layout)
+	assert.NoError(t, err)
+	assert.Equal(t, index, result.Digest)
+	// The platforms share their configuration, and a layer with flux.
+	assert.Equal(t, 2, result.BlobsSkipped)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "oci-layout"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"imageLayoutVersion":"1.0.0"}`, string(data))
+	images, err := layout.Images()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{fluxImage, weaveImage}, images)
+
+	// Pulling again replaces the image in the index.
+	_, err = client.Pull(context.Background(), fluxImage, layout)
+	assert.NoError(t, err)
+	data, err = ioutil.ReadFile(filepath.Join(dir, "index.json"))
+	assert.NoError(t, err)
+	var index2 struct {
+		Manifests []json.RawMessage `json:"manifests"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &index2))
+	assert.Len(t, index2.Manifests, 2)
+
+	// Push from a reopened layout.
+	layout, err = registry.OpenLayout(dir)
+	assert.NoError(t, err)
+	result, err = client.Push(context.Background(), layout, weaveImage, dst.Host()+"/wks/weave-kube:2.7.0")
+	assert.NoError(t, err)
+	assert.Equal(t, index, result.Digest)
+	assert.True(t, dst.HasManifest("wks/weave-kube", "2.7.0"))
+	assert.True(t, dst.HasManifest("wks/weave-kube", arm64))
+	assert.True(t, dst.HasBlob("wks/weave-kube", registrytest.Digest([]byte("shared layer"))))
+	digest, err := client.Digest(context.Background(), dst.Host()+"/wks/weave-kube:2.7.0")
+	assert.NoError(t, err)
+	assert.Equal(t, index, digest)
+
+	result, err = client.Push(context.Background(), layout, weaveImage, dst.Host()+"/wks/weave-kube:2.7.0")
+	assert.NoError(t, err)
+	assert.True(t, result.UpToDate)
+
+	_, err = client.Push(context.Background(), layout, src.Host()+"/fluxcd/flux:missing", dst.Host()+"/wks/flux:missing")
+	assert.Error(t, err)
+}
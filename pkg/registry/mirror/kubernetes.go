@@ -0,0 +1,145 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"sigs.k8s.io/yaml"
+)
+
+// KubernetesImageRepository is the repository kubeadm pulls the control plane
+// images from.
+const KubernetesImageRepository = "k8s.gcr.io"
+
+// kubeadmImageTags are the tags of the images kubeadm deploys besides the
+// Kubernetes components, by Kubernetes minor version.
+var kubeadmImageTags = map[uint64]struct{ etcd, coreDNS, pause string }{
+	16: {"3.3.17-0", "1.6.2", "3.1"},
+	17: {"3.4.3-0", "1.6.5", "3.1"},
+	18: {"3.4.3-0", "1.6.7", "3.2"},
+	19: {"3.4.13-0", "1.7.0", "3.2"},
+	20: {"3.4.13-0", "1.7.0", "3.2"},
+}
+
+// ControlPlaneImages returns the images kubeadm deploys for the given
+// Kubernetes version, eg. "1.17.13".
+func ControlPlaneImages(kubernetesVersion string) ([]registry.Image, error) {
+	version, err := semver.ParseTolerant(kubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Kubernetes version %q", kubernetesVersion)
+	}
+	tags, ok := kubeadmImageTags[version.Minor]
+	if version.Major != 1 || !ok {
+		return nil, fmt.Errorf("unsupported Kubernetes version %s", kubernetesVersion)
+	}
+
+	tag := "v" + version.String()
+	images := []registry.Image{
+		{Registry: KubernetesImageRepository, Name: "kube-apiserver", Tag: tag},
+		{Registry: KubernetesImageRepository, Name: "kube-controller-manager", Tag: tag},
+		{Registry: KubernetesImageRepository, Name: "kube-scheduler", Tag: tag},
+		{Registry: KubernetesImageRepository, Name: "kube-proxy", Tag: tag},
+		{Registry: KubernetesImageRepository, Name: "pause", Tag: tags.pause},
+		{Registry: KubernetesImageRepository, Name: "etcd", Tag: tags.etcd},
+		{Registry: KubernetesImageRepository, Name: "coredns", Tag: tags.coreDNS},
+	}
+	return images, nil
+}
+
+// KubernetesVersions resolves a range of Kubernetes versions, eg.
+// ">=1.16.1 <=1.20.x", to the released versions in the range, using the tags of
+// the kube-apiserver image.
+func KubernetesVersions(ctx context.Context, client *registry.Client, versionsRange string) ([]string, error) {
+	return kubernetesVersions(ctx, client, KubernetesImageRepository, versionsRange)
+}
+
+func kubernetesVersions(ctx context.Context, client *registry.Client, imageRepository, versionsRange string) ([]string, error) {
+	inRange, err := semver.ParseRange(versionsRange)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Kubernetes versions range %q", versionsRange)
+	}
+	tags, err := client.Tags(ctx, imageRepository+"/kube-apiserver")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []semver.Version
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, "v") {
+			continue
+		}
+		version, err := semver.Parse(tag[1:])
+		if err != nil || len(version.Pre) > 0 || len(version.Build) > 0 || !inRange(version) {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no Kubernetes version matches %q", versionsRange)
+	}
+	semver.Sort(versions)
+
+	result := make([]string, 0, len(versions))
+	for _, version := range versions {
+		result = append(result, version.String())
+	}
+	return result, nil
+}
+
+// eksdRelease holds the parts of an EKS-D release manifest listing images.
+type eksdRelease struct {
+	Status struct {
+		Components []struct {
+			Assets []struct {
+				Type  string `json:"type"`
+				Image *struct {
+					URI string `json:"uri"`
+				} `json:"image"`
+			} `json:"assets"`
+		} `json:"components"`
+	} `json:"status"`
+}
+
+// EKSDImages returns the images of the EKS-D release manifest at releaseURL.
+func EKSDImages(releaseURL string) ([]registry.Image, error) {
+	what := fmt.Sprintf("failed to read EKS-D release %s", releaseURL)
+	resp, err := http.Get(releaseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, what)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", what, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, what)
+	}
+	var release eksdRelease
+	if err := yaml.Unmarshal(data, &release); err != nil {
+		return nil, errors.Wrap(err, what)
+	}
+
+	var images []registry.Image
+	for _, component := range release.Status.Components {
+		for _, asset := range component.Assets {
+			if asset.Type != "Image" || asset.Image == nil {
+				continue
+			}
+			image, err := registry.NewImage(asset.Image.URI)
+			if err != nil {
+				return nil, errors.Wrap(err, what)
+			}
+			images = append(images, *image)
+		}
+	}
+	sort.Sort(registry.ByCoordinate(images))
+	return images, nil
+}
@@ -0,0 +1,147 @@
+// Package mirror lists the container images wksctl deploys, so that they can
+// be synchronized to an internal registry.
+package mirror
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/flavors/eksd"
+	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/kubernetes"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/apis/wksprovider/controller/manifests"
+	"github.com/weaveworks/wksctl/pkg/cluster/machine"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/specs"
+)
+
+// Options selects the images listed by Images.
+type Options struct {
+	// Jobs is the number of addons built concurrently to list their images.
+	// It defaults to the number of CPUs.
+	Jobs int
+	// KubernetesVersions are the versions of Kubernetes whose control plane
+	// images are listed.
+	KubernetesVersions []string
+	// EKSDReleaseURL is the URL of the manifest of an EKS-D release whose
+	// images are listed, see the eks-d cluster flavor.
+	EKSDReleaseURL string
+}
+
+// Images returns the deduplicated and sorted images of the addons, of the
+// controllers and of the control plane.
+func Images(options Options) ([]registry.Image, error) {
+	imagesSet := make(map[registry.Image]struct{}) // to deduplicate images.
+
+	list := addons.List()
+	for i, result := range addons.ListAllImages(list, options.Jobs) {
+		if result.Err != nil {
+			return nil, errors.Wrapf(result.Err, "failed to get the images of addon %s", list[i].Name)
+		}
+		for _, image := range result.Images {
+			imagesSet[image] = struct{}{}
+		}
+	}
+
+	controllerImages, err := manifests.ListImages()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the controllers' images")
+	}
+	for _, image := range controllerImages {
+		imagesSet[image] = struct{}{}
+	}
+
+	for _, version := range options.KubernetesVersions {
+		controlPlaneImages, err := ControlPlaneImages(version)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range controlPlaneImages {
+			imagesSet[image] = struct{}{}
+		}
+	}
+
+	if options.EKSDReleaseURL != "" {
+		eksdImages, err := EKSDImages(options.EKSDReleaseURL)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range eksdImages {
+			imagesSet[image] = struct{}{}
+		}
+	}
+
+	images := make([]registry.Image, 0, len(imagesSet))
+	for image := range imagesSet {
+		images = append(images, image)
+	}
+	sort.Sort(registry.ByCoordinate(images))
+	return images, nil
+}
+
+// Destination returns where image is mirrored in the given registry and
+// organization. Like the imageRepository of the cluster manifest, see
+// addons.UpdateImage, the whole path of the image, e.g. "eks-distro/kubernetes"
+// in "public.ecr.aws/eks-distro/kubernetes/pause", is replaced by the
+// organization, so that wksctl finds the image once the cluster is configured
+// to pull from the internal registry.
+func Destination(image registry.Image, registryHost, organization string) registry.Image {
+	image.Registry = registryHost
+	image.User = organization
+	return image
+}
+
+// Destinations returns the Destination of each of images. As paths are
+// replaced, images only differing by their registry or path would overwrite
+// each other in the internal registry: an error is returned instead.
+func Destinations(images []registry.Image, registryHost, organization string) ([]registry.Image, error) {
+	sources := make(map[string]registry.Image, len(images))
+	destinations := make([]registry.Image, 0, len(images))
+	for _, image := range images {
+		destination := Destination(image, registryHost, organization)
+		if source, ok := sources[destination.String()]; ok && source.String() != image.String() {
+			return nil, errors.Errorf("both %s and %s would be mirrored to %s", source, image, destination)
+		}
+		sources[destination.String()] = image
+		destinations = append(destinations, destination)
+	}
+	return destinations, nil
+}
+
+// ControlPlaneOptions returns the options listing the control plane images of
+// the cluster described by the given manifests and of the Kubernetes versions
+// in versionsRange. Any of them may be empty: the images of the default
+// Kubernetes version are then listed.
+func ControlPlaneOptions(ctx context.Context, client *registry.Client, clusterManifestPath, machinesManifestPath, versionsRange string) (Options, error) {
+	var options Options
+	if clusterManifestPath != "" {
+		_, eic, err := specs.ParseClusterManifest(clusterManifestPath)
+		if err != nil {
+			return options, errors.Wrapf(err, "failed to parse cluster manifest %s", clusterManifestPath)
+		}
+		if eic.Spec.Flavor.Name == eksd.Flavor {
+			options.EKSDReleaseURL = eic.Spec.Flavor.ManifestURL
+		}
+	}
+
+	// The images of EKS-D clusters are the ones of the release.
+	if machinesManifestPath != "" && options.EKSDReleaseURL == "" {
+		version, _, err := machine.GetKubernetesVersionFromManifest(machinesManifestPath)
+		if err != nil {
+			return options, errors.Wrapf(err, "failed to read the Kubernetes version from %s", machinesManifestPath)
+		}
+		options.KubernetesVersions = append(options.KubernetesVersions, version)
+	}
+	if versionsRange != "" {
+		versions, err := KubernetesVersions(ctx, client, versionsRange)
+		if err != nil {
+			return options, err
+		}
+		options.KubernetesVersions = append(options.KubernetesVersions, versions...)
+	}
+	if len(options.KubernetesVersions) == 0 && options.EKSDReleaseURL == "" {
+		options.KubernetesVersions = []string{kubernetes.DefaultVersion}
+	}
+	return options, nil
+}
@@ -0,0 +1,158 @@
+package mirror
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/registry/registrytest"
+)
+
+func TestControlPlaneImages(t *testing.T) {
+	images, err := ControlPlaneImages("1.17.13")
+	assert.NoError(t, err)
+	var names []string
+	for _, image := range images {
+		names = append(names, image.String())
+	}
+	assert.Equal(t, []string{
+		"k8s.gcr.io/kube-apiserver:v1.17.13",
+		"k8s.gcr.io/kube-controller-manager:v1.17.13",
+		"k8s.gcr.io/kube-scheduler:v1.17.13",
+		"k8s.gcr.io/kube-proxy:v1.17.13",
+		"k8s.gcr.io/pause:3.1",
+		"k8s.gcr.io/etcd:3.4.3-0",
+		"k8s.gcr.io/coredns:1.6.5",
+	}, names)
+
+	_, err = ControlPlaneImages("1.12.0")
+	assert.EqualError(t, err, "unsupported Kubernetes version 1.12.0")
+	_, err = ControlPlaneImages("latest")
+	assert.Error(t, err)
+}
+
+func TestKubernetesVersions(t *testing.T) {
+	r := registrytest.New()
+	defer r.Close()
+	for _, tag := range []string{"v1.15.12", "v1.16.1", "v1.17.13", "v1.20.2", "v1.20.0-rc.0", "v1.21.0", "latest"} {
+		r.PushImage("kube-apiserver", tag, "layer "+tag)
+	}
+
+	client := &registry.Client{}
+	versions, err := kubernetesVersions(context.Background(), client, r.Host(), ">=1.16.1 <=1.20.x")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.16.1", "1.17.13", "1.20.2"}, versions)
+
+	_, err = kubernetesVersions(context.Background(), client, r.Host(), ">=1.22.0")
+	assert.EqualError(t, err, `no Kubernetes version matches ">=1.22.0"`)
+	_, err = kubernetesVersions(context.Background(), client, r.Host(), "1.x.y")
+	assert.Error(t, err)
+}
+
+const eksdReleaseManifest = `apiVersion: distro.eks.amazonaws.com/v1alpha1
+kind: Release
+metadata:
+  name: kubernetes-1-18-eks-1
+status:
+  components:
+  - name: kubernetes
+    assets:
+    - name: kube-apiserver-image
+      type: Image
+      image:
+        uri: public.ecr.aws/eks-distro/kubernetes/kube-apiserver:v1.18.9-eks-1-18-1
+    - name: bin/linux/amd64/kubeadm
+      type: Archive
+      archive:
+        uri: https://distro.eks.amazonaws.com/kubernetes-1-18/releases/1/artifacts/kubernetes/v1.18.9/bin/linux/amd64/kubeadm
+  - name: etcd
+    assets:
+    - name: etcd-image
+      type: Image
+      image:
+        uri: public.ecr.aws/eks-distro/etcd-io/etcd:v3.4.14-eks-1-18-1
+`
+
+func TestEKSDImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/release.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(eksdReleaseManifest))
+	}))
+	defer server.Close()
+
+	images, err := EKSDImages(server.URL + "/release.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.Image{
+		{Registry: "public.ecr.aws", User: "eks-distro/etcd-io", Name: "etcd", Tag: "v3.4.14-eks-1-18-1"},
+		{Registry: "public.ecr.aws", User: "eks-distro/kubernetes", Name: "kube-apiserver", Tag: "v1.18.9-eks-1-18-1"},
+	}, images)
+	assert.Equal(t, "public.ecr.aws/eks-distro/etcd-io/etcd:v3.4.14-eks-1-18-1", images[0].String())
+	assert.Equal(t, "localhost:1337/wks/etcd:v3.4.14-eks-1-18-1", Destination(images[0], "localhost:1337", "wks").String())
+
+	_, err = EKSDImages(server.URL + "/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestImages(t *testing.T) {
+	images, err := Images(Options{KubernetesVersions: []string{"1.19.7", "1.20.2"}})
+	assert.NoError(t, err)
+
+	set := make(map[string]int)
+	for _, image := range images {
+		set[image.String()]++
+	}
+	for _, name := range []string{
+		"k8s.gcr.io/kube-apiserver:v1.19.7",
+		"k8s.gcr.io/kube-apiserver:v1.20.2",
+		"k8s.gcr.io/etcd:3.4.13-0",
+		"k8s.gcr.io/coredns:1.7.0",
+		"k8s.gcr.io/pause:3.2",
+	} {
+		assert.Equal(t, 1, set[name], name)
+	}
+	for _, image := range images {
+		if image.Name == "sealed-secrets-controller" {
+			return
+		}
+	}
+	t.Error("missing the sealed secrets controller image")
+}
+
+func TestDestination(t *testing.T) {
+	for _, test := range []struct {
+		image    string
+		expected string
+	}{
+		{"pause:3.2", "localhost:1337/wks/pause:3.2"},
+		{"weaveworks/weave-kube:2.7.0", "localhost:1337/wks/weave-kube:2.7.0"},
+		// Nested paths are replaced by the organization, as in addons.UpdateImage:
+		{"public.ecr.aws/eks-distro/kubernetes/pause:v1.18.9-eks-1-18-1", "localhost:1337/wks/pause:v1.18.9-eks-1-18-1"},
+		{"team/sub/app:v1", "localhost:1337/wks/app:v1"},
+	} {
+		image, err := registry.NewImage(test.image)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, Destination(*image, "localhost:1337", "wks").String())
+	}
+}
+
+func TestDestinations(t *testing.T) {
+	images := []registry.Image{
+		{Registry: "public.ecr.aws", User: "eks-distro/kubernetes", Name: "pause", Tag: "v1"},
+		{Registry: "k8s.gcr.io", Name: "etcd", Tag: "3.4.13-0"},
+	}
+	destinations, err := Destinations(images, "localhost:1337", "wks")
+	assert.NoError(t, err)
+	assert.Equal(t, []registry.Image{
+		{Registry: "localhost:1337", User: "wks", Name: "pause", Tag: "v1"},
+		{Registry: "localhost:1337", User: "wks", Name: "etcd", Tag: "3.4.13-0"},
+	}, destinations)
+
+	_, err = Destinations(append(images, registry.Image{Registry: "k8s.gcr.io", Name: "pause", Tag: "v1"}), "localhost:1337", "wks")
+	assert.EqualError(t, err, "both public.ecr.aws/eks-distro/kubernetes/pause:v1 and k8s.gcr.io/pause:v1 would be mirrored to localhost:1337/wks/pause:v1")
+}
@@ -1,4 +1,4 @@
-package addons
+package registry
 
 import (
 	"fmt"
@@ -0,0 +1,322 @@
+// Package registrytest provides an in-memory container registry implementing
+// the parts of the OCI distribution API used by wksctl, for tests.
+package registrytest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	mediaTypeManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeConfig       = "application/vnd.docker.container.image.v1+json"
+	mediaTypeLayer        = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+type manifest struct {
+	mediaType string
+	data      []byte
+}
+
+type repository struct {
+	manifests map[string]manifest // by tag and digest
+	blobs     map[string][]byte
+}
+
+// Registry is an in-memory container registry.
+type Registry struct {
+	*httptest.Server
+
+	// Username and Password, when set, are required to access the registry.
+	Username string
+	Password string
+	// TagsPageSize, when set, paginates the lists of tags.
+	TagsPageSize int
+
+	mu           sync.Mutex
+	repositories map[string]*repository
+	uploads      map[string][]byte
+	// Requests counts the requests received, by method.
+	Requests map[string]int
+}
+
+// New starts a Registry. Callers should Close it when done.
+func New() *Registry {
+	r := &Registry{
+		repositories: make(map[string]*repository),
+		uploads:      make(map[string][]byte),
+		Requests:     make(map[string]int),
+	}
+	r.Server = httptest.NewServer(r)
+	return r
+}
+
+// Host returns the host:port of the registry.
+func (r *Registry) Host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+// Digest computes the digest of data.
+func Digest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+func (r *Registry) repository(name string) *repository {
+	repo, ok := r.repositories[name]
+	if !ok {
+		repo = &repository{
+			manifests: make(map[string]manifest),
+			blobs:     make(map[string][]byte),
+		}
+		r.repositories[name] = repo
+	}
+	return repo
+}
+
+type descriptor struct {
+	MediaType string            `json:"mediaType"`
+	Size      int               `json:"size"`
+	Digest    string            `json:"digest"`
+	Platform  map[string]string `json:"platform,omitempty"`
+}
+
+// PushImage stores a single platform image made of the given layers and
+// returns the digest of its manifest.
+func (r *Registry) PushImage(repository, tag string, layers ...string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := r.repository(repository)
+	config := []byte(fmt.Sprintf(`{"architecture":"amd64","os":"linux","tag":%q}`, tag))
+	repo.blobs[Digest(config)] = config
+
+	m := struct {
+		SchemaVersion int          `json:"schemaVersion"`
+		MediaType     string       `json:"mediaType"`
+		Config        descriptor   `json:"config"`
+		Layers        []descriptor `json:"layers"`
+	}{
+		SchemaVersion: 2,
+		MediaType:     mediaTypeManifest,
+		Config:        descriptor{MediaType: mediaTypeConfig, Size: len(config), Digest: Digest(config)},
+	}
+	for _, layer := range layers {
+		repo.blobs[Digest([]byte(layer))] = []byte(layer)
+		m.Layers = append(m.Layers, descriptor{MediaType: mediaTypeLayer, Size: len(layer), Digest: Digest([]byte(layer))})
+	}
+	data, _ := json.Marshal(m)
+	digest := Digest(data)
+	repo.manifests[digest] = manifest{mediaType: mediaTypeManifest, data: data}
+	if tag != "" {
+		repo.manifests[tag] = repo.manifests[digest]
+	}
+	return digest
+}
+
+// PushIndex stores a manifest list referencing the given manifests of the
+// repository, one per platform (eg. "linux/amd64"), and returns its digest.
+func (r *Registry) PushIndex(repository, tag string, manifests map[string]string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	repo := r.repository(repository)
+	index := struct {
+		SchemaVersion int          `json:"schemaVersion"`
+		MediaType     string       `json:"mediaType"`
+		Manifests     []descriptor `json:"manifests"`
+	}{SchemaVersion: 2, MediaType: mediaTypeManifestList}
+	for platform, digest := range manifests {
+		parts := strings.SplitN(platform, "/", 2)
+		index.Manifests = append(index.Manifests, descriptor{
+			MediaType: mediaTypeManifest,
+			Size:      len(repo.manifests[digest].data),
+			Digest:    digest,
+			Platform:  map[string]string{"os": parts[0], "architecture": parts[1]},
+		})
+	}
+	data, _ := json.Marshal(index)
+	digest := Digest(data)
+	repo.manifests[digest] = manifest{mediaType: mediaTypeManifestList, data: data}
+	repo.manifests[tag] = repo.manifests[digest]
+	return digest
+}
+
+// HasManifest returns whether the repository holds the manifest with the
+// given tag or digest.
+func (r *Registry) HasManifest(repository, reference string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	repo, ok := r.repositories[repository]
+	if !ok {
+		return false
+	}
+	_, ok = repo.manifests[reference]
+	return ok
+}
+
+// HasBlob returns whether the repository holds the given blob.
+func (r *Registry) HasBlob(repository, digest string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	repo, ok := r.repositories[repository]
+	if !ok {
+		return false
+	}
+	_, ok = repo.blobs[digest]
+	return ok
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Requests[req.Method]++
+
+	if r.Username != "" {
+		username, password, ok := req.BasicAuth()
+		if !ok || username != r.Username || password != r.Password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registrytest"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case req.URL.Path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case strings.HasSuffix(path, "/tags/list"):
+		r.serveTags(w, req, strings.TrimSuffix(path, "/tags/list"))
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		r.serveManifest(w, req, path[:i], path[i+len("/manifests/"):])
+	case strings.Contains(path, "/blobs/uploads/"):
+		i := strings.LastIndex(path, "/blobs/uploads/")
+		r.serveUpload(w, req, path[:i], path[i+len("/blobs/uploads/"):])
+	case strings.Contains(path, "/blobs/"):
+		i := strings.LastIndex(path, "/blobs/")
+		r.serveBlob(w, req, path[:i], path[i+len("/blobs/"):])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *Registry) serveManifest(w http.ResponseWriter, req *http.Request, name, reference string) {
+	repo := r.repository(name)
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		m, ok := repo.manifests[reference]
+		if !ok {
+			http.Error(w, "manifest unknown", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", m.mediaType)
+		w.Header().Set("Docker-Content-Digest", Digest(m.data))
+		w.Header().Set("Content-Length", fmt.Sprint(len(m.data)))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(m.data)
+		}
+	case http.MethodPut:
+		data, _ := ioutil.ReadAll(req.Body)
+		m := manifest{mediaType: req.Header.Get("Content-Type"), data: data}
+		digest := Digest(data)
+		repo.manifests[digest] = m
+		repo.manifests[reference] = m
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// serveTags lists the tags of a repository, paginated when the client asks for
+// at most n tags or when TagsPageSize is set.
+func (r *Registry) serveTags(w http.ResponseWriter, req *http.Request, name string) {
+	repo, ok := r.repositories[name]
+	if !ok {
+		http.Error(w, "name unknown", http.StatusNotFound)
+		return
+	}
+	var tags []string
+	for reference := range repo.manifests {
+		if !strings.HasPrefix(reference, "sha256:") && reference > req.URL.Query().Get("last") {
+			tags = append(tags, reference)
+		}
+	}
+	sort.Strings(tags)
+	n, err := strconv.Atoi(req.URL.Query().Get("n"))
+	if err != nil {
+		n = r.TagsPageSize
+	}
+	if n > 0 && n < len(tags) {
+		tags = tags[:n]
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=%d&last=%s>; rel="next"`, name, n, tags[n-1]))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}{name, tags})
+}
+
+func (r *Registry) serveBlob(w http.ResponseWriter, req *http.Request, name, digest string) {
+	blob, ok := r.repository(name).blobs[digest]
+	if !ok {
+		http.Error(w, "blob unknown", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Docker-Content-Digest", digest)
+	w.Header().Set("Content-Length", fmt.Sprint(len(blob)))
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(blob)
+	}
+}
+
+func (r *Registry) serveUpload(w http.ResponseWriter, req *http.Request, name, id string) {
+	repo := r.repository(name)
+	switch req.Method {
+	case http.MethodPost:
+		// Cross repository mount.
+		if mount, from := req.URL.Query().Get("mount"), req.URL.Query().Get("from"); mount != "" {
+			if src, ok := r.repositories[from]; ok {
+				if blob, ok := src.blobs[mount]; ok {
+					repo.blobs[mount] = blob
+					w.Header().Set("Docker-Content-Digest", mount)
+					w.WriteHeader(http.StatusCreated)
+					return
+				}
+			}
+		}
+		id := fmt.Sprintf("upload-%d", len(r.uploads)+1)
+		r.uploads[id] = nil
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", name, id))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPatch:
+		data, _ := ioutil.ReadAll(req.Body)
+		r.uploads[id] = append(r.uploads[id], data...)
+		w.Header().Set("Location", req.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		data, _ := ioutil.ReadAll(req.Body)
+		blob := append(r.uploads[id], data...)
+		delete(r.uploads, id)
+		digest := req.URL.Query().Get("digest")
+		if Digest(blob) != digest {
+			http.Error(w, "digest invalid", http.StatusBadRequest)
+			return
+		}
+		repo.blobs[digest] = blob
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
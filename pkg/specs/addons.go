@@ -0,0 +1,62 @@
+package specs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	existinginfra1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+// AddonBuildOptions returns the build options the cluster manifest sets for
+// all its addons. namespace is the namespace of the wksctl controller.
+// Commands building addons add their own options, eg. BasePath.
+func AddonBuildOptions(cluster *clusterv1.Cluster, spec *existinginfra1.ClusterSpec, namespace string) (addons.BuildOptions, error) {
+	network, err := CNINetwork(cluster)
+	if err != nil {
+		return addons.BuildOptions{}, err
+	}
+	return addons.BuildOptions{
+		ImageRepository: spec.ImageRepository,
+		PinDigests:      PinAddonDigests(cluster),
+		ExtVars:         AddonExtVars(cluster, spec, namespace),
+		Network:         network,
+	}, nil
+}
+
+// BuildAddons builds the addons of the cluster spec concurrently, jobs at a
+// time, in subdirectories of dir. base holds the build options common to all
+// addons. It returns the built manifests of each addon.
+func BuildAddons(spec *existinginfra1.ClusterSpec, base addons.BuildOptions, transforms map[string][]addons.Transform, dir string, jobs int) ([]addons.Addon, [][]string, error) {
+	list := make([]addons.Addon, len(spec.Addons))
+	requests := make([]addons.BuildRequest, len(spec.Addons))
+	for i, addonDesc := range spec.Addons {
+		addon, err := addons.Get(addonDesc.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		list[i] = addon
+
+		buildOptions := base
+		buildOptions.OutputDirectory = filepath.Join(dir, strconv.Itoa(i))
+		if err := os.Mkdir(buildOptions.OutputDirectory, 0700); err != nil {
+			return nil, nil, err
+		}
+		buildOptions.Params = addonDesc.Params
+		buildOptions.Deps = addonDesc.Deps
+		buildOptions.Transforms = transforms[addonDesc.Name]
+		requests[i] = addons.BuildRequest{Addon: &list[i], Options: buildOptions}
+	}
+
+	manifests := make([][]string, len(requests))
+	for i, result := range addons.BuildAll(requests, jobs) {
+		if result.Err != nil {
+			return nil, nil, fmt.Errorf("failed to build addon %s: %v", list[i].ShortName, result.Err)
+		}
+		manifests[i] = result.Manifests
+	}
+	return list, manifests, nil
+}
@@ -0,0 +1,107 @@
+package specs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	existinginfra1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+// CNIAddon returns the name of the CNI addon selected for the cluster, if any.
+func CNIAddon(cluster *clusterv1.Cluster) (string, bool) {
+	name, ok := cluster.Annotations[CNIAddonAnnotation]
+	return name, ok
+}
+
+func podCIDRBlocks(cluster *clusterv1.Cluster) []string {
+	network := cluster.Spec.ClusterNetwork
+	if network == nil || network.Pods == nil {
+		return nil
+	}
+	return network.Pods.CIDRBlocks
+}
+
+// CNINetwork returns the network settings of the cluster given to its CNI
+// addon.
+func CNINetwork(cluster *clusterv1.Cluster) (addons.Network, error) {
+	var network addons.Network
+	if pods := podCIDRBlocks(cluster); len(pods) > 0 {
+		network.PodCIDR = pods[0]
+	}
+	mtu, err := CNIMTU(cluster)
+	if err != nil {
+		return network, err
+	}
+	network.MTU = mtu
+	return network, nil
+}
+
+// BuildCNI builds the CNI addon selected for the cluster and returns its
+// objects as a single YAML manifest.
+func BuildCNI(cluster *clusterv1.Cluster, spec *existinginfra1.ClusterSpec) ([]byte, error) {
+	name, ok := CNIAddon(cluster)
+	if !ok {
+		return nil, fmt.Errorf("no CNI addon selected")
+	}
+	addon, err := addons.GetCNI(name)
+	if err != nil {
+		return nil, err
+	}
+	network, err := CNINetwork(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "wksctl-cni")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	manifests, err := addon.Build(addons.BuildOptions{
+		OutputDirectory: dir,
+		ImageRepository: spec.ImageRepository,
+		PinDigests:      PinAddonDigests(cluster),
+		Network:         network,
+		YAML:            true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Leave the inventory out, the CNI isn't pruned.
+	return addons.ManifestYAML(manifests[:len(manifests)-1])
+}
+
+// CNIReadiness returns the readiness checks of the CNI addon selected for the
+// cluster, if any.
+func CNIReadiness(cluster *clusterv1.Cluster) ([]addons.ReadinessCheck, error) {
+	name, ok := CNIAddon(cluster)
+	if !ok {
+		return nil, nil
+	}
+	addon, err := addons.GetCNI(name)
+	if err != nil {
+		return nil, err
+	}
+	return addon.ReadinessChecks(nil), nil
+}
+
+// CNIInstallScript returns the script installing the CNI plugin on the seed
+// node: the cni field of the cluster spec, or a script applying the objects of
+// the selected CNI addon.
+func CNIInstallScript(cluster *clusterv1.Cluster, spec *existinginfra1.ClusterSpec) (string, error) {
+	if _, ok := CNIAddon(cluster); !ok {
+		return spec.CNI, nil
+	}
+	manifest, err := BuildCNI(cluster, spec)
+	if err != nil {
+		return "", err
+	}
+	// The script goes through variable expansion: the manifest is encoded so
+	// that no '$' is left in it.
+	return fmt.Sprintf("echo %s | base64 -d | kubectl apply -f -", base64.StdEncoding.EncodeToString(manifest)), nil
+}
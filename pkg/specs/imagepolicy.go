@@ -0,0 +1,237 @@
+package specs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	existinginfrav1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/apis/wksprovider/controller/manifests"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/utilities/manifest"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+// ImagePolicy restricts the container images the cluster components pull. It
+// is specified in the ImagePolicyAnnotation of the Cluster object, eg.:
+//
+//	wksctl.weave.works/image-policy: |
+//	  allowedRegistries: ["registry.example.com", "quay.io/example"]
+//	  requireDigests: true
+//
+// Addon images are checked as built for the cluster: with their parameters,
+// transforms and the imageRepository of the cluster.
+type ImagePolicy struct {
+	// AllowedRegistries are the registries images are pulled from, optionally
+	// followed by a path prefix, eg. "registry.example.com/team". Any
+	// registry is allowed when empty.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+	// RequireDigests requires images to be pinned to their digest. Addon
+	// images pinned when building addons, see PinAddonDigestsAnnotation,
+	// comply.
+	RequireDigests bool `json:"requireDigests,omitempty"`
+}
+
+func parseImagePolicy(data []byte) (*ImagePolicy, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var policy ImagePolicy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("invalid image policy: %v", err)
+	}
+	for _, allowed := range policy.AllowedRegistries {
+		if allowed == "" || strings.ContainsAny(allowed, " @") {
+			return nil, fmt.Errorf("invalid image policy: invalid registry %q", allowed)
+		}
+	}
+	return &policy, nil
+}
+
+// allows returns whether the registry and repository of image are allowed.
+func (p *ImagePolicy) allows(image registry.Image) bool {
+	if len(p.AllowedRegistries) == 0 {
+		return true
+	}
+	location := image.Registry + "/" + image.Repository()
+	for _, allowed := range p.AllowedRegistries {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if !strings.Contains(allowed, "/") && image.Registry == allowed {
+			return true
+		}
+		if strings.HasPrefix(location, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error if the image doesn't comply with the policy. pinned
+// tells the image is pinned to its digest when deployed.
+func (p *ImagePolicy) Check(image string, pinned bool) error {
+	ref, err := registry.NewImage(image)
+	if err != nil {
+		return err
+	}
+	if !p.allows(ref.Normalize()) {
+		return fmt.Errorf("image %s is not from an allowed registry (%s)", image, strings.Join(p.AllowedRegistries, ", "))
+	}
+	if p.RequireDigests && !pinned && ref.Digest == "" {
+		return fmt.Errorf("image %s is not pinned to a digest", image)
+	}
+	return nil
+}
+
+// ParseImagePolicy returns the image policy of the cluster, nil if it has
+// none.
+func ParseImagePolicy(cluster *clusterv1.Cluster) (*ImagePolicy, error) {
+	value, ok := cluster.Annotations[ImagePolicyAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return parseImagePolicy([]byte(value))
+}
+
+// validateImagePolicy checks the images of the controller manifests and of
+// the CNI addon against the image policy of the cluster. controllerImage, if
+// not empty, overrides the image of the WKS controller, see the
+// --controller-image flag of the commands setting up the seed node. The
+// images of the other addons are checked by validateAddons, which builds them
+// already.
+func validateImagePolicy(cluster *clusterv1.Cluster, spec *existinginfrav1.ClusterSpec, manifestPath, controllerImage string) field.ErrorList {
+	policyPath := clusterPath("metadata", "annotations", ImagePolicyAnnotation)
+	policy, err := ParseImagePolicy(cluster)
+	if err != nil {
+		return field.ErrorList{field.Invalid(policyPath, cluster.Annotations[ImagePolicyAnnotation], err.Error())}
+	}
+	if policy == nil {
+		return field.ErrorList{}
+	}
+
+	var errors field.ErrorList
+	if controllerImage != "" {
+		image := controllerImage
+		if controllerImage, err = addons.UpdateImage(controllerImage, spec.ImageRepository); err != nil {
+			return field.ErrorList{field.Invalid(policyPath, image, err.Error())}
+		}
+	}
+	if err := policy.CheckControllerImages(controllerImage); err != nil {
+		errors = append(errors, field.Invalid(policyPath, cluster.Annotations[ImagePolicyAnnotation], err.Error()))
+	}
+
+	// The CNI addon and its network are reported by validateCNI.
+	name, ok := CNIAddon(cluster)
+	if !ok {
+		return errors
+	}
+	addon, err := addons.GetCNI(name)
+	if err != nil {
+		return errors
+	}
+	buildOptions, err := AddonBuildOptions(cluster, spec, manifest.DefaultNamespace)
+	if err != nil || addon.ValidateOptions(&buildOptions) != nil {
+		return errors
+	}
+	buildOptions.BasePath = filepath.Dir(manifestPath)
+	cniPath := clusterPath("metadata", "annotations", CNIAddonAnnotation)
+	images, err := builtImages(&addon, buildOptions)
+	if err != nil {
+		return append(errors, field.Invalid(cniPath, addon.ShortName, err.Error()))
+	}
+	return append(errors, checkAddonImages(policy, PinAddonDigests(cluster), cniPath, &addon, images)...)
+}
+
+// checkAddonImages checks the images of the addon, as built, against the
+// policy. pinned tells they are pinned to their digest when deployed.
+func checkAddonImages(policy *ImagePolicy, pinned bool, path *field.Path, addon *addons.Addon, images []registry.Image) field.ErrorList {
+	var errors field.ErrorList
+	for _, image := range images {
+		if err := policy.Check(image.String(), pinned); err != nil {
+			errors = append(errors, field.Invalid(path, image.String(), fmt.Sprintf("addon %s: %v", addon.ShortName, err)))
+		}
+	}
+	return errors
+}
+
+// builtImages builds the addon and lists the images of its objects, as
+// deployed but for pinning them to their digest.
+func builtImages(addon *addons.Addon, buildOptions addons.BuildOptions) ([]registry.Image, error) {
+	dir, err := ioutil.TempDir("", "wksctl-image-policy")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	buildOptions.OutputDirectory = dir
+	// Resolving digests requires pulling from the registries, see the pinned
+	// argument of Check.
+	buildOptions.PinDigests = false
+	// The build is only inspected and removed: secret parameters don't need
+	// sealing.
+	buildOptions.AllowPlaintextSecrets = true
+	manifests, err := addon.Build(buildOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build addon: %v", err)
+	}
+	return manifestsImages(manifests)
+}
+
+// manifestsImages lists the images of the objects of the manifest files.
+func manifestsImages(manifests []string) ([]registry.Image, error) {
+	var images []registry.Image
+	seen := make(map[string]bool)
+	for _, m := range manifests {
+		data, err := ioutil.ReadFile(m)
+		if err != nil {
+			return nil, err
+		}
+		manifestImages, err := addons.ListImagesFromManifest(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range manifestImages {
+			if !seen[image.String()] {
+				seen[image.String()] = true
+				images = append(images, image)
+			}
+		}
+	}
+	return images, nil
+}
+
+// CheckControllerImages checks the images of the controller manifests against
+// the policy, the WKS controller image being controllerImage if not empty.
+// Unlike addon images, controller images aren't rewritten with the
+// imageRepository of the cluster.
+func (p *ImagePolicy) CheckControllerImages(controllerImage string) error {
+	for _, name := range manifests.ControllerManifests {
+		var images []string
+		if name == manifests.WksControllerManifest && controllerImage != "" {
+			images = []string{controllerImage}
+		} else {
+			manifestImages, err := manifests.ListManifestImages(name)
+			if err != nil {
+				return err
+			}
+			for _, image := range manifestImages {
+				images = append(images, image.String())
+			}
+		}
+		for _, image := range images {
+			if err := p.Check(image, false); err != nil {
+				return fmt.Errorf("controller manifest %s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
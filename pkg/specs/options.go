@@ -0,0 +1,85 @@
+package specs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+// wksctl options which aren't part of the ExistingInfraCluster spec are
+// specified as annotations on the Cluster object: the ExistingInfraCluster API
+// belongs to the cluster controller, which wouldn't accept unknown fields in the
+// manifests it reads from git. See the "Cluster manifest options" section of
+// the README.
+const (
+	// PinAddonDigestsAnnotation, when "true", pins the container images of
+	// addons to their digest.
+	PinAddonDigestsAnnotation = "wksctl.weave.works/pin-addon-digests"
+	// CNIMTUAnnotation is the MTU of the pod network given to the CNI addon.
+	CNIMTUAnnotation = "wksctl.weave.works/cni-mtu"
+	// CNIAddonAnnotation names the CNI addon installed on the cluster, in
+	// place of the cni script of the ExistingInfraCluster spec.
+	CNIAddonAnnotation = "wksctl.weave.works/cni-addon"
+	// AddonTransformsAnnotation holds the transforms applied to the objects
+	// of addons, as a YAML map of addon names to lists of transforms. Addon
+	// entries of the ExistingInfraCluster spec can't hold them, eg.:
+	//
+	//	wksctl.weave.works/addon-transforms: |
+	//	  weave-net:
+	//	  - priorityClassName: system-node-critical
+	AddonTransformsAnnotation = "wksctl.weave.works/addon-transforms"
+	// RegistriesAnnotation holds the Registries of the cluster, in YAML.
+	RegistriesAnnotation = "wksctl.weave.works/registries"
+	// ImagePolicyAnnotation holds the ImagePolicy of the cluster, in YAML.
+	ImagePolicyAnnotation = "wksctl.weave.works/image-policy"
+)
+
+// PinAddonDigests returns whether addon images should be pinned to their
+// digest.
+func PinAddonDigests(cluster *clusterv1.Cluster) bool {
+	pin, _ := strconv.ParseBool(cluster.Annotations[PinAddonDigestsAnnotation])
+	return pin
+}
+
+// CNIMTU returns the MTU of the pod network, 0 when left to the CNI addon.
+func CNIMTU(cluster *clusterv1.Cluster) (int, error) {
+	mtu, ok := cluster.Annotations[CNIMTUAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(mtu)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid MTU %q", mtu)
+	}
+	return value, nil
+}
+
+// AddonTransforms returns the transforms of the addons of the cluster, indexed
+// by addon name.
+func AddonTransforms(cluster *clusterv1.Cluster) (map[string][]addons.Transform, error) {
+	transforms := make(map[string][]addons.Transform)
+	value, ok := cluster.Annotations[AddonTransformsAnnotation]
+	if !ok {
+		return transforms, nil
+	}
+	data, err := yaml.YAMLToJSON([]byte(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid addon transforms: %v", err)
+	}
+	var byAddon map[string]json.RawMessage
+	if err := json.Unmarshal(data, &byAddon); err != nil {
+		return nil, fmt.Errorf("invalid addon transforms: %v", err)
+	}
+	for name, raw := range byAddon {
+		t, err := addons.ParseTransforms(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transforms for addon %s: %v", name, err)
+		}
+		transforms[name] = t
+	}
+	return transforms, nil
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/weaveworks/wksctl/pkg/addons"
 )
 
 const clusterMissingClusterDefinition = `
@@ -63,3 +65,52 @@ func TestParseCluster(t *testing.T) {
 	assert.Error(t, parseConfig(clusterMissingClusterDefinition))
 	assert.Error(t, parseConfig(clusterMissingExistingInfraClusterDefinition))
 }
+
+const clusterWithAddonTransforms = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/addon-transforms: |
+      weave-net:
+      - labels:
+          team: infra
+      - priorityClassName: system-node-critical
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+  infrastructureRef:
+    kind: ExistingInfraCluster
+    name: example
+`
+
+func TestAddonTransforms(t *testing.T) {
+	manifest := mergeObjects(clusterWithAddonTransforms, clusterMissingClusterDefinition)
+	assert.NoError(t, parseConfig(manifest))
+
+	cluster, _ := clusterFromString(t, manifest)
+	transforms, err := AddonTransforms(cluster)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]addons.Transform{
+		"weave-net": {
+			{Labels: map[string]string{"team": "infra"}},
+			{PriorityClassName: "system-node-critical"},
+		},
+	}, transforms)
+
+	cluster.Annotations[AddonTransformsAnnotation] = strings.Replace(cluster.Annotations[AddonTransformsAnnotation], "priorityClassName", "priority", 1)
+	_, err = AddonTransforms(cluster)
+	assert.Error(t, err)
+	cluster.Annotations[AddonTransformsAnnotation] = "[]"
+	_, err = AddonTransforms(cluster)
+	assert.Error(t, err)
+
+	delete(cluster.Annotations, AddonTransformsAnnotation)
+	transforms, err = AddonTransforms(cluster)
+	assert.NoError(t, err)
+	assert.Empty(t, transforms)
+}
@@ -0,0 +1,301 @@
+package specs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	existinginfra1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+// Registries configures the container runtime of every machine to pull images
+// from mirrors, insecure registries and private registries. It is specified
+// in the RegistriesAnnotation of the Cluster object, eg.:
+//
+//	wksctl.weave.works/registries: |
+//	  mirrors:
+//	  - registry: docker.io
+//	    endpoints: ["https://mirror.example.com"]
+//	  insecure: ["registry.example.com:5000"]
+//	  credentialsSecretFile: registry-credentials.yaml
+type Registries struct {
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+	// Insecure registries are accessed over plain HTTP, or over HTTPS
+	// without verifying their certificate.
+	Insecure []string `json:"insecure,omitempty"`
+	// CredentialsSecretFile is the path, relative to the configuration
+	// directory, of a SealedSecret of type kubernetes.io/dockerconfigjson
+	// holding the credentials of private registries. Sealing it cluster-wide
+	// lets addons pull images with it from any namespace. wksctl apply
+	// unseals it and writes the credentials on the machines of the machines
+	// manifest over SSH, wksctl registry-credentials on the machines added to
+	// it later.
+	CredentialsSecretFile string `json:"credentialsSecretFile,omitempty"`
+}
+
+// RegistryMirror lists the mirrors of a registry.
+type RegistryMirror struct {
+	// Registry is the host of the mirrored registry, eg. "docker.io".
+	Registry string `json:"registry"`
+	// Endpoints are the URLs of the mirrors, tried in order.
+	Endpoints []string `json:"endpoints"`
+}
+
+// ParseRegistries returns the registries configuration of the cluster, nil if
+// it has none.
+func ParseRegistries(cluster *clusterv1.Cluster) (*Registries, error) {
+	value, ok := cluster.Annotations[RegistriesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return parseRegistries([]byte(value))
+}
+
+func parseRegistries(data []byte) (*Registries, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var registries Registries
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&registries); err != nil {
+		return nil, fmt.Errorf("invalid registries: %v", err)
+	}
+	for _, mirror := range registries.Mirrors {
+		if mirror.Registry == "" {
+			return nil, fmt.Errorf("invalid registries: mirrors need a registry")
+		}
+		if len(mirror.Endpoints) == 0 {
+			return nil, fmt.Errorf("invalid registries: no endpoint for the mirrors of %s", mirror.Registry)
+		}
+		for _, endpoint := range mirror.Endpoints {
+			u, err := url.Parse(endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("invalid registries: invalid endpoint %q for the mirrors of %s", endpoint, mirror.Registry)
+			}
+		}
+	}
+	for _, host := range registries.Insecure {
+		if host == "" || strings.ContainsAny(host, "/ ") {
+			return nil, fmt.Errorf("invalid registries: invalid insecure registry %q", host)
+		}
+	}
+	return &registries, nil
+}
+
+// validateRegistries checks the registries configuration of the cluster, and
+// that its container runtime can be configured for it.
+func validateRegistries(cluster *clusterv1.Cluster, spec *existinginfra1.ClusterSpec, _ string) field.ErrorList {
+	registries, err := ParseRegistries(cluster)
+	if err == nil {
+		_, err = RegistryFiles(spec, registries)
+	}
+	if err != nil {
+		return field.ErrorList{
+			field.Invalid(clusterPath("metadata", "annotations", RegistriesAnnotation), cluster.Annotations[RegistriesAnnotation], err.Error()),
+		}
+	}
+	return field.ErrorList{}
+}
+
+const (
+	// registriesConfigMap holds the sources of the registry files in the
+	// cluster.
+	registriesConfigMap = "registries"
+
+	dockerConfigPath        = "/etc/docker/daemon.json"
+	dockerCredentialsPath   = "/root/.docker/config.json"
+	containerdConfigPath    = "/etc/containerd/config.toml"
+	kubeletCredentialsPath  = "/var/lib/kubelet/config.json"
+	dockerHubRegistry       = "docker.io"
+	containerdRegistryTable = `plugins."io.containerd.grpc.v1.cri".registry`
+)
+
+// RegistryFiles returns the os.files of the cluster spec, configuring the
+// container runtime of every machine for the registries. Like every os.files
+// source, they are stored in a ConfigMap of the cluster: they never hold the
+// registry credentials, see RegistryCredentialsPaths.
+// The registries are merged into the docker daemon configuration when the
+// os.files already write one, e.g. to set the cgroup driver. containerd
+// configurations can't be merged: the registries must then be configured in
+// the os.files one.
+func RegistryFiles(spec *existinginfra1.ClusterSpec, registries *Registries) ([]existinginfra1.FileSpec, error) {
+	files := spec.OS.Files
+	if registries == nil || (len(registries.Mirrors) == 0 && len(registries.Insecure) == 0) {
+		return files, nil
+	}
+
+	var (
+		destination, key string
+		config           []byte
+		err              error
+	)
+	existing := -1
+	switch spec.CRI.Kind {
+	case "docker":
+		destination, key = dockerConfigPath, "daemon.json"
+		existing = fileIndex(files, destination)
+		var daemonConfig []byte
+		if existing >= 0 {
+			daemonConfig = []byte(files[existing].Source.Contents)
+		}
+		config, err = dockerDaemonConfig(daemonConfig, registries)
+		if err != nil {
+			return nil, err
+		}
+	case "containerd":
+		destination, key = containerdConfigPath, "config.toml"
+		if fileIndex(files, destination) >= 0 {
+			return nil, fmt.Errorf("os.files already write %s, configure the registries in it instead", destination)
+		}
+		config = containerdConfig(registries)
+	default:
+		return nil, fmt.Errorf("can't configure the registries of container runtime %q", spec.CRI.Kind)
+	}
+
+	// Never modify the os.files of spec in place.
+	files = append([]existinginfra1.FileSpec(nil), files...)
+	if existing >= 0 {
+		files[existing].Source.Contents = string(config)
+		return files, nil
+	}
+	return append(files, existinginfra1.FileSpec{
+		Source:      existinginfra1.SourceSpec{ConfigMap: registriesConfigMap, Key: key, Contents: string(config)},
+		Destination: destination,
+	}), nil
+}
+
+// fileIndex returns the index of the file written to destination in files,
+// -1 if none is.
+func fileIndex(files []existinginfra1.FileSpec, destination string) int {
+	for i, file := range files {
+		if file.Destination == destination {
+			return i
+		}
+	}
+	return -1
+}
+
+// RegistryCredentialsPaths returns where the kubelet, and the docker client,
+// look for the credentials of private registries on a machine.
+func RegistryCredentialsPaths(cri existinginfra1.ContainerRuntime) []string {
+	paths := []string{kubeletCredentialsPath}
+	if cri.Kind == "docker" {
+		paths = append(paths, dockerCredentialsPath)
+	}
+	return paths
+}
+
+// CheckRegistryCredentials checks the unsealed registry credentials are a
+// docker configuration JSON with auths.
+func CheckRegistryCredentials(credentials []byte) error {
+	var config struct {
+		Auths map[string]json.RawMessage `json:"auths"`
+	}
+	if err := json.Unmarshal(credentials, &config); err != nil || len(config.Auths) == 0 {
+		return fmt.Errorf("invalid registry credentials: expected a docker configuration with auths")
+	}
+	return nil
+}
+
+// ImagePullSecret returns the SealedSecret holding the registry credentials
+// of the cluster, read from the configuration directory, nil if it has none.
+// Addons pull images from private registries with it.
+func ImagePullSecret(cluster *clusterv1.Cluster, configDir string) ([]byte, error) {
+	registries, err := ParseRegistries(cluster)
+	if err != nil || registries == nil || registries.CredentialsSecretFile == "" {
+		return nil, err
+	}
+	return ioutil.ReadFile(filepath.Join(configDir, registries.CredentialsSecretFile))
+}
+
+// dockerDaemonConfig returns the docker daemon configuration daemonConfig,
+// which may be empty, with the registries added to it. docker only uses
+// mirrors for the Docker Hub.
+func dockerDaemonConfig(daemonConfig []byte, registries *Registries) ([]byte, error) {
+	config := map[string]interface{}{}
+	if len(bytes.TrimSpace(daemonConfig)) > 0 {
+		if err := json.Unmarshal(daemonConfig, &config); err != nil {
+			return nil, fmt.Errorf("can't add the registries to %s: %v", dockerConfigPath, err)
+		}
+	}
+	add := func(key string, values []string) error {
+		if len(values) == 0 {
+			return nil
+		}
+		var list []interface{}
+		if existing, ok := config[key]; ok {
+			if list, ok = existing.([]interface{}); !ok {
+				return fmt.Errorf("can't add the registries to %s: %s isn't a list", dockerConfigPath, key)
+			}
+		}
+		present := make(map[string]bool, len(list))
+		for _, value := range list {
+			if s, ok := value.(string); ok {
+				present[s] = true
+			}
+		}
+		for _, value := range values {
+			if !present[value] {
+				list = append(list, value)
+			}
+		}
+		config[key] = list
+		return nil
+	}
+
+	var mirrors []string
+	for _, mirror := range registries.Mirrors {
+		if mirror.Registry != dockerHubRegistry {
+			return nil, fmt.Errorf("docker only mirrors %s, not %s", dockerHubRegistry, mirror.Registry)
+		}
+		mirrors = append(mirrors, mirror.Endpoints...)
+	}
+	if err := add("registry-mirrors", mirrors); err != nil {
+		return nil, err
+	}
+	if err := add("insecure-registries", registries.Insecure); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// containerdConfig returns the containerd configuration for the registries.
+// Insecure registries are reached over plain HTTP, and over HTTPS without
+// verifying their certificate, unless they are mirrored.
+func containerdConfig(registries *Registries) []byte {
+	var b strings.Builder
+	b.WriteString("version = 2\n")
+	mirrored := make(map[string]bool)
+	for _, mirror := range registries.Mirrors {
+		mirrored[mirror.Registry] = true
+		fmt.Fprintf(&b, "\n[%s.mirrors.%q]\n  endpoint = %s\n", containerdRegistryTable, mirror.Registry, tomlStrings(mirror.Endpoints))
+	}
+	for _, host := range registries.Insecure {
+		if !mirrored[host] {
+			fmt.Fprintf(&b, "\n[%s.mirrors.%q]\n  endpoint = %s\n", containerdRegistryTable, host, tomlStrings([]string{"http://" + host, "https://" + host}))
+		}
+		fmt.Fprintf(&b, "\n[%s.configs.%q.tls]\n  insecure_skip_verify = true\n", containerdRegistryTable, host)
+	}
+	return []byte(b.String())
+}
+
+func tomlStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,196 @@
+package specs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	existinginfra1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
+)
+
+const clusterWithRegistries = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/registries: |
+      mirrors:
+      - registry: docker.io
+        endpoints: ["https://mirror.example.com"]
+      insecure: ["registry.example.com:5000"]
+      credentialsSecretFile: registry-credentials.yaml
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+  infrastructureRef:
+    kind: ExistingInfraCluster
+    name: example
+`
+
+func TestParseRegistries(t *testing.T) {
+	manifest := mergeObjects(clusterWithRegistries, clusterMissingClusterDefinition)
+	assert.NoError(t, parseConfig(manifest))
+
+	cluster, _ := clusterFromString(t, manifest)
+	registries, err := ParseRegistries(cluster)
+	assert.NoError(t, err)
+	assert.Equal(t, &Registries{
+		Mirrors:               []RegistryMirror{{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}}},
+		Insecure:              []string{"registry.example.com:5000"},
+		CredentialsSecretFile: "registry-credentials.yaml",
+	}, registries)
+
+	for _, invalid := range []string{
+		strings.Replace(manifest, "https://mirror.example.com", "mirror.example.com", 1),
+		strings.Replace(manifest, "registry.example.com:5000", "registry.example.com/org", 1),
+		strings.Replace(manifest, "credentialsSecretFile", "credentials", 1),
+		strings.Replace(manifest, `endpoints: ["https://mirror.example.com"]`, "endpoints: []", 1),
+	} {
+		cluster, _ := clusterFromString(t, invalid)
+		_, err = ParseRegistries(cluster)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"cluster.metadata.annotations.wksctl.weave.works/registries"}, fieldsInError(validateRegistries(cluster, &existinginfra1.ClusterSpec{}, "")))
+	}
+
+	delete(cluster.Annotations, RegistriesAnnotation)
+	registries, err = ParseRegistries(cluster)
+	assert.NoError(t, err)
+	assert.Nil(t, registries)
+}
+
+func TestImagePullSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "registry-credentials.yaml"), []byte("kind: SealedSecret\n"), 0600))
+
+	cluster, _ := clusterFromString(t, mergeObjects(clusterWithRegistries, clusterMissingClusterDefinition))
+	secret, err := ImagePullSecret(cluster, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "kind: SealedSecret\n", string(secret))
+
+	delete(cluster.Annotations, RegistriesAnnotation)
+	secret, err = ImagePullSecret(cluster, dir)
+	assert.NoError(t, err)
+	assert.Nil(t, secret)
+}
+
+const credentials = `{"auths":{"registry.example.com":{"auth":"dXNlcjpwYXNzd29yZA=="}}}`
+
+func specWithCRI(kind string, files ...existinginfra1.FileSpec) *existinginfra1.ClusterSpec {
+	spec := &existinginfra1.ClusterSpec{CRI: existinginfra1.ContainerRuntime{Kind: kind}}
+	spec.OS.Files = files
+	return spec
+}
+
+func TestRegistryFilesDocker(t *testing.T) {
+	files, err := RegistryFiles(specWithCRI("docker"), &Registries{
+		Mirrors:  []RegistryMirror{{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}}},
+		Insecure: []string{"registry.example.com:5000"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, dockerConfigPath, files[0].Destination)
+	assert.Equal(t, `{
+  "insecure-registries": [
+    "registry.example.com:5000"
+  ],
+  "registry-mirrors": [
+    "https://mirror.example.com"
+  ]
+}
+`, files[0].Source.Contents)
+	assert.Equal(t, registriesConfigMap, files[0].Source.ConfigMap)
+
+	// docker only mirrors the Docker Hub.
+	_, err = RegistryFiles(specWithCRI("docker"), &Registries{
+		Mirrors: []RegistryMirror{{Registry: "quay.io", Endpoints: []string{"https://mirror.example.com"}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestRegistryFilesDockerMerge(t *testing.T) {
+	daemonConfig := existinginfra1.FileSpec{
+		Source: existinginfra1.SourceSpec{
+			ConfigMap: "docker",
+			Key:       "daemon.json",
+			Contents:  `{"exec-opts": ["native.cgroupdriver=cgroupfs"], "insecure-registries": ["registry.example.com:5000"]}`,
+		},
+		Destination: dockerConfigPath,
+	}
+	spec := specWithCRI("docker", daemonConfig)
+	files, err := RegistryFiles(spec, &Registries{
+		Insecure: []string{"registry.example.com:5000", "other.example.com:5000"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, dockerConfigPath, files[0].Destination)
+	assert.Equal(t, "docker", files[0].Source.ConfigMap)
+	assert.Equal(t, `{
+  "exec-opts": [
+    "native.cgroupdriver=cgroupfs"
+  ],
+  "insecure-registries": [
+    "registry.example.com:5000",
+    "other.example.com:5000"
+  ]
+}
+`, files[0].Source.Contents)
+	// The os.files of the spec are left untouched.
+	assert.Equal(t, daemonConfig, spec.OS.Files[0])
+
+	daemonConfig.Source.Contents = `{"insecure-registries": "registry.example.com:5000"}`
+	_, err = RegistryFiles(specWithCRI("docker", daemonConfig), &Registries{Insecure: []string{"other.example.com:5000"}})
+	assert.Error(t, err)
+}
+
+func TestRegistryFilesContainerd(t *testing.T) {
+	files, err := RegistryFiles(specWithCRI("containerd"), &Registries{
+		Mirrors:  []RegistryMirror{{Registry: "quay.io", Endpoints: []string{"https://mirror.example.com"}}},
+		Insecure: []string{"registry.example.com:5000"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, containerdConfigPath, files[0].Destination)
+	assert.Equal(t, `version = 2
+
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."quay.io"]
+  endpoint = ["https://mirror.example.com"]
+
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."registry.example.com:5000"]
+  endpoint = ["http://registry.example.com:5000", "https://registry.example.com:5000"]
+
+[plugins."io.containerd.grpc.v1.cri".registry.configs."registry.example.com:5000".tls]
+  insecure_skip_verify = true
+`, files[0].Source.Contents)
+
+	// containerd configurations aren't merged.
+	_, err = RegistryFiles(specWithCRI("containerd", existinginfra1.FileSpec{Destination: containerdConfigPath}), &Registries{
+		Insecure: []string{"registry.example.com:5000"},
+	})
+	assert.Error(t, err)
+}
+
+func TestRegistryFilesErrors(t *testing.T) {
+	files, err := RegistryFiles(specWithCRI("docker"), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	_, err = RegistryFiles(specWithCRI("cri-o"), &Registries{Insecure: []string{"registry:5000"}})
+	assert.Error(t, err)
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	assert.NoError(t, CheckRegistryCredentials([]byte(credentials)))
+	assert.Error(t, CheckRegistryCredentials([]byte(`{"user":"password"}`)))
+
+	assert.Equal(t, []string{kubeletCredentialsPath, dockerCredentialsPath}, RegistryCredentialsPaths(existinginfra1.ContainerRuntime{Kind: "docker"}))
+	assert.Equal(t, []string{kubeletCredentialsPath}, RegistryCredentialsPaths(existinginfra1.ContainerRuntime{Kind: "containerd"}))
+}
@@ -1,12 +1,20 @@
 package specs
 
 import (
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	existinginfra1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
 	capeimachine "github.com/weaveworks/cluster-api-provider-existinginfra/pkg/cluster/machine"
 	"github.com/weaveworks/cluster-api-provider-existinginfra/pkg/specs"
+	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/apis/wksprovider/controller/manifests"
+	"github.com/weaveworks/wksctl/pkg/apis/wksprovider/machine/crds"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema"
 	"github.com/weaveworks/wksctl/pkg/utilities"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
@@ -16,34 +24,58 @@ import (
 // Utilities for managing cluster and machine specs.
 // Common code for commands that need to run ssh commands on master cluster nodes.
 
+// SkipValidation downgrades the validation errors of the manifests parsed by
+// NewFromPaths to warnings. It is set by the global --skip-validation flag.
+var SkipValidation bool
+
 // Get a "capeispecs.Specs" object that can create an SSHClient (and retrieve useful nested fields)
-func NewFromPaths(clusterManifestPath, machinesManifestPath string) *specs.Specs {
-	cluster, eic, machines, bml, err := parseManifests(clusterManifestPath, machinesManifestPath)
+func NewFromPaths(clusterManifestPath, machinesManifestPath string) (*specs.Specs, error) {
+	return NewFromPathsWithControllerImage(clusterManifestPath, machinesManifestPath, "")
+}
+
+// NewFromPathsWithControllerImage is NewFromPaths for the commands setting up
+// the seed node: controllerImage, if not empty, is the --controller-image
+// override of the WKS controller image, checked against the image policy of
+// the cluster.
+func NewFromPathsWithControllerImage(clusterManifestPath, machinesManifestPath, controllerImage string) (*specs.Specs, error) {
+	cluster, eic, machines, bml, err := parseManifests(clusterManifestPath, machinesManifestPath, controllerImage)
 	if err != nil {
-		log.Fatal("Error parsing manifest: ", err)
+		return nil, err
+	}
+	return specs.New(cluster, eic, machines, bml), nil
+}
+
+// checkValidation prints the validation errors of a manifest. They fail the
+// validation unless SkipValidation is set, printing them as warnings instead.
+func checkValidation(manifestPath string, errors field.ErrorList) error {
+	if len(errors) == 0 {
+		return nil
+	}
+	if SkipValidation {
+		for _, e := range errors {
+			log.Warnf("%v (validation skipped)", e)
+		}
+		return nil
 	}
-	return specs.New(cluster, eic, machines, bml)
+	utilities.PrintErrors(errors)
+	return apierrors.InvalidMachineConfiguration(
+		"%s failed validation, use --skip-validation to force the operation", manifestPath)
 }
 
-func parseManifests(clusterManifestPath, machinesManifestPath string) (*clusterv1.Cluster, *existinginfra1.ExistingInfraCluster, []*clusterv1.Machine, []*existinginfra1.ExistingInfraMachine, error) {
+func parseManifests(clusterManifestPath, machinesManifestPath, controllerImage string) (*clusterv1.Cluster, *existinginfra1.ExistingInfraCluster, []*clusterv1.Machine, []*existinginfra1.ExistingInfraMachine, error) {
 	cluster, eic, err := ParseClusterManifest(clusterManifestPath)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
 	populateCluster(cluster)
 
-	validationErrors := validateCluster(cluster, eic, clusterManifestPath)
-	if len(validationErrors) > 0 {
-		utilities.PrintErrors(validationErrors)
-		return nil, nil, nil, nil, apierrors.InvalidMachineConfiguration(
-			"%s failed validation, use --skip-validation to force the operation", clusterManifestPath)
+	if err := checkValidation(clusterManifestPath, validateCluster(cluster, eic, clusterManifestPath, controllerImage)); err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	errorsHandler := func(machines []*clusterv1.Machine, bl []*existinginfra1.ExistingInfraMachine, errors field.ErrorList) ([]*clusterv1.Machine, []*existinginfra1.ExistingInfraMachine, error) {
-		if len(errors) > 0 {
-			utilities.PrintErrors(errors)
-			return nil, nil, apierrors.InvalidMachineConfiguration(
-				"%s failed validation, use --skip-validation to force the operation", machinesManifestPath)
+		if err := checkValidation(machinesManifestPath, errors); err != nil {
+			return nil, nil, err
 		}
 		return machines, bl, nil
 	}
@@ -65,3 +97,67 @@ func ParseClusterManifest(file string) (*clusterv1.Cluster, *existinginfra1.Exis
 
 	return specs.ParseCluster(f)
 }
+
+// AddonExtVars returns the jsonnet external variables describing the cluster
+// to its addons. namespace is the namespace of the wksctl controller.
+func AddonExtVars(cluster *clusterv1.Cluster, spec *existinginfra1.ClusterSpec, namespace string) map[string]string {
+	network := cluster.Spec.ClusterNetwork
+	var pods, services []string
+	if network != nil && network.Pods != nil {
+		pods = network.Pods.CIDRBlocks
+	}
+	if network != nil && network.Services != nil {
+		services = network.Services.CIDRBlocks
+	}
+	return map[string]string{
+		addons.ExtVarClusterName:       cluster.Name,
+		addons.ExtVarKubernetesVersion: spec.KubernetesVersion,
+		addons.ExtVarPodCIDRBlocks:     strings.Join(pods, ","),
+		addons.ExtVarServiceCIDRBlocks: strings.Join(services, ","),
+		addons.ExtVarNamespace:         namespace,
+	}
+}
+
+// NewAddonValidator creates a validator for the objects built by addons. It
+// knows about the Kubernetes API of the given version and the custom resources
+// defined by wksctl.
+func NewAddonValidator(kubernetesVersion string) (*schema.Validator, error) {
+	v, err := schema.New(kubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+	add := func(fs http.FileSystem, name string) error {
+		f, err := fs.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		data, err := ioutil.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		if err := v.AddCRDs(data); err != nil {
+			return errors.Wrapf(err, "failed to load CRDs from %s", name)
+		}
+		return nil
+	}
+
+	root, err := crds.CRDs.Open("/")
+	if err != nil {
+		return nil, err
+	}
+	files, err := root.Readdir(-1)
+	root.Close()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if err := add(crds.CRDs, "/"+f.Name()); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(manifests.Manifests, "05_sealed_secret_crd.yaml"); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
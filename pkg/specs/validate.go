@@ -0,0 +1,207 @@
+package specs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	capeimachine "github.com/weaveworks/cluster-api-provider-existinginfra/pkg/cluster/machine"
+	"github.com/weaveworks/wksctl/pkg/cluster/machine"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidationError is a validation error of a manifest, located in the manifest
+// file when possible.
+type ValidationError struct {
+	// Manifest is the path of the manifest file.
+	Manifest string `json:"manifest"`
+	// Line and Column locate the invalid field in the manifest, starting at 1.
+	// They are 0 when the field can't be located.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Field is the path of the invalid field, eg.
+	// "cluster.spec.clusterNetwork.pods.cidrBlocks".
+	Field string `json:"field"`
+	// Type is the kind of error, eg. "FieldValueInvalid".
+	Type     field.ErrorType `json:"type"`
+	BadValue interface{}     `json:"value,omitempty"`
+	Detail   string          `json:"detail"`
+	// Message is the full error message, as printed by the other commands.
+	Message string `json:"message"`
+}
+
+// Validate validates the cluster and machines manifests and the addons of the
+// cluster, without connecting to the machines. It returns the validation
+// errors, or an error if the manifests can't be parsed.
+func Validate(clusterManifestPath, machinesManifestPath string) ([]ValidationError, error) {
+	cluster, eic, err := ParseClusterManifest(clusterManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", clusterManifestPath, err)
+	}
+	populateCluster(cluster)
+	clusterErrors := validateCluster(cluster, eic, clusterManifestPath, "")
+
+	machines, bl, err := capeimachine.ParseManifest(machinesManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", machinesManifestPath, err)
+	}
+	capeimachine.Populate(machines)
+	machineErrors := machine.Validate(machines, bl)
+
+	var errors []ValidationError
+	for _, m := range []struct {
+		path   string
+		errors field.ErrorList
+	}{
+		{clusterManifestPath, clusterErrors},
+		{machinesManifestPath, machineErrors},
+	} {
+		if len(m.errors) == 0 {
+			continue
+		}
+		locator, err := newLocator(m.path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", m.path, err)
+		}
+		for _, e := range m.errors {
+			line, column := locator.locate(e.Field)
+			errors = append(errors, ValidationError{
+				Manifest: m.path,
+				Line:     line,
+				Column:   column,
+				Field:    e.Field,
+				Type:     e.Type,
+				BadValue: e.BadValue,
+				Detail:   e.Detail,
+				Message:  e.Error(),
+			})
+		}
+	}
+	return errors, nil
+}
+
+// locator finds the position of the fields of validation errors in a manifest.
+type locator struct {
+	docs []*yaml.Node
+}
+
+func newLocator(path string) (*locator, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l := &locator{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err == io.EOF {
+			return l, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if len(doc.Content) > 0 {
+			l.docs = append(l.docs, doc.Content[0])
+		}
+	}
+}
+
+// document returns the nth document of the given kind.
+func (l *locator) document(kind string, n int) *yaml.Node {
+	for _, doc := range l.docs {
+		if k := mappingValue(doc, "kind"); k == nil || k.Value != kind {
+			continue
+		}
+		if n == 0 {
+			return doc
+		}
+		n--
+	}
+	return nil
+}
+
+var machinePathPattern = regexp.MustCompile(`^machines\[(\d+)\]\.(.*)$`)
+
+// locate returns the position of the deepest node of the manifest on the path
+// of the field, 0, 0 if the field isn't in the manifest. Field paths are the
+// ones of validateCluster and machine.Validate.
+func (l *locator) locate(path string) (int, int) {
+	var doc *yaml.Node
+	const providerSpec = "cluster.spec.providerSpec.value."
+	switch {
+	case strings.HasPrefix(path, providerSpec):
+		doc = l.document("ExistingInfraCluster", 0)
+		path = "spec." + strings.TrimPrefix(path, providerSpec)
+	case strings.HasPrefix(path, "cluster."):
+		doc = l.document("Cluster", 0)
+		path = strings.TrimPrefix(path, "cluster.")
+	default:
+		m := machinePathPattern.FindStringSubmatch(path)
+		if m == nil {
+			return 0, 0
+		}
+		i, _ := strconv.Atoi(m[1])
+		doc = l.document("Machine", i)
+		path = m[2]
+	}
+	if doc == nil {
+		return 0, 0
+	}
+	node := findNode(doc, strings.Split(path, "."))
+	return node.Line, node.Column
+}
+
+var indexPattern = regexp.MustCompile(`^(.*)\[(\d+)\]$`)
+
+// findNode returns the deepest node on the path starting at node. Keys can
+// contain dots, eg. annotation names: the longest matching key is used.
+func findNode(node *yaml.Node, path []string) *yaml.Node {
+	if len(path) == 0 || node.Kind != yaml.MappingNode {
+		return node
+	}
+	for n := len(path); n > 0; n-- {
+		key := strings.Join(path[:n], ".")
+		index := -1
+		if m := indexPattern.FindStringSubmatch(key); m != nil {
+			key = m[1]
+			index, _ = strconv.Atoi(m[2])
+		}
+		keyNode, value := mappingEntry(node, key)
+		if value == nil {
+			continue
+		}
+		if index < 0 {
+			if found := findNode(value, path[n:]); found != value {
+				return found
+			}
+			// Errors on a field point at its key.
+			return keyNode
+		}
+		if value.Kind != yaml.SequenceNode || index >= len(value.Content) {
+			return keyNode
+		}
+		return findNode(value.Content[index], path[n:])
+	}
+	return node
+}
+
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, value := mappingEntry(node, key)
+	return value
+}
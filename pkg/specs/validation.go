@@ -2,12 +2,17 @@ package specs
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
 	"path/filepath"
 
 	existinginfrav1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
 	"github.com/weaveworks/launcher/pkg/kubectl"
 	"github.com/weaveworks/wksctl/pkg/addons"
+	"github.com/weaveworks/wksctl/pkg/kubernetes/schema"
+	"github.com/weaveworks/wksctl/pkg/registry"
+	"github.com/weaveworks/wksctl/pkg/utilities/manifest"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 )
@@ -136,16 +141,51 @@ func addonPath(i int, args ...string) *field.Path {
 	return clusterProviderPath(allArgs...)
 }
 
-func validateAddons(_ *clusterv1.Cluster, spec *existinginfrav1.ClusterSpec, manifestPath string) field.ErrorList {
+// kubectlPresent returns whether kubectl is installed, replaced in tests.
+var kubectlPresent = kubectl.LocalClient{}.IsPresent
+
+func validateAddons(cluster *clusterv1.Cluster, spec *existinginfrav1.ClusterSpec, manifestPath string) field.ErrorList {
 	// Addons require kubectl for their manifests to be applied.
-	kubectl := kubectl.LocalClient{}
-	if len(spec.Addons) > 0 && !kubectl.IsPresent() {
+	if len(spec.Addons) > 0 && !kubectlPresent() {
 		return field.ErrorList{
 			field.Invalid(clusterProviderPath("addons"), "", "addons require kubectl to be installed"),
 		}
 	}
 
+	transforms, err := AddonTransforms(cluster)
+	if err != nil {
+		return field.ErrorList{
+			field.Invalid(clusterPath("metadata", "annotations", AddonTransformsAnnotation), cluster.Annotations[AddonTransformsAnnotation], err.Error()),
+		}
+	}
+	// Transforms are keyed by addon name, catch the ones which would never
+	// be applied.
+	for name := range transforms {
+		if !hasAddon(spec, name) {
+			return field.ErrorList{
+				field.Invalid(clusterPath("metadata", "annotations", AddonTransformsAnnotation), name, "transforms of an addon which isn't part of the cluster"),
+			}
+		}
+	}
+
+	// Addons are built as deployed, and their images checked against the
+	// image policy of the cluster, reported by validateImagePolicy.
+	base, err := AddonBuildOptions(cluster, spec, manifest.DefaultNamespace)
+	if err != nil {
+		// The CNI network is reported by validateCNI, it only matters to the
+		// CNI addon.
+		base = addons.BuildOptions{
+			ImageRepository: spec.ImageRepository,
+			ExtVars:         AddonExtVars(cluster, spec, manifest.DefaultNamespace),
+		}
+	}
+	base.BasePath = filepath.Dir(manifestPath)
+	policy, _ := ParseImagePolicy(cluster)
+	pinned := PinAddonDigests(cluster)
+	var policyErrors field.ErrorList
+
 	// Validate addons and their parameters.
+	var validator *schema.Validator
 	for i, addonDesc := range spec.Addons {
 		addon, err := addons.Get(addonDesc.Name)
 		if err != nil {
@@ -153,10 +193,29 @@ func validateAddons(_ *clusterv1.Cluster, spec *existinginfrav1.ClusterSpec, man
 				field.Invalid(addonPath(i, addonDesc.Name), addonDesc.Name, err.Error()),
 			}
 		}
-		if err := addon.ValidateOptions(&addons.BuildOptions{
-			BasePath: filepath.Dir(manifestPath),
-			Params:   addonDesc.Params,
-		}); err != nil {
+		if spec.KubernetesVersion != "" {
+			supported, err := addon.SupportsKubernetesVersion(spec.KubernetesVersion)
+			if err != nil {
+				return field.ErrorList{
+					field.Invalid(clusterProviderPath("kubernetesVersion"), spec.KubernetesVersion, err.Error()),
+				}
+			}
+			if !supported {
+				return field.ErrorList{
+					field.Invalid(addonPath(i, addonDesc.Name), addonDesc.Name,
+						fmt.Sprintf("addon supports Kubernetes versions %s, not %s", addon.KubernetesVersions, spec.KubernetesVersion)),
+				}
+			}
+		}
+		if err := addon.ValidateReadiness(); err != nil {
+			return field.ErrorList{
+				field.Invalid(addonPath(i, addonDesc.Name), addonDesc.Name, err.Error()),
+			}
+		}
+		buildOptions := base
+		buildOptions.Params = addonDesc.Params
+		buildOptions.Deps = addonDesc.Deps
+		if err := addon.ValidateOptions(&buildOptions); err != nil {
 			if e, ok := err.(*addons.ParamError); ok {
 				return field.ErrorList{
 					field.Invalid(addonPath(i, addonDesc.Name, e.Param), addonDesc.Params[e.Param], err.Error()),
@@ -166,11 +225,134 @@ func validateAddons(_ *clusterv1.Cluster, spec *existinginfrav1.ClusterSpec, man
 				field.Invalid(addonPath(i, addonDesc.Name), addonDesc.Name, err.Error()),
 			}
 		}
+
+		// Validate the objects of the addon against their schema.
+		if validator == nil {
+			if validator, err = NewAddonValidator(spec.KubernetesVersion); err != nil {
+				return field.ErrorList{field.InternalError(clusterProviderPath("addons"), err)}
+			}
+		}
+		buildOptions.Transforms = transforms[addonDesc.Name]
+		images, err := validateAddonObjects(&addon, buildOptions, validator)
+		if err != nil {
+			errs, ok := err.(addons.ValidationErrors)
+			if !ok {
+				return field.ErrorList{
+					field.Invalid(addonPath(i, addonDesc.Name), addonDesc.Name, err.Error()),
+				}
+			}
+			var errors field.ErrorList
+			for _, e := range errs {
+				errors = append(errors, field.Invalid(addonPath(i, addonDesc.Name), e.Object, fmt.Sprintf("%s: %v", e.File, e.FieldError)))
+			}
+			return errors
+		}
+		if policy != nil {
+			policyErrors = append(policyErrors, checkAddonImages(policy, pinned, addonPath(i, addonDesc.Name), &addon, images)...)
+		}
+	}
+
+	return policyErrors
+}
+
+func hasAddon(spec *existinginfrav1.ClusterSpec, name string) bool {
+	for _, addonDesc := range spec.Addons {
+		if addonDesc.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateCNI(cluster *clusterv1.Cluster, spec *existinginfrav1.ClusterSpec, _ string) field.ErrorList {
+	name, ok := CNIAddon(cluster)
+	if !ok {
+		return field.ErrorList{}
+	}
+	cniPath := clusterPath("metadata", "annotations", CNIAddonAnnotation)
+	if spec.CNI != "" {
+		return field.ErrorList{
+			field.Invalid(cniPath, name, "the CNI addon can't be selected along with the cni script of the cluster spec"),
+		}
+	}
+	addon, err := addons.GetCNI(name)
+	if err != nil {
+		return field.ErrorList{
+			field.Invalid(cniPath, name, err.Error()),
+		}
+	}
+	if err := addon.ValidateReadiness(); err != nil {
+		return field.ErrorList{
+			field.Invalid(cniPath, name, err.Error()),
+		}
+	}
+	for i, addonDesc := range spec.Addons {
+		if addonDesc.Name == name {
+			return field.ErrorList{
+				field.Invalid(addonPath(i, addonDesc.Name), addonDesc.Name, "addon is already installed as the cluster CNI"),
+			}
+		}
+	}
+	if _, err := CNIMTU(cluster); err != nil {
+		return field.ErrorList{
+			field.Invalid(clusterPath("metadata", "annotations", CNIMTUAnnotation), cluster.Annotations[CNIMTUAnnotation], err.Error()),
+		}
 	}
 
+	pods := podCIDRBlocks(cluster)
+	podsPath := clusterPath("spec", "clusterNetwork", "pods", "cidrBlocks")
+	if len(pods) == 0 {
+		return field.ErrorList{
+			field.Required(podsPath, fmt.Sprintf("the %s CNI addon requires a pod network", name)),
+		}
+	}
+	_, cidr, err := net.ParseCIDR(pods[0])
+	if err != nil {
+		// Reported by validateCIDRBlocks.
+		return field.ErrorList{}
+	}
+	prefixLength, _ := cidr.Mask.Size()
+	if err := addon.ValidatePodCIDR(prefixLength); err != nil {
+		return field.ErrorList{
+			field.Invalid(podsPath, pods[0], err.Error()),
+		}
+	}
 	return field.ErrorList{}
 }
 
+// validateAddonObjects builds the addon, validates the objects it creates and
+// returns their images, as deployed but for pinning them to their digest.
+func validateAddonObjects(addon *addons.Addon, buildOptions addons.BuildOptions, validator *schema.Validator) ([]registry.Image, error) {
+	dir, err := ioutil.TempDir("", "wksctl-validate-addon")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	buildOptions.OutputDirectory = dir
+	// Resolving digests requires pulling from the registries, see the pinned
+	// argument of ImagePolicy.Check.
+	buildOptions.PinDigests = false
+	// The build is only validated and removed: secret parameters don't need
+	// sealing.
+	buildOptions.AllowPlaintextSecrets = true
+	manifests, err := addon.Build(buildOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build addon: %v", err)
+	}
+	err = addons.Validate(validator, manifests)
+	if errs, ok := err.(addons.ValidationErrors); ok {
+		// Report the manifest names, not the temporary files.
+		for i := range errs {
+			errs[i].File = filepath.Base(errs[i].File)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return manifestsImages(manifests)
+}
+
 // populateCluster mutates the cluster manifest:
 //   - fill in default values
 //   - expand ~ and resolve relative path in SSH key path
@@ -178,17 +360,22 @@ func populateCluster(cluster *clusterv1.Cluster) {
 	populateNetwork(cluster)
 }
 
-func validateCluster(cluster *clusterv1.Cluster, eic *existinginfrav1.ExistingInfraCluster, manifestPath string) field.ErrorList {
+// validateCluster validates the cluster manifest. controllerImage, if not
+// empty, overrides the image of the WKS controller, see validateImagePolicy.
+func validateCluster(cluster *clusterv1.Cluster, eic *existinginfrav1.ExistingInfraCluster, manifestPath, controllerImage string) field.ErrorList {
 	var errors field.ErrorList
 
 	for _, f := range []clusterValidationFunc{
 		validateCIDRBlocks,
 		validateServiceDomain,
 		validateSSHKeyEmpty,
+		validateCNI,
 		validateAddons,
+		validateRegistries,
 	} {
 		errors = append(errors, f(cluster, &eic.Spec, manifestPath)...)
 	}
+	errors = append(errors, validateImagePolicy(cluster, &eic.Spec, manifestPath, controllerImage)...)
 
 	return errors
 }
@@ -3,14 +3,23 @@ package specs
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	existinginfrav1 "github.com/weaveworks/cluster-api-provider-existinginfra/apis/cluster.weave.works/v1alpha3"
+	"github.com/weaveworks/wksctl/pkg/addons"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 )
 
+func TestMain(m *testing.M) {
+	// Validating addons doesn't run kubectl, only checks it's installed.
+	kubectlPresent = func() bool { return true }
+	os.Exit(m.Run())
+}
+
 const clusterMinimumValid = `
 apiVersion: "cluster.x-k8s.io/v1alpha3"
 kind: Cluster
@@ -273,6 +282,95 @@ spec:
       keytab: /foo
 `
 
+const clusterAddonUnsupportedKubernetesVersion = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  user: "vagrant"
+  kubernetesVersion: "1.14.1"
+  addons:
+  - name: weave-net
+`
+
+const clusterCNIAddon = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/cni-addon: cilium
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  user: "vagrant"
+`
+
+const clusterCNIUnknownAddon = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/cni-addon: foo
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  user: "vagrant"
+`
+
+const clusterCNIPodNetworkTooSmall = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/cni-addon: calico
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/27"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  user: "vagrant"
+`
+
 func clusterFromString(t *testing.T, s string) (*clusterv1.Cluster, *existinginfrav1.ExistingInfraCluster) {
 	f, err := ioutil.TempFile("", "")
 	assert.NoError(t, err)
@@ -286,6 +384,26 @@ func clusterFromString(t *testing.T, s string) (*clusterv1.Cluster, *existinginf
 	return cluster, eic
 }
 
+// validateClusterString validates a cluster manifest, written to a temporary
+// file as validation resolves addon files relative to the manifest.
+func validateClusterString(t *testing.T, s string) field.ErrorList {
+	return validateClusterStringWithControllerImage(t, s, "")
+}
+
+func validateClusterStringWithControllerImage(t *testing.T, s, controllerImage string) field.ErrorList {
+	f, err := ioutil.TempFile("", "")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(s)
+	assert.NoError(t, err)
+	err = f.Close()
+	assert.NoError(t, err)
+	cluster, eic, err := ParseClusterManifest(f.Name())
+	assert.NoError(t, err)
+	populateCluster(cluster)
+	return validateCluster(cluster, eic, f.Name(), controllerImage)
+}
+
 // Gather the list of fields paths that didn't pass validation.
 func fieldsInError(errors field.ErrorList) []string {
 	fields := []string{}
@@ -317,12 +435,20 @@ func TestValidateCluster(t *testing.T) {
 		{ClusterAddonBadName, []string{
 			"cluster.spec.providerSpec.value.addons[0].foo",
 		}},
+		{clusterAddonUnsupportedKubernetesVersion, []string{
+			"cluster.spec.providerSpec.value.addons[0].weave-net",
+		}},
+		{clusterCNIAddon, []string{}},
+		{clusterCNIUnknownAddon, []string{
+			"cluster.metadata.annotations.wksctl.weave.works/cni-addon",
+		}},
+		{clusterCNIPodNetworkTooSmall, []string{
+			"cluster.spec.clusterNetwork.pods.cidrBlocks",
+		}},
 	}
 
 	for _, test := range tests {
-		cluster, eic := clusterFromString(t, test.input)
-		populateCluster(cluster)
-		errors := validateCluster(cluster, eic, "/tmp/test.yaml")
+		errors := validateClusterString(t, test.input)
 		assert.Equal(t, len(test.errors), len(errors))
 		assert.Equal(t, test.errors, fieldsInError(errors))
 
@@ -333,6 +459,20 @@ func TestValidateCluster(t *testing.T) {
 	}
 }
 
+func TestValidateCNIAddonWithScript(t *testing.T) {
+	errors := validateClusterString(t, clusterCNIAddon+"  cni: 'kubectl apply -f cni.yaml'\n")
+	assert.Equal(t, []string{"cluster.metadata.annotations.wksctl.weave.works/cni-addon"}, fieldsInError(errors))
+}
+
+func TestValidateAddonsRequireKubectl(t *testing.T) {
+	kubectlPresent = func() bool { return false }
+	defer func() { kubectlPresent = func() bool { return true } }()
+	errors := validateClusterString(t, clusterCNIAddon)
+	assert.Empty(t, errors)
+	errors = validateClusterString(t, ClusterAddonBadName)
+	assert.Equal(t, []string{"cluster.spec.providerSpec.value.addons"}, fieldsInError(errors))
+}
+
 func TestValidCIDR(t *testing.T) {
 	tests := []struct {
 		input string
@@ -357,3 +497,364 @@ func TestDefaultClusterValues(t *testing.T) {
 	populateCluster(cluster)
 	assert.Equal(t, "cluster.local", cluster.Spec.ClusterNetwork.ServiceDomain)
 }
+
+func TestAddonBuildOptions(t *testing.T) {
+	cluster, eic := clusterFromString(t, clusterMinimumValid)
+	populateCluster(cluster)
+	cluster.Annotations = map[string]string{PinAddonDigestsAnnotation: "true", CNIMTUAnnotation: "1376"}
+	eic.Spec.ImageRepository = "registry.example.com"
+	options, err := AddonBuildOptions(cluster, &eic.Spec, "system")
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com", options.ImageRepository)
+	assert.True(t, options.PinDigests)
+	assert.Equal(t, addons.Network{PodCIDR: "192.168.0.0/16", MTU: 1376}, options.Network)
+	assert.Equal(t, "system", options.ExtVars[addons.ExtVarNamespace])
+
+	cluster.Annotations[CNIMTUAnnotation] = "-1"
+	_, err = AddonBuildOptions(cluster, &eic.Spec, "system")
+	assert.Error(t, err)
+}
+
+const clusterAddonInvalidObjects = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/addon-transforms: |
+      weave-net:
+      - jsonnet: replicas.jsonnet
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  user: "vagrant"
+  addons:
+  - name: weave-net
+`
+
+func TestValidateAddonObjects(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	manifestPath := filepath.Join(dir, "cluster.yaml")
+	assert.NoError(t, ioutil.WriteFile(manifestPath, []byte(clusterAddonInvalidObjects), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "replicas.jsonnet"), []byte(
+		"function(object) if object.kind == 'DaemonSet' then object + { spec+: { replicas: 2 } } else object"), 0600))
+
+	cluster, eic, err := ParseClusterManifest(manifestPath)
+	assert.NoError(t, err)
+	populateCluster(cluster)
+	errors := validateCluster(cluster, eic, manifestPath, "")
+	assert.Equal(t, []string{"cluster.spec.providerSpec.value.addons[0].weave-net"}, fieldsInError(errors))
+	assert.Contains(t, errors[0].Error(), "weave-net.yaml: spec.replicas: unknown field")
+	assert.Equal(t, "DaemonSet/kube-system/weave-net", errors[0].BadValue)
+}
+
+const clusterImagePolicy = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/cni-addon: cilium
+    wksctl.weave.works/image-policy: |
+      allowedRegistries: ["registry.example.com/mirror", "quay.io/bitnami"]
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  user: "vagrant"
+  imageRepository: registry.example.com/mirror
+`
+
+func TestValidateImagePolicy(t *testing.T) {
+	policyField := "cluster.metadata.annotations.wksctl.weave.works/image-policy"
+	controllerImage := "weaveworks/cluster-api-existinginfra-controller:v0.2.5"
+
+	// The default controller image is pulled from the Docker Hub.
+	errors := validateClusterString(t, clusterImagePolicy)
+	assert.Equal(t, []string{policyField}, fieldsInError(errors))
+	assert.Contains(t, errors[0].Detail, "controller manifest 04_controller.yaml: image weaveworks/cluster-api-existinginfra-controller")
+	// The controller image is rewritten by the image repository.
+	assert.Empty(t, validateClusterStringWithControllerImage(t, clusterImagePolicy, controllerImage))
+
+	// Images not rewritten by the image repository come from public
+	// registries.
+	errors = validateClusterStringWithControllerImage(t, strings.Replace(clusterImagePolicy, "  imageRepository: registry.example.com/mirror\n", "", 1),
+		"registry.example.com/mirror/cluster-api-existinginfra-controller:v0.2.5")
+	assert.NotEmpty(t, errors)
+	for _, err := range errors {
+		assert.Equal(t, "cluster.metadata.annotations.wksctl.weave.works/cni-addon", err.Field)
+		assert.Contains(t, err.Detail, "addon cilium: image "+err.BadValue.(string)+" is not from an allowed registry")
+	}
+
+	requireDigests := strings.Replace(clusterImagePolicy, "      allowedRegistries:", "      requireDigests: true\n      allowedRegistries:", 1)
+	errors = validateClusterStringWithControllerImage(t, requireDigests, controllerImage)
+	assert.NotEmpty(t, errors)
+	for _, err := range errors {
+		assert.Contains(t, err.Detail, "is not pinned to a digest")
+	}
+	// Pinning addon digests complies, controller images aren't pinned.
+	pinned := strings.Replace(requireDigests, "  annotations:\n", "  annotations:\n    "+PinAddonDigestsAnnotation+": \"true\"\n", 1)
+	errors = validateClusterStringWithControllerImage(t, pinned, controllerImage)
+	assert.Equal(t, []string{policyField}, fieldsInError(errors))
+	assert.Contains(t, errors[0].Detail, "controller manifest")
+
+	errors = validateClusterString(t, strings.Replace(clusterImagePolicy, "allowedRegistries", "registries", 1))
+	assert.Equal(t, []string{policyField}, fieldsInError(errors))
+}
+
+const clusterImagePolicyAddonTransforms = `
+apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/image-policy: |
+      allowedRegistries: ["docker.io/weaveworks", "quay.io/bitnami"]
+    wksctl.weave.works/addon-transforms: |
+      weave-net:
+      - jsonnet: image.jsonnet
+spec:
+  clusterNetwork:
+    services:
+      cidrBlocks: ["10.96.0.0/12"]
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  user: "vagrant"
+  addons:
+  - name: weave-net
+`
+
+func TestValidateImagePolicyAddonTransforms(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	manifestPath := filepath.Join(dir, "cluster.yaml")
+	assert.NoError(t, ioutil.WriteFile(manifestPath, []byte(clusterImagePolicyAddonTransforms), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "image.jsonnet"), []byte(
+		"function(object) if object.kind == 'DaemonSet' then object + { spec+: { template+: { spec+: { containers: [c + { image: 'busybox:1.32' } for c in super.containers] } } } } else object"), 0600))
+
+	cluster, eic, err := ParseClusterManifest(manifestPath)
+	assert.NoError(t, err)
+	populateCluster(cluster)
+	// Addon images are checked as built by validateAddons.
+	assert.Empty(t, validateImagePolicy(cluster, &eic.Spec, manifestPath, "weaveworks/cluster-api-existinginfra-controller:v0.2.5"))
+	errors := validateAddons(cluster, &eic.Spec, manifestPath)
+	assert.Equal(t, []string{"cluster.spec.providerSpec.value.addons[0].weave-net"}, fieldsInError(errors))
+	assert.Equal(t, "busybox:1.32", errors[0].BadValue)
+	assert.Contains(t, errors[0].Detail, "addon weave-net: image busybox:1.32 is not from an allowed registry")
+
+	delete(cluster.Annotations, AddonTransformsAnnotation)
+	assert.Empty(t, validateAddons(cluster, &eic.Spec, manifestPath))
+}
+
+func TestValidateAddonTransformsUnknownAddon(t *testing.T) {
+	manifest := strings.Replace(clusterImagePolicyAddonTransforms, "      weave-net:\n      - jsonnet", "      flux:\n      - jsonnet", 1)
+	cluster, eic := clusterFromString(t, manifest)
+	errors := validateAddons(cluster, &eic.Spec, "cluster.yaml")
+	assert.Equal(t, []string{"cluster.metadata.annotations.wksctl.weave.works/addon-transforms"}, fieldsInError(errors))
+	assert.Equal(t, "flux", errors[0].BadValue)
+}
+
+func TestImagePolicyCheck(t *testing.T) {
+	policy := &ImagePolicy{AllowedRegistries: []string{"registry.example.com", "docker.io/weaveworks/"}}
+	assert.NoError(t, policy.Check("registry.example.com/team/app:v1", false))
+	assert.NoError(t, policy.Check("weaveworks/flux:1.0", false))
+	assert.NoError(t, policy.Check("index.docker.io/weaveworks/flux:1.0", false))
+	assert.Error(t, policy.Check("registry.example.com.evil.org/app:v1", false))
+	assert.Error(t, policy.Check("docker.io/weaveworksx/flux:1.0", false))
+	assert.Error(t, policy.Check("busybox", false))
+
+	policy.RequireDigests = true
+	digest := "@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+	assert.NoError(t, policy.Check("registry.example.com/app:v1"+digest, false))
+	assert.NoError(t, policy.Check("registry.example.com/app:v1", true))
+	assert.EqualError(t, policy.Check("registry.example.com/app:v1", false), "image registry.example.com/app:v1 is not pinned to a digest")
+}
+
+func TestImagePolicyControllerImages(t *testing.T) {
+	policy := &ImagePolicy{AllowedRegistries: []string{"registry.example.com"}}
+	err := policy.CheckControllerImages("")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "controller manifest 04_controller.yaml: image weaveworks/cluster-api-existinginfra-controller")
+
+	policy.AllowedRegistries = append(policy.AllowedRegistries, "quay.io/bitnami")
+	assert.NoError(t, policy.CheckControllerImages("registry.example.com/wks/cluster-api-existinginfra-controller:v0.2.5"))
+}
+
+const machinesInconsistentVersions = `apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Machine
+metadata:
+  labels:
+    set: master
+  name: master
+spec:
+  clusterName: example
+  version: 1.18.9
+  infrastructureRef:
+    kind: ExistingInfraMachine
+    name: master-provider
+---
+apiVersion: cluster.weave.works/v1alpha3
+kind: ExistingInfraMachine
+metadata:
+  name: master-provider
+spec:
+  private:
+    address: 172.17.8.101
+---
+apiVersion: cluster.x-k8s.io/v1alpha3
+kind: Machine
+metadata:
+  labels:
+    set: worker
+  name: worker
+spec:
+  clusterName: example
+  version: 1.18.8
+  infrastructureRef:
+    kind: ExistingInfraMachine
+    name: worker-provider
+---
+apiVersion: cluster.weave.works/v1alpha3
+kind: ExistingInfraMachine
+metadata:
+  name: worker-provider
+spec:
+  private:
+    address: 172.17.8.102
+`
+
+func TestValidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	clusterPath := filepath.Join(dir, "cluster.yaml")
+	machinesPath := filepath.Join(dir, "machines.yaml")
+	assert.NoError(t, ioutil.WriteFile(clusterPath, []byte(clusterHasSSHKey), 0600))
+	assert.NoError(t, ioutil.WriteFile(machinesPath, []byte(machinesInconsistentVersions), 0600))
+
+	errors, err := Validate(clusterPath, machinesPath)
+	assert.NoError(t, err)
+	if assert.Len(t, errors, 2) {
+		assert.Equal(t, clusterPath, errors[0].Manifest)
+		assert.Equal(t, "cluster.spec.providerSpec.value.sshKeyPath", errors[0].Field)
+		assert.Equal(t, 21, errors[0].Line)
+		assert.Equal(t, 3, errors[0].Column)
+		assert.Equal(t, field.ErrorTypeInvalid, errors[0].Type)
+
+		assert.Equal(t, machinesPath, errors[1].Manifest)
+		assert.Equal(t, "machines[1].spec.version", errors[1].Field)
+		assert.Equal(t, 30, errors[1].Line)
+		assert.Equal(t, 3, errors[1].Column)
+		assert.Contains(t, errors[1].Message, `inconsistent kubernetes version, expected "1.18.9"`)
+	}
+
+	assert.NoError(t, ioutil.WriteFile(clusterPath, []byte(clusterMinimumValid), 0600))
+	assert.NoError(t, ioutil.WriteFile(machinesPath, []byte(strings.Replace(machinesInconsistentVersions, "1.18.8", "1.18.9", 1)), 0600))
+	errors, err = Validate(clusterPath, machinesPath)
+	assert.NoError(t, err)
+	assert.Empty(t, errors)
+
+	_, err = Validate(filepath.Join(dir, "missing.yaml"), machinesPath)
+	assert.Error(t, err)
+}
+
+func TestLocate(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cluster.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`apiVersion: "cluster.x-k8s.io/v1alpha3"
+kind: Cluster
+metadata:
+  name: example
+  annotations:
+    wksctl.weave.works/cni-mtu: "x"
+spec:
+  clusterNetwork:
+    pods:
+      cidrBlocks: ["192.168.0.0/16"]
+---
+apiVersion: "cluster.weave.works/v1alpha3"
+kind: "ExistingInfraCluster"
+metadata:
+  name: example
+spec:
+  addons:
+  - name: weave-net
+    params:
+      ipalloc: x
+`), 0600))
+	l, err := newLocator(path)
+	assert.NoError(t, err)
+	for _, test := range []struct {
+		field        string
+		line, column int
+	}{
+		{"cluster.metadata.annotations.wksctl.weave.works/cni-mtu", 6, 5},
+		{"cluster.spec.clusterNetwork.pods.cidrBlocks", 10, 7},
+		// The deepest existing node.
+		{"cluster.spec.clusterNetwork.services.cidrBlocks", 8, 3},
+		{"cluster.spec.providerSpec.value.addons[0].weave-net", 18, 5},
+		{"cluster.spec.providerSpec.value.addons[0].weave-net.ipalloc", 18, 5},
+		{"cluster.spec.providerSpec.value.addons[0].params.ipalloc", 20, 7},
+		{"machines[0].spec.version", 0, 0},
+		{"metadata.labels.set", 0, 0},
+	} {
+		line, column := l.locate(test.field)
+		assert.Equal(t, test.line, line, test.field)
+		assert.Equal(t, test.column, column, test.field)
+	}
+}
+
+func TestNewFromPathsSkipValidation(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	clusterPath := filepath.Join(dir, "cluster.yaml")
+	machinesPath := filepath.Join(dir, "machines.yaml")
+	assert.NoError(t, ioutil.WriteFile(clusterPath, []byte(clusterHasSSHKey), 0600))
+	assert.NoError(t, ioutil.WriteFile(machinesPath, []byte(machinesInconsistentVersions), 0600))
+
+	_, err = NewFromPaths(clusterPath, machinesPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), clusterPath+" failed validation")
+
+	// Machines are validated even when the cluster is valid.
+	assert.NoError(t, ioutil.WriteFile(clusterPath, []byte(clusterMinimumValid), 0600))
+	_, err = NewFromPaths(clusterPath, machinesPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), machinesPath+" failed validation")
+
+	SkipValidation = true
+	defer func() { SkipValidation = false }()
+	assert.NoError(t, ioutil.WriteFile(clusterPath, []byte(clusterHasSSHKey), 0600))
+	sp, err := NewFromPaths(clusterPath, machinesPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "example", sp.GetClusterName())
+}
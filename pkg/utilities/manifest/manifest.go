@@ -4,4 +4,8 @@ const (
 	DefaultNamespace = `weavek8sops`
 )
 
+// DefaultAddonNamespaces is the namespace of the weave-net addon.
+//
+// Deprecated: use addons.CNINamespaces, which reads the namespace of every CNI
+// addon from its addon.json.
 var DefaultAddonNamespaces = map[string]string{"weave-net": "kube-system"}
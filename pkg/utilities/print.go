@@ -1,6 +1,11 @@
 package utilities
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -10,3 +15,23 @@ func PrintErrors(errors field.ErrorList) {
 		log.Errorf("%v\n", e)
 	}
 }
+
+// PrintObject writes v to w in the given format, "json" or "yaml".
+func PrintObject(w io.Writer, format string, v interface{}) error {
+	var data []byte
+	var err error
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(v, "", "  ")
+		data = append(data, '\n')
+	case "yaml":
+		data, err = yaml.Marshal(v)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -32,3 +32,13 @@ func (t *Tarball) Unpack(destDir string) error {
 	}
 	return nil
 }
+
+// Pack creates the tarball with the contents of srcDir.
+func (t *Tarball) Pack(srcDir string) error {
+	flags := fmt.Sprintf("-c%s", t.Compression)
+	out, err := exec.Command("tar", flags, "-f", t.Path, "-C", srcDir, ".").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("tar failed: %v; combined output:\n%s", err, utilities.Indent(string(out), "\t"))
+	}
+	return nil
+}